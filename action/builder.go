@@ -11,16 +11,32 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 
 	"github.com/iotexproject/iotex-core/pkg/version"
 )
 
+// GovernanceProtocolID is the protocol ID of the governance protocol, the governance actions are the ethereum
+// transactions to the address of the protocol
+const GovernanceProtocolID = "governance"
+
 var (
-	_stakingProtocolEthAddr   = common.BytesToAddress(address.StakingProtocolAddrHash[:])
-	_rewardingProtocolEthAddr = common.BytesToAddress(address.RewardingProtocolAddrHash[:])
+	_stakingProtocolEthAddr     = common.BytesToAddress(address.StakingProtocolAddrHash[:])
+	_rewardingProtocolEthAddr   = common.BytesToAddress(address.RewardingProtocolAddrHash[:])
+	_governanceProtocolAddrHash = hash.Hash160b([]byte(GovernanceProtocolID))
+	_governanceProtocolEthAddr  = common.BytesToAddress(_governanceProtocolAddrHash[:])
 )
 
+// GovernanceProtocolAddr returns the address of the governance protocol
+func GovernanceProtocolAddr() address.Address {
+	addr, err := address.FromBytes(_governanceProtocolAddrHash[:])
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
 // Builder is used to build an action.
 type Builder struct {
 	act AbstractAction
@@ -217,8 +233,22 @@ func (b *EnvelopeBuilder) BuildRewardingAction(tx *types.Transaction) (Envelope,
 	return b.build(), nil
 }
 
+// BuildGovernanceAction loads governance action into envelope from abi-encoded data
+func (b *EnvelopeBuilder) BuildGovernanceAction(tx *types.Transaction) (Envelope, error) {
+	if !bytes.Equal(tx.To().Bytes(), _governanceProtocolEthAddr.Bytes()) {
+		return nil, ErrInvalidAct
+	}
+	b.setEnvelopeCommonFields(tx)
+	act, err := newGovernanceActionFromABIBinary(tx.Data())
+	if err != nil {
+		return nil, err
+	}
+	b.elp.payload = act
+	return b.build(), nil
+}
+
 func newStakingActionFromABIBinary(data []byte) (actionPayload, error) {
-	if len(data) <= 4 {
+	if len(data) < 4 {
 		return nil, ErrInvalidABI
 	}
 	if act, err := NewCreateStakeFromABIBinary(data); err == nil {
@@ -260,6 +290,15 @@ func newStakingActionFromABIBinary(data []byte) (actionPayload, error) {
 	if act, err := NewMigrateStakeFromABIBinary(data); err == nil {
 		return act, nil
 	}
+	if act, err := NewSplitStakeFromABIBinary(data); err == nil {
+		return act, nil
+	}
+	if act, err := NewMergeStakeFromABIBinary(data); err == nil {
+		return act, nil
+	}
+	if act, err := NewCandidateDeactivateFromABIBinary(data); err == nil {
+		return act, nil
+	}
 	return nil, ErrInvalidABI
 }
 
@@ -275,3 +314,16 @@ func newRewardingActionFromABIBinary(data []byte) (actionPayload, error) {
 	}
 	return nil, ErrInvalidABI
 }
+
+func newGovernanceActionFromABIBinary(data []byte) (actionPayload, error) {
+	if len(data) <= 4 {
+		return nil, ErrInvalidABI
+	}
+	if act, err := NewCreateProposalFromABIBinary(data); err == nil {
+		return act, nil
+	}
+	if act, err := NewVoteProposalFromABIBinary(data); err == nil {
+		return act, nil
+	}
+	return nil, ErrInvalidABI
+}
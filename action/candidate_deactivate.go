@@ -0,0 +1,127 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// CandidateDeactivateBaseIntrinsicGas represents the base intrinsic gas for CandidateDeactivate
+	CandidateDeactivateBaseIntrinsicGas = uint64(10000)
+
+	_candidateDeactivateInterfaceABI = `[
+		{
+			"inputs": [],
+			"name": "candidateDeactivate",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// _candidateDeactivateMethod is the interface of the abi encoding of candidate deactivate action
+	_candidateDeactivateMethod abi.Method
+	_                          EthCompatibleAction = (*CandidateDeactivate)(nil)
+)
+
+// CandidateDeactivate is the action for the owner to exit its candidate, the candidate is removed from the
+// candidate list at the next epoch, and its self-stake bucket becomes a normal bucket which can be unstaked. It has
+// no native proto, and can only be sent as an ethereum transaction in a tx container
+type CandidateDeactivate struct {
+	AbstractAction
+}
+
+func init() {
+	candidateDeactivateInterface, err := abi.JSON(strings.NewReader(_candidateDeactivateInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	_candidateDeactivateMethod, ok = candidateDeactivateInterface.Methods["candidateDeactivate"]
+	if !ok {
+		panic("fail to load the method")
+	}
+}
+
+// NewCandidateDeactivate returns a CandidateDeactivate action
+func NewCandidateDeactivate(nonce, gasLimit uint64, gasPrice *big.Int) *CandidateDeactivate {
+	return &CandidateDeactivate{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+	}
+}
+
+func (cd *CandidateDeactivate) isContainerOnly() bool {
+	return true
+}
+
+// IntrinsicGas returns the intrinsic gas of a CandidateDeactivate
+func (cd *CandidateDeactivate) IntrinsicGas() (uint64, error) {
+	return CandidateDeactivateBaseIntrinsicGas, nil
+}
+
+// Cost returns the total cost of a CandidateDeactivate
+func (cd *CandidateDeactivate) Cost() (*big.Int, error) {
+	intrinsicGas, err := cd.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the CandidateDeactivate")
+	}
+	fee := big.NewInt(0).Mul(cd.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return fee, nil
+}
+
+// EncodeABIBinary encodes data in abi encoding
+func (cd *CandidateDeactivate) EncodeABIBinary() ([]byte, error) {
+	return cd.encodeABIBinary()
+}
+
+func (cd *CandidateDeactivate) encodeABIBinary() ([]byte, error) {
+	data, err := _candidateDeactivateMethod.Inputs.Pack()
+	if err != nil {
+		return nil, err
+	}
+	return append(_candidateDeactivateMethod.ID, data...), nil
+}
+
+// NewCandidateDeactivateFromABIBinary parses the smart contract input and creates an action
+func NewCandidateDeactivateFromABIBinary(data []byte) (*CandidateDeactivate, error) {
+	// sanity check, the method has no input
+	if len(data) != 4 || !bytes.Equal(_candidateDeactivateMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	return &CandidateDeactivate{}, nil
+}
+
+// ToEthTx returns an Ethereum transaction which corresponds to this action
+func (cd *CandidateDeactivate) ToEthTx(_ uint32) (*types.Transaction, error) {
+	data, err := cd.encodeABIBinary()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    cd.Nonce(),
+		GasPrice: cd.GasPrice(),
+		Gas:      cd.GasLimit(),
+		To:       &_stakingProtocolEthAddr,
+		Value:    big.NewInt(0),
+		Data:     data,
+	}), nil
+}
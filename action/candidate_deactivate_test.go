@@ -0,0 +1,63 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestCandidateDeactivate(t *testing.T) {
+	require := require.New(t)
+	cd := NewCandidateDeactivate(1, 1000000, big.NewInt(10))
+	gas, err := cd.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(CandidateDeactivateBaseIntrinsicGas, gas)
+	cost, err := cd.Cost()
+	require.NoError(err)
+	require.Equal("100000", cost.String())
+
+	data, err := cd.EncodeABIBinary()
+	require.NoError(err)
+	require.Len(data, 4)
+	_, err = NewCandidateDeactivateFromABIBinary(data)
+	require.NoError(err)
+	_, err = NewCandidateDeactivateFromABIBinary(append(data, 0))
+	require.Error(err)
+	_, err = NewCandidateActivateFromABIBinary(data)
+	require.Error(err)
+	act, err := newStakingActionFromABIBinary(data)
+	require.NoError(err)
+	require.IsType(&CandidateDeactivate{}, act)
+
+	// the action has no native proto, and is only carried by the tx container
+	require.True(IsContainerOnly(cd))
+	require.False(IsContainerOnly(NewCandidateActivate(1, 1000000, big.NewInt(10), 3)))
+	selp := signedTxContainer(require, cd, identityset.PrivateKey(27))
+	h1, err := selp.Hash()
+	require.NoError(err)
+	ser, err := proto.Marshal(selp.Proto())
+	require.NoError(err)
+	pb := &iotextypes.Action{}
+	require.NoError(proto.Unmarshal(ser, pb))
+	selp2, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(pb)
+	require.NoError(err)
+	require.IsType(&txContainer{}, selp2.Action())
+	require.NoError(selp2.Action().(TxContainer).Unfold(selp2, context.Background(), stakingChecker))
+	require.IsType(cd, selp2.Action())
+	h2, err := selp2.Hash()
+	require.NoError(err)
+	require.Equal(h1, h2)
+	require.NoError(selp2.VerifySignature())
+}
@@ -26,6 +26,9 @@ const (
 	// CandidateUpdateBaseIntrinsicGas represents the base intrinsic gas for CandidateUpdate
 	CandidateUpdateBaseIntrinsicGas = uint64(10000)
 
+	// MaxCommissionRate is the max commission rate of a candidate in basis points
+	MaxCommissionRate = uint64(10000)
+
 	_candidateUpdateInterfaceABI = `[
 		{
 			"inputs": [
@@ -49,6 +52,34 @@ const (
 			"outputs": [],
 			"stateMutability": "nonpayable",
 			"type": "function"
+		},
+		{
+			"inputs": [
+				{
+					"internalType": "string",
+					"name": "name",
+					"type": "string"
+				},
+				{
+					"internalType": "address",
+					"name": "operatorAddress",
+					"type": "address"
+				},
+				{
+					"internalType": "address",
+					"name": "rewardAddress",
+					"type": "address"
+				},
+				{
+					"internalType": "uint64",
+					"name": "commissionRate",
+					"type": "uint64"
+				}
+			],
+			"name": "candidateUpdateWithCommission",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
 		}
 	]`
 )
@@ -56,7 +87,12 @@ const (
 var (
 	// _candidateUpdateMethod is the interface of the abi encoding of stake action
 	_candidateUpdateMethod abi.Method
-	_                      EthCompatibleAction = (*CandidateUpdate)(nil)
+	// _candidateUpdateWithCommissionMethod is the interface of the abi encoding of stake action with commission rate
+	_candidateUpdateWithCommissionMethod abi.Method
+	_                                    EthCompatibleAction = (*CandidateUpdate)(nil)
+
+	// ErrInvalidCommissionRate represents that the commission rate is invalid
+	ErrInvalidCommissionRate = errors.New("invalid commission rate")
 )
 
 // CandidateUpdate is the action to update a candidate
@@ -66,6 +102,10 @@ type CandidateUpdate struct {
 	name            string
 	operatorAddress address.Address
 	rewardAddress   address.Address
+	// commissionRate is the share of the epoch reward kept by the candidate in basis points, the rest is
+	// distributed to the voters, nil if not to update. The new rate applies from the next epoch. It has no
+	// native proto, so it is only carried by the candidateUpdateWithCommission method in a tx container
+	commissionRate *uint64
 }
 
 func init() {
@@ -78,6 +118,10 @@ func init() {
 	if !ok {
 		panic("fail to load the method")
 	}
+	_candidateUpdateWithCommissionMethod, ok = _candidateUpdateInterface.Methods["candidateUpdateWithCommission"]
+	if !ok {
+		panic("fail to load the method")
+	}
 }
 
 // NewCandidateUpdate creates a CandidateUpdate instance
@@ -114,6 +158,23 @@ func NewCandidateUpdate(
 	return cu, nil
 }
 
+// NewCandidateUpdateWithCommission creates a CandidateUpdate instance which also declares the commission rate,
+// it can only be sent as an ethereum transaction in a tx container
+func NewCandidateUpdateWithCommission(
+	nonce uint64,
+	name, operatorAddrStr, rewardAddrStr string,
+	commissionRate uint64,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) (*CandidateUpdate, error) {
+	cu, err := NewCandidateUpdate(nonce, name, operatorAddrStr, rewardAddrStr, gasLimit, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+	cu.commissionRate = &commissionRate
+	return cu, nil
+}
+
 // Name returns candidate name to update
 func (cu *CandidateUpdate) Name() string { return cu.name }
 
@@ -123,6 +184,14 @@ func (cu *CandidateUpdate) OperatorAddress() address.Address { return cu.operato
 // RewardAddress returns candidate rewardAddress to update
 func (cu *CandidateUpdate) RewardAddress() address.Address { return cu.rewardAddress }
 
+// CommissionRate returns candidate commission rate to update, and false if not to update
+func (cu *CandidateUpdate) CommissionRate() (uint64, bool) {
+	if cu.commissionRate == nil {
+		return 0, false
+	}
+	return *cu.commissionRate, true
+}
+
 // Serialize returns a raw byte stream of the CandidateUpdate struct
 func (cu *CandidateUpdate) Serialize() []byte {
 	return byteutil.Must(proto.Marshal(cu.Proto()))
@@ -141,7 +210,6 @@ func (cu *CandidateUpdate) Proto() *iotextypes.CandidateBasicInfo {
 	if cu.rewardAddress != nil {
 		act.RewardAddress = cu.rewardAddress.String()
 	}
-
 	return act
 }
 
@@ -168,9 +236,14 @@ func (cu *CandidateUpdate) LoadProto(pbAct *iotextypes.CandidateBasicInfo) error
 		}
 		cu.rewardAddress = rewardAddr
 	}
+
 	return nil
 }
 
+func (cu *CandidateUpdate) isContainerOnly() bool {
+	return cu.commissionRate != nil
+}
+
 // IntrinsicGas returns the intrinsic gas of a CandidateUpdate
 func (cu *CandidateUpdate) IntrinsicGas() (uint64, error) {
 	return CandidateUpdateBaseIntrinsicGas, nil
@@ -191,6 +264,9 @@ func (cu *CandidateUpdate) SanityCheck() error {
 	if !IsValidCandidateName(cu.Name()) {
 		return ErrInvalidCanName
 	}
+	if cu.commissionRate != nil && *cu.commissionRate > MaxCommissionRate {
+		return ErrInvalidCommissionRate
+	}
 
 	return cu.AbstractAction.SanityCheck()
 }
@@ -207,6 +283,16 @@ func (cu *CandidateUpdate) encodeABIBinary() ([]byte, error) {
 	if cu.rewardAddress == nil {
 		return nil, ErrAddress
 	}
+	if cu.commissionRate != nil {
+		data, err := _candidateUpdateWithCommissionMethod.Inputs.Pack(cu.name,
+			common.BytesToAddress(cu.operatorAddress.Bytes()),
+			common.BytesToAddress(cu.rewardAddress.Bytes()),
+			*cu.commissionRate)
+		if err != nil {
+			return nil, err
+		}
+		return append(_candidateUpdateWithCommissionMethod.ID, data...), nil
+	}
 	data, err := _candidateUpdateMethod.Inputs.Pack(cu.name,
 		common.BytesToAddress(cu.operatorAddress.Bytes()),
 		common.BytesToAddress(cu.rewardAddress.Bytes()))
@@ -223,12 +309,20 @@ func NewCandidateUpdateFromABIBinary(data []byte) (*CandidateUpdate, error) {
 		ok        bool
 		err       error
 		cu        CandidateUpdate
+		method    abi.Method
 	)
 	// sanity check
-	if len(data) <= 4 || !bytes.Equal(_candidateUpdateMethod.ID, data[:4]) {
+	switch {
+	case len(data) <= 4:
+		return nil, errDecodeFailure
+	case bytes.Equal(_candidateUpdateMethod.ID, data[:4]):
+		method = _candidateUpdateMethod
+	case bytes.Equal(_candidateUpdateWithCommissionMethod.ID, data[:4]):
+		method = _candidateUpdateWithCommissionMethod
+	default:
 		return nil, errDecodeFailure
 	}
-	if err := _candidateUpdateMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+	if err := method.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
 		return nil, err
 	}
 	if cu.name, ok = paramsMap["name"].(string); !ok {
@@ -240,6 +334,13 @@ func NewCandidateUpdateFromABIBinary(data []byte) (*CandidateUpdate, error) {
 	if cu.rewardAddress, err = ethAddrToNativeAddr(paramsMap["rewardAddress"]); err != nil {
 		return nil, err
 	}
+	if method.Name == _candidateUpdateWithCommissionMethod.Name {
+		rate, ok := paramsMap["commissionRate"].(uint64)
+		if !ok {
+			return nil, errDecodeFailure
+		}
+		cu.commissionRate = &rate
+	}
 	return &cu, nil
 }
 
@@ -6,10 +6,12 @@
 package action
 
 import (
+	"context"
 	"encoding/hex"
 	"math/big"
 	"testing"
 
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
@@ -98,3 +100,45 @@ func TestCandidateUpdateABIEncodeAndDecode(t *testing.T) {
 	_, err = stake.EncodeABIBinary()
 	require.Equal(ErrAddress, err)
 }
+
+func TestCandidateUpdateWithCommission(t *testing.T) {
+	require := require.New(t)
+	cu, err := NewCandidateUpdateWithCommission(_cuNonce, _cuName, _cuOperatorAddrStr, _cuRewardAddrStr, 1500, _cuGasLimit, _cuGasPrice)
+	require.NoError(err)
+	require.NoError(cu.SanityCheck())
+	rate, ok := cu.CommissionRate()
+	require.True(ok)
+	require.Equal(uint64(1500), rate)
+
+	require.True(IsContainerOnly(cu))
+
+	// the commission rate is only carried by the tx container
+	selp := signedTxContainer(require, cu, _senderKey)
+	require.NoError(selp.Action().(TxContainer).Unfold(selp, context.Background(), stakingChecker))
+	rate, ok = selp.Action().(*CandidateUpdate).CommissionRate()
+	require.True(ok)
+	require.Equal(uint64(1500), rate)
+	require.NotNil(selp.Proto().GetCore().GetTxContainer())
+
+	// without the commission rate
+	cu2 := &CandidateUpdate{}
+	require.NoError(cu2.LoadProto(&iotextypes.CandidateBasicInfo{Name: _cuName}))
+	_, ok = cu2.CommissionRate()
+	require.False(ok)
+	require.False(IsContainerOnly(cu2))
+
+	// abi
+	data, err := cu.EncodeABIBinary()
+	require.NoError(err)
+	require.Equal(_candidateUpdateWithCommissionMethod.ID, data[:4])
+	cu2, err = NewCandidateUpdateFromABIBinary(data)
+	require.NoError(err)
+	rate, ok = cu2.CommissionRate()
+	require.True(ok)
+	require.Equal(uint64(1500), rate)
+	require.Equal(_cuOperatorAddrStr, cu2.OperatorAddress().String())
+
+	cu, err = NewCandidateUpdateWithCommission(_cuNonce, _cuName, _cuOperatorAddrStr, _cuRewardAddrStr, MaxCommissionRate+1, _cuGasLimit, _cuGasPrice)
+	require.NoError(err)
+	require.Equal(ErrInvalidCommissionRate, cu.SanityCheck())
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// CreateProposalBaseIntrinsicGas represents the base intrinsic gas for CreateProposal
+	CreateProposalBaseIntrinsicGas = uint64(10000)
+	// CreateProposalPayloadGas represents the CreateProposal payload gas per uint
+	CreateProposalPayloadGas = uint64(100)
+	// ProposalDescriptionMaxLength is the max length of the description of a proposal
+	ProposalDescriptionMaxLength = 1024
+
+	_createProposalInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "string",
+					"name": "parameter",
+					"type": "string"
+				},
+				{
+					"internalType": "string",
+					"name": "value",
+					"type": "string"
+				},
+				{
+					"internalType": "string",
+					"name": "description",
+					"type": "string"
+				}
+			],
+			"name": "createProposal",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// _createProposalMethod is the interface of the abi encoding of create proposal action
+	_createProposalMethod abi.Method
+	_                     EthCompatibleAction = (*CreateProposal)(nil)
+)
+
+// CreateProposal is the action to propose a change of a chain parameter, which is voted by the stakers. It has no
+// native proto, and can only be sent as an ethereum transaction in a tx container
+type CreateProposal struct {
+	AbstractAction
+
+	parameter   string
+	value       string
+	description string
+}
+
+func init() {
+	createProposalInterface, err := abi.JSON(strings.NewReader(_createProposalInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	_createProposalMethod, ok = createProposalInterface.Methods["createProposal"]
+	if !ok {
+		panic("fail to load the method")
+	}
+}
+
+// NewCreateProposal returns a CreateProposal action
+func NewCreateProposal(nonce uint64, parameter, value, description string, gasLimit uint64, gasPrice *big.Int) *CreateProposal {
+	return &CreateProposal{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		parameter:   parameter,
+		value:       value,
+		description: description,
+	}
+}
+
+// Parameter returns the name of the parameter to change
+func (cp *CreateProposal) Parameter() string { return cp.parameter }
+
+// Value returns the proposed value of the parameter
+func (cp *CreateProposal) Value() string { return cp.value }
+
+// Description returns the description of the proposal
+func (cp *CreateProposal) Description() string { return cp.description }
+
+func (cp *CreateProposal) isContainerOnly() bool {
+	return true
+}
+
+// IntrinsicGas returns the intrinsic gas of a CreateProposal
+func (cp *CreateProposal) IntrinsicGas() (uint64, error) {
+	size := uint64(len(cp.parameter) + len(cp.value) + len(cp.description))
+	return CalculateIntrinsicGas(CreateProposalBaseIntrinsicGas, CreateProposalPayloadGas, size)
+}
+
+// Cost returns the total cost of a CreateProposal, the proposal fee is charged when the action is handled
+func (cp *CreateProposal) Cost() (*big.Int, error) {
+	intrinsicGas, err := cp.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the CreateProposal")
+	}
+	fee := big.NewInt(0).Mul(cp.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return fee, nil
+}
+
+// SanityCheck validates the variables in the action
+func (cp *CreateProposal) SanityCheck() error {
+	if cp.parameter == "" || cp.value == "" {
+		return errors.Wrap(ErrInvalidAct, "empty parameter or value")
+	}
+	if len(cp.description) > ProposalDescriptionMaxLength {
+		return errors.Wrapf(ErrInvalidAct, "description is longer than %d", ProposalDescriptionMaxLength)
+	}
+	return cp.AbstractAction.SanityCheck()
+}
+
+// EncodeABIBinary encodes data in abi encoding
+func (cp *CreateProposal) EncodeABIBinary() ([]byte, error) {
+	return cp.encodeABIBinary()
+}
+
+func (cp *CreateProposal) encodeABIBinary() ([]byte, error) {
+	data, err := _createProposalMethod.Inputs.Pack(cp.parameter, cp.value, cp.description)
+	if err != nil {
+		return nil, err
+	}
+	return append(_createProposalMethod.ID, data...), nil
+}
+
+// NewCreateProposalFromABIBinary parses the smart contract input and creates an action
+func NewCreateProposalFromABIBinary(data []byte) (*CreateProposal, error) {
+	var (
+		paramsMap = map[string]interface{}{}
+		ok        bool
+		cp        CreateProposal
+	)
+	// sanity check
+	if len(data) <= 4 || !bytes.Equal(_createProposalMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	if err := _createProposalMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	if cp.parameter, ok = paramsMap["parameter"].(string); !ok {
+		return nil, errDecodeFailure
+	}
+	if cp.value, ok = paramsMap["value"].(string); !ok {
+		return nil, errDecodeFailure
+	}
+	if cp.description, ok = paramsMap["description"].(string); !ok {
+		return nil, errDecodeFailure
+	}
+	return &cp, nil
+}
+
+// ToEthTx returns an Ethereum transaction which corresponds to this action
+func (cp *CreateProposal) ToEthTx(_ uint32) (*types.Transaction, error) {
+	data, err := cp.encodeABIBinary()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    cp.Nonce(),
+		GasPrice: cp.GasPrice(),
+		Gas:      cp.GasLimit(),
+		To:       &_governanceProtocolEthAddr,
+		Value:    big.NewInt(0),
+		Data:     data,
+	}), nil
+}
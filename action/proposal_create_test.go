@@ -0,0 +1,104 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestCreateProposal(t *testing.T) {
+	require := require.New(t)
+	cp := NewCreateProposal(1, "minGasPrice", "500000000000", "lower the min gas price", 1000000, big.NewInt(10))
+	require.Equal("minGasPrice", cp.Parameter())
+	require.Equal("500000000000", cp.Value())
+	require.Equal("lower the min gas price", cp.Description())
+	gas, err := cp.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(CreateProposalBaseIntrinsicGas+uint64(11+12+23)*CreateProposalPayloadGas, gas)
+	cost, err := cp.Cost()
+	require.NoError(err)
+	require.Equal(new(big.Int).Mul(big.NewInt(10), new(big.Int).SetUint64(gas)), cost)
+	require.NoError(cp.SanityCheck())
+
+	t.Run("sanity check", func(t *testing.T) {
+		require.ErrorIs(NewCreateProposal(1, "", "1", "", 1000000, big.NewInt(10)).SanityCheck(), ErrInvalidAct)
+		require.ErrorIs(NewCreateProposal(1, "minGasPrice", "", "", 1000000, big.NewInt(10)).SanityCheck(), ErrInvalidAct)
+		long := strings.Repeat("a", ProposalDescriptionMaxLength+1)
+		require.ErrorIs(NewCreateProposal(1, "minGasPrice", "1", long, 1000000, big.NewInt(10)).SanityCheck(), ErrInvalidAct)
+	})
+
+	t.Run("abi", func(t *testing.T) {
+		data, err := cp.EncodeABIBinary()
+		require.NoError(err)
+		cp2, err := NewCreateProposalFromABIBinary(data)
+		require.NoError(err)
+		require.Equal(cp.Parameter(), cp2.Parameter())
+		require.Equal(cp.Value(), cp2.Value())
+		require.Equal(cp.Description(), cp2.Description())
+		_, err = NewVoteProposalFromABIBinary(data)
+		require.Error(err)
+		act, err := newGovernanceActionFromABIBinary(data)
+		require.NoError(err)
+		require.IsType(&CreateProposal{}, act)
+
+		tx, err := cp.ToEthTx(0)
+		require.NoError(err)
+		require.Equal(_governanceProtocolEthAddr, *tx.To())
+		require.Equal(data, tx.Data())
+		elp, err := (&EnvelopeBuilder{}).BuildGovernanceAction(tx)
+		require.NoError(err)
+		require.IsType(&CreateProposal{}, elp.Action())
+		_, err = (&EnvelopeBuilder{}).BuildGovernanceAction(types.NewTx(&types.LegacyTx{
+			To:   &_stakingProtocolEthAddr,
+			Data: data,
+		}))
+		require.ErrorIs(err, ErrInvalidAct)
+	})
+
+	t.Run("envelope", func(t *testing.T) {
+		// the action has no native proto, and is only carried by the tx container
+		require.True(IsContainerOnly(cp))
+		selp := signedTxContainer(require, cp, identityset.PrivateKey(27))
+		ser, err := proto.Marshal(selp.Proto())
+		require.NoError(err)
+		pb := &iotextypes.Action{}
+		require.NoError(proto.Unmarshal(ser, pb))
+		require.NotNil(pb.GetCore().GetTxContainer())
+		selp2, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(pb)
+		require.NoError(err)
+		require.NoError(selp2.Action().(TxContainer).Unfold(selp2, context.Background(), stakingChecker))
+		require.IsType(&CreateProposal{}, selp2.Action())
+		h1, err := selp.Hash()
+		require.NoError(err)
+		h2, err := selp2.Hash()
+		require.NoError(err)
+		require.Equal(h1, h2)
+		require.NoError(selp2.VerifySignature())
+
+		// an execution to the governance protocol is loaded as execution
+		data, err := cp.EncodeABIBinary()
+		require.NoError(err)
+		ex, err := NewExecution(GovernanceProtocolAddr().String(), 1, big.NewInt(0), 1000000, big.NewInt(10), data)
+		require.NoError(err)
+		elp := (&EnvelopeBuilder{}).SetNonce(1).SetGasLimit(1000000).SetGasPrice(big.NewInt(10)).SetAction(ex).Build()
+		selp, err = Sign(elp, identityset.PrivateKey(27))
+		require.NoError(err)
+		selp2, err = (&Deserializer{}).SetEvmNetworkID(0).ActionToSealedEnvelope(selp.Proto())
+		require.NoError(err)
+		require.IsType(&Execution{}, selp2.Action())
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+// the choices of a proposal vote
+const (
+	ProposalVoteYes uint8 = iota + 1
+	ProposalVoteNo
+	ProposalVoteAbstain
+)
+
+const (
+	// VoteProposalBaseIntrinsicGas represents the base intrinsic gas for VoteProposal
+	VoteProposalBaseIntrinsicGas = uint64(10000)
+
+	_voteProposalInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "uint64",
+					"name": "proposalID",
+					"type": "uint64"
+				},
+				{
+					"internalType": "uint8",
+					"name": "choice",
+					"type": "uint8"
+				}
+			],
+			"name": "voteProposal",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// _voteProposalMethod is the interface of the abi encoding of vote proposal action
+	_voteProposalMethod abi.Method
+	_                   EthCompatibleAction = (*VoteProposal)(nil)
+)
+
+// VoteProposal is the action to vote for a proposal, the vote is weighted by the staked votes of the voter when
+// the proposal is tallied. It has no native proto, and can only be sent as an ethereum transaction in a tx container
+type VoteProposal struct {
+	AbstractAction
+
+	proposalID uint64
+	choice     uint8
+}
+
+func init() {
+	voteProposalInterface, err := abi.JSON(strings.NewReader(_voteProposalInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	_voteProposalMethod, ok = voteProposalInterface.Methods["voteProposal"]
+	if !ok {
+		panic("fail to load the method")
+	}
+}
+
+// NewVoteProposal returns a VoteProposal action
+func NewVoteProposal(nonce, proposalID uint64, choice uint8, gasLimit uint64, gasPrice *big.Int) *VoteProposal {
+	return &VoteProposal{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		proposalID: proposalID,
+		choice:     choice,
+	}
+}
+
+// ProposalID returns the id of the proposal to vote
+func (vp *VoteProposal) ProposalID() uint64 { return vp.proposalID }
+
+// Choice returns the choice of the vote
+func (vp *VoteProposal) Choice() uint8 { return vp.choice }
+
+func (vp *VoteProposal) isContainerOnly() bool {
+	return true
+}
+
+// IntrinsicGas returns the intrinsic gas of a VoteProposal
+func (vp *VoteProposal) IntrinsicGas() (uint64, error) {
+	return VoteProposalBaseIntrinsicGas, nil
+}
+
+// Cost returns the total cost of a VoteProposal
+func (vp *VoteProposal) Cost() (*big.Int, error) {
+	intrinsicGas, err := vp.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the VoteProposal")
+	}
+	fee := big.NewInt(0).Mul(vp.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return fee, nil
+}
+
+// SanityCheck validates the variables in the action
+func (vp *VoteProposal) SanityCheck() error {
+	if vp.choice < ProposalVoteYes || vp.choice > ProposalVoteAbstain {
+		return errors.Wrapf(ErrInvalidAct, "invalid choice %d", vp.choice)
+	}
+	return vp.AbstractAction.SanityCheck()
+}
+
+// EncodeABIBinary encodes data in abi encoding
+func (vp *VoteProposal) EncodeABIBinary() ([]byte, error) {
+	return vp.encodeABIBinary()
+}
+
+func (vp *VoteProposal) encodeABIBinary() ([]byte, error) {
+	data, err := _voteProposalMethod.Inputs.Pack(vp.proposalID, vp.choice)
+	if err != nil {
+		return nil, err
+	}
+	return append(_voteProposalMethod.ID, data...), nil
+}
+
+// NewVoteProposalFromABIBinary parses the smart contract input and creates an action
+func NewVoteProposalFromABIBinary(data []byte) (*VoteProposal, error) {
+	var (
+		paramsMap = map[string]interface{}{}
+		ok        bool
+		vp        VoteProposal
+	)
+	// sanity check
+	if len(data) <= 4 || !bytes.Equal(_voteProposalMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	if err := _voteProposalMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	if vp.proposalID, ok = paramsMap["proposalID"].(uint64); !ok {
+		return nil, errDecodeFailure
+	}
+	if vp.choice, ok = paramsMap["choice"].(uint8); !ok {
+		return nil, errDecodeFailure
+	}
+	return &vp, nil
+}
+
+// ToEthTx returns an Ethereum transaction which corresponds to this action
+func (vp *VoteProposal) ToEthTx(_ uint32) (*types.Transaction, error) {
+	data, err := vp.encodeABIBinary()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    vp.Nonce(),
+		GasPrice: vp.GasPrice(),
+		Gas:      vp.GasLimit(),
+		To:       &_governanceProtocolEthAddr,
+		Value:    big.NewInt(0),
+		Data:     data,
+	}), nil
+}
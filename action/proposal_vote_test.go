@@ -0,0 +1,69 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestVoteProposal(t *testing.T) {
+	require := require.New(t)
+	vp := NewVoteProposal(1, 3, ProposalVoteNo, 1000000, big.NewInt(10))
+	require.Equal(uint64(3), vp.ProposalID())
+	require.Equal(ProposalVoteNo, vp.Choice())
+	gas, err := vp.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(VoteProposalBaseIntrinsicGas, gas)
+	cost, err := vp.Cost()
+	require.NoError(err)
+	require.Equal("100000", cost.String())
+	require.NoError(vp.SanityCheck())
+	for _, choice := range []uint8{0, ProposalVoteAbstain + 1} {
+		require.ErrorIs(NewVoteProposal(1, 3, choice, 1000000, big.NewInt(10)).SanityCheck(), ErrInvalidAct)
+	}
+
+	data, err := vp.EncodeABIBinary()
+	require.NoError(err)
+	vp2, err := NewVoteProposalFromABIBinary(data)
+	require.NoError(err)
+	require.Equal(vp.ProposalID(), vp2.ProposalID())
+	require.Equal(vp.Choice(), vp2.Choice())
+	_, err = NewCreateProposalFromABIBinary(data)
+	require.Error(err)
+	act, err := newGovernanceActionFromABIBinary(data)
+	require.NoError(err)
+	require.IsType(&VoteProposal{}, act)
+
+	// the action has no native proto, and is only carried by the tx container
+	require.True(IsContainerOnly(vp))
+	selp := signedTxContainer(require, vp, identityset.PrivateKey(27))
+	ser, err := proto.Marshal(selp.Proto())
+	require.NoError(err)
+	pb := &iotextypes.Action{}
+	require.NoError(proto.Unmarshal(ser, pb))
+	selp2, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(pb)
+	require.NoError(err)
+	require.NoError(selp2.Action().(TxContainer).Unfold(selp2, context.Background(), stakingChecker))
+	require.IsType(&VoteProposal{}, selp2.Action())
+	vp2 = selp2.Action().(*VoteProposal)
+	require.Equal(vp.ProposalID(), vp2.ProposalID())
+	require.Equal(vp.Choice(), vp2.Choice())
+	h1, err := selp.Hash()
+	require.NoError(err)
+	h2, err := selp2.Hash()
+	require.NoError(err)
+	require.Equal(h1, h2)
+	require.NoError(selp2.VerifySignature())
+}
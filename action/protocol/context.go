@@ -33,6 +33,13 @@ type (
 
 	vmConfigContextKey struct{}
 
+	depositDeferrerContextKey struct{}
+
+	// DepositDeferrer takes a deposit into a fund shared by all actions, and applies it
+	// when the action is settled, so that the deposit does not become part of the
+	// read set of the action
+	DepositDeferrer func(func(StateManager) error)
+
 	// TipInfo contains the tip block information
 	TipInfo struct {
 		Height    uint64
@@ -125,6 +132,12 @@ type (
 		UseTxContainer                          bool
 		LimitedStakingContract                  bool
 		MigrateNativeStake                      bool
+		DistributeRewardToVoters                bool
+		SplitMergeNativeStake                   bool
+		CandidateDeactivation                   bool
+		EnableGovernance                        bool
+		SlashSelfStake                          bool
+		IndexCandidateVotes                     bool
 	}
 
 	// FeatureWithHeightCtx provides feature check functions.
@@ -275,6 +288,12 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			UseTxContainer:                          g.IsToBeEnabled(height),
 			LimitedStakingContract:                  !g.IsToBeEnabled(height),
 			MigrateNativeStake:                      g.IsToBeEnabled(height),
+			DistributeRewardToVoters:                g.IsToBeEnabled(height),
+			SplitMergeNativeStake:                   g.IsToBeEnabled(height),
+			CandidateDeactivation:                   g.IsToBeEnabled(height),
+			EnableGovernance:                        g.IsToBeEnabled(height),
+			SlashSelfStake:                          g.IsToBeEnabled(height),
+			IndexCandidateVotes:                     g.IsToBeEnabled(height),
 		},
 	)
 }
@@ -356,3 +375,14 @@ func GetVMConfigCtx(ctx context.Context) (vm.Config, bool) {
 	cfg, ok := ctx.Value(vmConfigContextKey{}).(vm.Config)
 	return cfg, ok
 }
+
+// WithDepositDeferrerCtx adds deposit deferrer to context
+func WithDepositDeferrerCtx(ctx context.Context, deferrer DepositDeferrer) context.Context {
+	return context.WithValue(ctx, depositDeferrerContextKey{}, deferrer)
+}
+
+// GetDepositDeferrerCtx returns the deposit deferrer from context
+func GetDepositDeferrerCtx(ctx context.Context) (DepositDeferrer, bool) {
+	deferrer, ok := ctx.Value(depositDeferrerContextKey{}).(DepositDeferrer)
+	return deferrer, ok
+}
@@ -32,3 +32,16 @@ func mustGetHelperCtx(ctx context.Context) HelperContext {
 	}
 	return hc
 }
+
+type simulationOverrideContextKey struct{}
+
+// WithSimulationOverrideCtx returns a new context with the overrides of the state and block in simulation
+func WithSimulationOverrideCtx(ctx context.Context, so SimulationOverride) context.Context {
+	return context.WithValue(ctx, simulationOverrideContextKey{}, so)
+}
+
+// GetSimulationOverrideCtx returns the overrides of the state and block in simulation
+func GetSimulationOverrideCtx(ctx context.Context) (SimulationOverride, bool) {
+	so, ok := ctx.Value(simulationOverrideContextKey{}).(SimulationOverride)
+	return so, ok
+}
@@ -644,8 +644,6 @@ func SimulateExecution(
 ) ([]byte, *action.Receipt, error) {
 	ctx, span := tracer.NewSpan(ctx, "evm.SimulateExecution")
 	defer span.End()
-	bcCtx := protocol.MustGetBlockchainCtx(ctx)
-	g := genesis.MustExtractGenesisContext(ctx)
 	ctx = protocol.WithActionCtx(
 		ctx,
 		protocol.ActionCtx{
@@ -653,24 +651,45 @@ func SimulateExecution(
 			ActionHash: hash.Hash256b(byteutil.Must(proto.Marshal(ex.Proto()))),
 		},
 	)
-	zeroAddr, err := address.FromString(address.ZeroAddress)
+	ctx, err := simulationCtx(ctx, sm)
 	if err != nil {
 		return nil, nil, err
 	}
-	ctx = protocol.WithBlockCtx(
-		ctx,
-		protocol.BlockCtx{
-			BlockHeight:    bcCtx.Tip.Height + 1,
-			BlockTimeStamp: bcCtx.Tip.Timestamp.Add(g.BlockInterval),
-			GasLimit:       g.BlockGasLimitByHeight(bcCtx.Tip.Height + 1),
-			Producer:       zeroAddr,
-		},
-	)
-
-	ctx = protocol.WithFeatureCtx(ctx)
 	return ExecuteContract(
 		ctx,
 		sm,
 		action.NewEvmTx(ex),
 	)
 }
+
+// simulationCtx returns the context of the block following the tip, and applies the simulation overrides in
+// the context to the block and the state manager
+func simulationCtx(ctx context.Context, sm protocol.StateManager) (context.Context, error) {
+	bcCtx := protocol.MustGetBlockchainCtx(ctx)
+	g := genesis.MustExtractGenesisContext(ctx)
+	zeroAddr, err := address.FromString(address.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+	blkCtx := protocol.BlockCtx{
+		BlockHeight:    bcCtx.Tip.Height + 1,
+		BlockTimeStamp: bcCtx.Tip.Timestamp.Add(g.BlockInterval),
+		GasLimit:       g.BlockGasLimitByHeight(bcCtx.Tip.Height + 1),
+		Producer:       zeroAddr,
+	}
+	override, hasOverride := GetSimulationOverrideCtx(ctx)
+	if hasOverride {
+		if err := override.Block.apply(&blkCtx, g.Blockchain); err != nil {
+			return nil, err
+		}
+	}
+	ctx = protocol.WithBlockCtx(ctx, blkCtx)
+
+	ctx = protocol.WithFeatureCtx(ctx)
+	if hasOverride {
+		if err := override.State.apply(ctx, sm); err != nil {
+			return nil, errors.Wrap(err, "failed to apply state override")
+		}
+	}
+	return ctx, nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/accountpb"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+)
+
+type (
+	// AccountOverride overrides the state of an account in simulation, a nil field is not overridden
+	AccountOverride struct {
+		// Nonce is the pending nonce of the account
+		Nonce   *uint64
+		Code    []byte
+		Balance *big.Int
+		// State replaces the whole storage of the account
+		State map[common.Hash]common.Hash
+		// StateDiff overrides the given slots of the storage
+		StateDiff map[common.Hash]common.Hash
+	}
+
+	// StateOverride is the overrides of accounts in simulation
+	StateOverride map[common.Address]AccountOverride
+
+	// BlockOverride overrides the context of the simulated block, a nil field is not overridden
+	BlockOverride struct {
+		Number   *uint64
+		Time     *uint64
+		Coinbase *common.Address
+	}
+
+	// SimulationOverride is the overrides of the state and block in simulation
+	SimulationOverride struct {
+		State StateOverride
+		Block *BlockOverride
+	}
+)
+
+func (bo *BlockOverride) apply(blkCtx *protocol.BlockCtx, g genesis.Blockchain) error {
+	if bo == nil {
+		return nil
+	}
+	if bo.Number != nil {
+		blkCtx.BlockHeight = *bo.Number
+		blkCtx.GasLimit = g.BlockGasLimitByHeight(*bo.Number)
+	}
+	if bo.Time != nil {
+		blkCtx.BlockTimeStamp = time.Unix(int64(*bo.Time), 0)
+	}
+	if bo.Coinbase != nil {
+		producer, err := address.FromBytes(bo.Coinbase.Bytes())
+		if err != nil {
+			return err
+		}
+		blkCtx.Producer = producer
+	}
+	return nil
+}
+
+// apply writes the overrides into the state manager, which is supposed to be a working set
+// discarded after the simulation
+func (so StateOverride) apply(ctx context.Context, sm protocol.StateManager) error {
+	if len(so) == 0 {
+		return nil
+	}
+	stateDB, err := prepareStateDB(ctx, sm)
+	if err != nil {
+		return err
+	}
+	evmAddrs := make([]common.Address, 0, len(so))
+	for evmAddr := range so {
+		evmAddrs = append(evmAddrs, evmAddr)
+	}
+	sort.Slice(evmAddrs, func(i, j int) bool { return bytes.Compare(evmAddrs[i][:], evmAddrs[j][:]) < 0 })
+
+	// the accounts are stored before overriding the code and storage, which load the stored accounts
+	for _, evmAddr := range evmAddrs {
+		override := so[evmAddr]
+		if override.State != nil && override.StateDiff != nil {
+			return errors.Errorf("account %s has both state and stateDiff overrides", evmAddr.Hex())
+		}
+		if override.Nonce == nil && override.Balance == nil && override.State == nil {
+			continue
+		}
+		addr, err := address.FromBytes(evmAddr.Bytes())
+		if err != nil {
+			return err
+		}
+		account, err := accountutil.LoadOrCreateAccount(sm, addr, stateDB.accountCreationOpts()...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load account %s", evmAddr.Hex())
+		}
+		if override.Nonce != nil {
+			// the pending nonce of a zero-nonce account is its nonce
+			pb := account.ToProto()
+			pb.Type, pb.Nonce = accountpb.AccountType_ZERO_NONCE, *override.Nonce
+			account.FromProto(pb)
+		}
+		if override.Balance != nil {
+			if override.Balance.Sign() < 0 {
+				return errors.Errorf("negative balance override of account %s", evmAddr.Hex())
+			}
+			account.Balance = new(big.Int).Set(override.Balance)
+		}
+		if override.State != nil {
+			// start from an empty storage trie
+			account.Root = hash.ZeroHash256
+		}
+		if err := accountutil.StoreAccount(sm, addr, account); err != nil {
+			return errors.Wrapf(err, "failed to store account %s", evmAddr.Hex())
+		}
+	}
+	for _, evmAddr := range evmAddrs {
+		override := so[evmAddr]
+		if override.Code != nil {
+			stateDB.SetCode(evmAddr, override.Code)
+		}
+		for k, v := range override.State {
+			stateDB.SetState(evmAddr, k, v)
+		}
+		for k, v := range override.StateDiff {
+			stateDB.SetState(evmAddr, k, v)
+		}
+	}
+	if err := stateDB.Error(); err != nil {
+		return err
+	}
+	return stateDB.CommitContracts()
+}
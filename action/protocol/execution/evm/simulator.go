@@ -0,0 +1,62 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/pkg/tracer"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// Simulator simulates executions one after another on a state manager, which is supposed to be a working set
+// discarded after the simulation, so that each execution sees the state changes of the preceding ones
+type Simulator struct {
+	sm protocol.StateManager
+}
+
+// NewSimulator creates a simulator on the state manager
+func NewSimulator(sm protocol.StateManager) *Simulator {
+	return &Simulator{sm: sm}
+}
+
+// Simulate simulates the execution on the state left by the preceding executions. The simulation overrides in
+// the context are applied before the execution, and the nonce of the execution is set to the pending nonce of
+// the caller
+func (s *Simulator) Simulate(ctx context.Context, caller address.Address, ex *action.Execution) ([]byte, *action.Receipt, error) {
+	ctx, span := tracer.NewSpan(ctx, "evm.Simulator.Simulate")
+	defer span.End()
+	// the action hash depends on the nonce, which is read after the overrides are applied
+	ctx, err := simulationCtx(protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: caller}), s.sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	state, err := accountutil.AccountState(ctx, s.sm, caller)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load account %s", caller.String())
+	}
+	if protocol.MustGetFeatureCtx(ctx).RefactorFreshAccountConversion {
+		ex.SetNonce(state.PendingNonceConsideringFreshAccount())
+	} else {
+		ex.SetNonce(state.PendingNonce())
+	}
+	ctx = protocol.WithActionCtx(
+		ctx,
+		protocol.ActionCtx{
+			Caller:     caller,
+			ActionHash: hash.Hash256b(byteutil.Must(proto.Marshal(ex.Proto()))),
+		},
+	)
+	return ExecuteContract(ctx, s.sm, action.NewEvmTx(ex))
+}
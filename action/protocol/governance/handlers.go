@@ -0,0 +1,219 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// the parameters which can be proposed to change
+const (
+	// ParameterBlockReward is the block reward in rau
+	ParameterBlockReward = "blockReward"
+	// ParameterEpochReward is the epoch reward in rau
+	ParameterEpochReward = "epochReward"
+	// ParameterMinGasPrice is the min gas price of the actpool in rau
+	ParameterMinGasPrice = "minGasPrice"
+	// ParameterBlockGasLimit is the gas limit of a block
+	ParameterBlockGasLimit = "blockGasLimit"
+	// ParameterUpgrade is a free-form value describing a protocol upgrade, e.g. the name and height of a hard fork
+	ParameterUpgrade = "upgrade"
+)
+
+const (
+	// HandleCreateProposal is the topic of the receipt log of CreateProposal
+	HandleCreateProposal = "createProposal"
+	// HandleVoteProposal is the topic of the receipt log of VoteProposal
+	HandleVoteProposal = "voteProposal"
+)
+
+var (
+	_parameterValidators = map[string]func(string) error{
+		ParameterBlockReward:   validateAmount,
+		ParameterEpochReward:   validateAmount,
+		ParameterMinGasPrice:   validateAmount,
+		ParameterBlockGasLimit: validateGasLimit,
+		ParameterUpgrade:       func(string) error { return nil },
+	}
+
+	errProposalNotExist = &handleError{
+		err:           errors.New("proposal does not exist"),
+		failureStatus: iotextypes.ReceiptStatus_Failure,
+	}
+	errProposalClosed = &handleError{
+		err:           errors.New("proposal is closed for voting"),
+		failureStatus: iotextypes.ReceiptStatus_Failure,
+	}
+)
+
+type handleError struct {
+	err           error
+	failureStatus iotextypes.ReceiptStatus
+}
+
+func (h *handleError) Error() string {
+	return h.err.Error()
+}
+
+func (h *handleError) ReceiptStatus() uint64 {
+	return uint64(h.failureStatus)
+}
+
+func (p *Protocol) handleCreateProposal(ctx context.Context, act *action.CreateProposal, sm protocol.StateManager,
+) (*action.Log, []*action.TransactionLog, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+
+	// check the caller's balance for the proposal fee and the gas fee
+	fee := p.cfg.ProposalFee()
+	caller, err := accountutil.LoadAccount(sm, actCtx.Caller)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load the account of caller %s", actCtx.Caller.String())
+	}
+	gasFee := big.NewInt(0).Mul(actCtx.GasPrice, big.NewInt(0).SetUint64(actCtx.IntrinsicGas))
+	if !caller.HasSufficientBalance(new(big.Int).Add(fee, gasFee)) {
+		return nil, nil, &handleError{
+			err:           errors.Wrapf(state.ErrNotEnoughBalance, "caller %s balance not enough", actCtx.Caller.String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrNotEnoughBalance,
+		}
+	}
+	// put the proposal fee to reward pool
+	var tLogs []*action.TransactionLog
+	if fee.Sign() > 0 {
+		if _, err := p.depositGas(ctx, sm, fee); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to deposit proposal fee")
+		}
+		tLogs = append(tLogs, &action.TransactionLog{
+			Type:      iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND,
+			Sender:    actCtx.Caller.String(),
+			Recipient: address.RewardingPoolAddr,
+			Amount:    fee,
+		})
+	}
+
+	count, err := p.proposalCount(sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	startEpoch := rp.GetEpochNum(blkCtx.BlockHeight)
+	proposal := &Proposal{
+		ID:           count + 1,
+		Proposer:     actCtx.Caller,
+		Parameter:    act.Parameter(),
+		Value:        act.Value(),
+		Description:  act.Description(),
+		CreateHeight: blkCtx.BlockHeight,
+		StartEpoch:   startEpoch,
+		EndEpoch:     startEpoch + p.cfg.VotingEpochs - 1,
+		Status:       ProposalActive,
+		YesVotes:     big.NewInt(0),
+		NoVotes:      big.NewInt(0),
+		AbstainVotes: big.NewInt(0),
+	}
+	if err := p.putProposal(sm, proposal); err != nil {
+		return nil, nil, err
+	}
+	if err := p.putState(sm, _proposalCountKey, counter(proposal.ID)); err != nil {
+		return nil, nil, err
+	}
+	ids, err := p.activeProposalIDs(sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := p.putState(sm, _activeProposalsKey, append(ids, proposal.ID)); err != nil {
+		return nil, nil, err
+	}
+
+	return p.receiptLog(ctx, HandleCreateProposal, proposal.ID, actCtx.Caller, byteutil.Uint64ToBytesBigEndian(proposal.EndEpoch)), tLogs, nil
+}
+
+func (p *Protocol) handleVoteProposal(ctx context.Context, act *action.VoteProposal, sm protocol.StateManager,
+) (*action.Log, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+
+	proposal, err := p.proposal(sm, act.ProposalID())
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+		return nil, errProposalNotExist
+	case err != nil:
+		return nil, err
+	}
+	if proposal.Status != ProposalActive || rp.GetEpochNum(blkCtx.BlockHeight) > proposal.EndEpoch {
+		return nil, errProposalClosed
+	}
+	if err := p.vote(sm, proposal.ID, actCtx.Caller, act.Choice()); err != nil {
+		return nil, err
+	}
+
+	return p.receiptLog(ctx, HandleVoteProposal, proposal.ID, actCtx.Caller, []byte{act.Choice()}), nil
+}
+
+// receiptLog returns the log with the topics of the handler name, the proposal id and the caller
+func (p *Protocol) receiptLog(ctx context.Context, topic string, id uint64, caller address.Address, data []byte) *action.Log {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	return &action.Log{
+		Address: p.addr.String(),
+		Topics: action.Topics{
+			hash.BytesToHash256([]byte(topic)),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(id)),
+			hash.BytesToHash256(caller.Bytes()),
+		},
+		Data:        data,
+		BlockHeight: blkCtx.BlockHeight,
+		ActionHash:  actCtx.ActionHash,
+	}
+}
+
+func validateParameter(parameter, value string) error {
+	validator, ok := _parameterValidators[parameter]
+	if !ok {
+		return errors.Wrapf(action.ErrInvalidAct, "unknown parameter %s", parameter)
+	}
+	if err := validator(value); err != nil {
+		return errors.Wrapf(action.ErrInvalidAct, "invalid value %s of parameter %s: %v", value, parameter, err)
+	}
+	return nil
+}
+
+func validateAmount(value string) error {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return errors.New("not a decimal number")
+	}
+	if amount.Sign() < 0 {
+		return action.ErrNegativeValue
+	}
+	return nil
+}
+
+func validateGasLimit(value string) error {
+	limit, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	if limit == 0 {
+		return errors.New("zero gas limit")
+	}
+	return nil
+}
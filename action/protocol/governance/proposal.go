@@ -0,0 +1,242 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/governance/governancepb"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// ProposalStatus is the status of a proposal
+type ProposalStatus uint32
+
+// the statuses of a proposal
+const (
+	// ProposalActive means the proposal is open for voting, or waiting to be tallied
+	ProposalActive ProposalStatus = iota
+	// ProposalPassed means the share of yes votes is over the pass threshold when the proposal is tallied
+	ProposalPassed
+	// ProposalRejected means the share of yes votes is not over the pass threshold when the proposal is tallied
+	ProposalRejected
+)
+
+type (
+	// Vote is the choice of a voter on a proposal
+	Vote struct {
+		Voter  address.Address
+		Choice uint8
+	}
+
+	// Proposal is a proposal of changing a chain parameter. The votes are weighted by the staked votes of the voters
+	// when the proposal is tallied, which is at the start of the epoch after the voting period. The votes are stored
+	// under their own keys, so that a vote does not rewrite the proposal
+	Proposal struct {
+		ID           uint64
+		Proposer     address.Address
+		Parameter    string
+		Value        string
+		Description  string
+		CreateHeight uint64
+		StartEpoch   uint64
+		EndEpoch     uint64
+		Status       ProposalStatus
+		YesVotes     *big.Int
+		NoVotes      *big.Int
+		AbstainVotes *big.Int
+		Votes        []*Vote
+	}
+
+	// proposalIDs is the list of the ids of the proposals
+	proposalIDs []uint64
+
+	// voterAddr is the address of a voter, indexed by the order of the first vote of the voter on a proposal
+	voterAddr struct {
+		address.Address
+	}
+
+	// counter is the number of the proposals ever created, or the number of the voters of a proposal
+	counter uint64
+)
+
+// Proto converts the proposal to protobuf
+func (p *Proposal) Proto() *governancepb.Proposal {
+	pb := &governancepb.Proposal{
+		Id:           p.ID,
+		Proposer:     p.Proposer.String(),
+		Parameter:    p.Parameter,
+		Value:        p.Value,
+		Description:  p.Description,
+		CreateHeight: p.CreateHeight,
+		StartEpoch:   p.StartEpoch,
+		EndEpoch:     p.EndEpoch,
+		Status:       uint32(p.Status),
+		YesVotes:     p.YesVotes.String(),
+		NoVotes:      p.NoVotes.String(),
+		AbstainVotes: p.AbstainVotes.String(),
+		Votes:        make([]*governancepb.Vote, 0, len(p.Votes)),
+	}
+	for _, v := range p.Votes {
+		pb.Votes = append(pb.Votes, &governancepb.Vote{
+			Voter:  v.Voter.String(),
+			Choice: uint32(v.Choice),
+		})
+	}
+	return pb
+}
+
+// LoadProto loads the proposal from protobuf
+func (p *Proposal) LoadProto(pb *governancepb.Proposal) error {
+	proposer, err := address.FromString(pb.GetProposer())
+	if err != nil {
+		return errors.Wrapf(err, "invalid proposer %s", pb.GetProposer())
+	}
+	amounts := make([]*big.Int, 3)
+	for i, s := range []string{pb.GetYesVotes(), pb.GetNoVotes(), pb.GetAbstainVotes()} {
+		amount, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return errors.Errorf("invalid votes %s", s)
+		}
+		amounts[i] = amount
+	}
+	var votes []*Vote
+	for _, v := range pb.GetVotes() {
+		voter, err := address.FromString(v.GetVoter())
+		if err != nil {
+			return errors.Wrapf(err, "invalid voter %s", v.GetVoter())
+		}
+		votes = append(votes, &Vote{Voter: voter, Choice: uint8(v.GetChoice())})
+	}
+	*p = Proposal{
+		ID:           pb.GetId(),
+		Proposer:     proposer,
+		Parameter:    pb.GetParameter(),
+		Value:        pb.GetValue(),
+		Description:  pb.GetDescription(),
+		CreateHeight: pb.GetCreateHeight(),
+		StartEpoch:   pb.GetStartEpoch(),
+		EndEpoch:     pb.GetEndEpoch(),
+		Status:       ProposalStatus(pb.GetStatus()),
+		YesVotes:     amounts[0],
+		NoVotes:      amounts[1],
+		AbstainVotes: amounts[2],
+		Votes:        votes,
+	}
+	return nil
+}
+
+// Serialize serializes the proposal into bytes, without the votes
+func (p *Proposal) Serialize() ([]byte, error) {
+	pb := p.Proto()
+	pb.Votes = nil
+	return proto.Marshal(pb)
+}
+
+// Deserialize deserializes bytes into the proposal
+func (p *Proposal) Deserialize(data []byte) error {
+	pb := &governancepb.Proposal{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return err
+	}
+	return p.LoadProto(pb)
+}
+
+// Serialize serializes the vote into bytes
+func (v *Vote) Serialize() ([]byte, error) {
+	return proto.Marshal(&governancepb.Vote{
+		Voter:  v.Voter.String(),
+		Choice: uint32(v.Choice),
+	})
+}
+
+// Deserialize deserializes bytes into the vote
+func (v *Vote) Deserialize(data []byte) error {
+	pb := &governancepb.Vote{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return err
+	}
+	voter, err := address.FromString(pb.GetVoter())
+	if err != nil {
+		return errors.Wrapf(err, "invalid voter %s", pb.GetVoter())
+	}
+	*v = Vote{Voter: voter, Choice: uint8(pb.GetChoice())}
+	return nil
+}
+
+// tally sums up the weights of the voters by their choices
+func (p *Proposal) tally(weights []*big.Int) {
+	p.YesVotes, p.NoVotes, p.AbstainVotes = big.NewInt(0), big.NewInt(0), big.NewInt(0)
+	for i, v := range p.Votes {
+		switch v.Choice {
+		case action.ProposalVoteYes:
+			p.YesVotes.Add(p.YesVotes, weights[i])
+		case action.ProposalVoteNo:
+			p.NoVotes.Add(p.NoVotes, weights[i])
+		case action.ProposalVoteAbstain:
+			p.AbstainVotes.Add(p.AbstainVotes, weights[i])
+		}
+	}
+}
+
+// passed returns whether the share of yes votes among the yes and no votes is over the threshold in percentage
+func (p *Proposal) passed(passThreshold uint64) bool {
+	total := new(big.Int).Add(p.YesVotes, p.NoVotes)
+	if total.Sign() == 0 {
+		return false
+	}
+	yes := new(big.Int).Mul(p.YesVotes, big.NewInt(100))
+	return yes.Cmp(total.Mul(total, new(big.Int).SetUint64(passThreshold))) > 0
+}
+
+// Serialize serializes the proposal ids into bytes
+func (ids proposalIDs) Serialize() ([]byte, error) {
+	return proto.Marshal(&governancepb.ProposalIDs{Ids: ids})
+}
+
+// Deserialize deserializes bytes into the proposal ids
+func (ids *proposalIDs) Deserialize(data []byte) error {
+	pb := &governancepb.ProposalIDs{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return err
+	}
+	*ids = pb.GetIds()
+	return nil
+}
+
+// Serialize serializes the voter into bytes
+func (v voterAddr) Serialize() ([]byte, error) {
+	return v.Bytes(), nil
+}
+
+// Deserialize deserializes bytes into the voter
+func (v *voterAddr) Deserialize(data []byte) error {
+	addr, err := address.FromBytes(data)
+	if err != nil {
+		return errors.Wrap(err, "invalid voter")
+	}
+	v.Address = addr
+	return nil
+}
+
+// Serialize serializes the counter into bytes
+func (c counter) Serialize() ([]byte, error) {
+	return byteutil.Uint64ToBytesBigEndian(uint64(c)), nil
+}
+
+// Deserialize deserializes bytes into the counter
+func (c *counter) Deserialize(data []byte) error {
+	if len(data) != 8 {
+		return errors.Errorf("invalid counter length %d", len(data))
+	}
+	*c = counter(byteutil.BytesToUint64BigEndian(data))
+	return nil
+}
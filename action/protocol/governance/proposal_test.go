@@ -0,0 +1,85 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestProposal(t *testing.T) {
+	r := require.New(t)
+	p := &Proposal{
+		ID:           3,
+		Proposer:     identityset.Address(0),
+		Parameter:    ParameterBlockGasLimit,
+		Value:        "60000000",
+		Description:  "raise the block gas limit",
+		CreateHeight: 100,
+		StartEpoch:   2,
+		EndEpoch:     9,
+		Status:       ProposalActive,
+		YesVotes:     big.NewInt(0),
+		NoVotes:      big.NewInt(0),
+		AbstainVotes: big.NewInt(0),
+	}
+	data, err := p.Serialize()
+	r.NoError(err)
+	p2 := &Proposal{}
+	r.NoError(p2.Deserialize(data))
+	r.Equal(p, p2)
+
+	// the votes are not serialized with the proposal
+	vote := &Vote{identityset.Address(1), action.ProposalVoteAbstain}
+	p.Votes = []*Vote{vote}
+	data, err = p.Serialize()
+	r.NoError(err)
+	r.NoError(p2.Deserialize(data))
+	r.Empty(p2.Votes)
+	data, err = vote.Serialize()
+	r.NoError(err)
+	vote2 := &Vote{}
+	r.NoError(vote2.Deserialize(data))
+	r.Equal(vote, vote2)
+	data, err = voterAddr{identityset.Address(2)}.Serialize()
+	r.NoError(err)
+	var voter voterAddr
+	r.NoError(voter.Deserialize(data))
+	r.Equal(identityset.Address(2).String(), voter.String())
+
+	for _, c := range []struct {
+		yes, no, abstain int64
+		threshold        uint64
+		passed           bool
+	}{
+		{0, 0, 10, 50, false},
+		{5, 5, 0, 50, false},
+		{6, 5, 100, 50, true},
+		{2, 1, 0, 66, true},
+		{2, 1, 0, 67, false},
+		{1, 0, 0, 100, false},
+	} {
+		p.Votes = []*Vote{
+			{identityset.Address(1), action.ProposalVoteYes},
+			{identityset.Address(2), action.ProposalVoteNo},
+			{identityset.Address(3), action.ProposalVoteAbstain},
+		}
+		p.tally([]*big.Int{big.NewInt(c.yes), big.NewInt(c.no), big.NewInt(c.abstain)})
+		r.Equal(c.passed, p.passed(c.threshold))
+	}
+
+	var ids proposalIDs = []uint64{1, 3}
+	data, err = ids.Serialize()
+	r.NoError(err)
+	var ids2 proposalIDs
+	r.NoError(ids2.Deserialize(data))
+	r.Equal(ids, ids2)
+}
@@ -0,0 +1,502 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/governance/governancepb"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// TODO: it works only for one instance per protocol definition now
+	_protocolID          = action.GovernanceProtocolID
+	_governanceNamespace = "Governance"
+	// _maxProposalsPerQuery is the max number of the proposals returned by a query
+	_maxProposalsPerQuery = 100
+)
+
+var (
+	_proposalCountKey     = []byte("cnt")
+	_activeProposalsKey   = []byte("act")
+	_proposalKeyPrefix    = []byte("prp")
+	_voteKeyPrefix        = []byte("vot")
+	_voterKeyPrefix       = []byte("vtr")
+	_voterCountKeyPrefix  = []byte("vtc")
+	errGovernanceDisabled = errors.New("governance is not enabled")
+)
+
+type (
+	// DepositGas deposits gas to some pool
+	DepositGas func(ctx context.Context, sm protocol.StateManager, amount *big.Int) (*action.TransactionLog, error)
+
+	// VoterWeight returns the staking weight of a voter
+	VoterWeight func(ctx context.Context, sr protocol.StateReader, voter address.Address) (*big.Int, error)
+
+	// Protocol defines the protocol of the governance proposals. A staker pays a fee to propose a change of a chain
+	// parameter, the stakers vote for the proposal during the voting epochs, and the proposal is tallied at the start
+	// of the epoch after the voting period with the staking weights of the voters at that time. The result is a
+	// signal for the upgrades, it does not change the parameter on chain.
+	Protocol struct {
+		addr        address.Address
+		depositGas  DepositGas
+		voterWeight VoterWeight
+		cfg         genesis.Governance
+	}
+)
+
+// NewProtocol instantiates the governance protocol
+func NewProtocol(depositGas DepositGas, voterWeight VoterWeight, cfg genesis.Governance) *Protocol {
+	if cfg.VotingEpochs == 0 || cfg.PassThreshold > 100 {
+		log.L().Panic("invalid governance config",
+			zap.Uint64("votingEpochs", cfg.VotingEpochs),
+			zap.Uint64("passThreshold", cfg.PassThreshold))
+	}
+	return &Protocol{
+		addr:        ProtocolAddr(),
+		depositGas:  depositGas,
+		voterWeight: voterWeight,
+		cfg:         cfg,
+	}
+}
+
+// ProtocolAddr returns the address generated from protocol id
+func ProtocolAddr() address.Address {
+	return protocol.HashStringToAddress(_protocolID)
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	gp, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast governance protocol")
+	}
+	return gp
+}
+
+// CreatePreStates tallies the proposals whose voting period is over at the start of an epoch
+func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableGovernance {
+		return nil
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	epochNum := rp.GetEpochNum(blkCtx.BlockHeight)
+	if blkCtx.BlockHeight != rp.GetEpochHeight(epochNum) {
+		return nil
+	}
+	return p.tallyProposals(ctx, sm, epochNum)
+}
+
+// Validate validates a governance action
+func (p *Protocol) Validate(ctx context.Context, act action.Action, _ protocol.StateReader) error {
+	switch act := act.(type) {
+	case *action.CreateProposal:
+		if !protocol.MustGetFeatureCtx(ctx).EnableGovernance {
+			return errors.Wrap(action.ErrInvalidAct, errGovernanceDisabled.Error())
+		}
+		if err := validateParameter(act.Parameter(), act.Value()); err != nil {
+			return errors.Wrap(err, "error when validating create proposal action")
+		}
+	case *action.VoteProposal:
+		if !protocol.MustGetFeatureCtx(ctx).EnableGovernance {
+			return errors.Wrap(action.ErrInvalidAct, errGovernanceDisabled.Error())
+		}
+	}
+	return nil
+}
+
+// Handle handles the actions on the governance protocol
+func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
+	var (
+		rLog  *action.Log
+		tLogs []*action.TransactionLog
+		err   error
+		si    int
+	)
+	switch act := act.(type) {
+	case *action.CreateProposal:
+		si = sm.Snapshot()
+		rLog, tLogs, err = p.handleCreateProposal(ctx, act, sm)
+	case *action.VoteProposal:
+		si = sm.Snapshot()
+		rLog, err = p.handleVoteProposal(ctx, act, sm)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		herr, ok := errors.Cause(err).(*handleError)
+		if !ok {
+			return nil, err
+		}
+		log.L().Debug("Error when handling governance action", zap.Error(err))
+		if err := sm.Revert(si); err != nil {
+			return nil, err
+		}
+		return p.settleAction(ctx, sm, herr.ReceiptStatus(), nil, nil)
+	}
+	return p.settleAction(ctx, sm, uint64(iotextypes.ReceiptStatus_Success), []*action.Log{rLog}, tLogs)
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch string(method) {
+	case "Proposal":
+		if len(args) != 1 {
+			return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		id, perr := strconv.ParseUint(string(args[0]), 10, 64)
+		if perr != nil {
+			return nil, uint64(0), errors.Wrap(perr, "invalid proposal id")
+		}
+		proposal, perr := p.Proposal(ctx, sr, id)
+		if perr != nil {
+			return nil, uint64(0), perr
+		}
+		data, err = proto.Marshal(proposal.Proto())
+	case "Proposals":
+		if len(args) != 2 {
+			return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		start, perr := strconv.ParseUint(string(args[0]), 10, 64)
+		if perr != nil {
+			return nil, uint64(0), errors.Wrap(perr, "invalid start id")
+		}
+		count, perr := strconv.ParseUint(string(args[1]), 10, 64)
+		if perr != nil {
+			return nil, uint64(0), errors.Wrap(perr, "invalid count")
+		}
+		if count > _maxProposalsPerQuery {
+			return nil, uint64(0), errors.Errorf("count %d exceeds the limit %d", count, _maxProposalsPerQuery)
+		}
+		proposals, perr := p.Proposals(ctx, sr, start, count)
+		if perr != nil {
+			return nil, uint64(0), perr
+		}
+		data, err = serializeProposals(proposals)
+	case "ActiveProposals":
+		proposals, perr := p.ActiveProposals(ctx, sr)
+		if perr != nil {
+			return nil, uint64(0), perr
+		}
+		data, err = serializeProposals(proposals)
+	default:
+		return nil, uint64(0), errors.New("corresponding method isn't found")
+	}
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
+}
+
+// Proposal returns the proposal of the id, the votes of an active proposal are tallied with the current staking
+// weights of the voters
+func (p *Protocol) Proposal(ctx context.Context, sr protocol.StateReader, id uint64) (*Proposal, error) {
+	proposal, err := p.proposal(sr, id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Votes, err = p.votes(sr, id); err != nil {
+		return nil, err
+	}
+	if proposal.Status == ProposalActive {
+		if err := p.tallyProposal(ctx, sr, proposal); err != nil {
+			return nil, err
+		}
+	}
+	return proposal, nil
+}
+
+// Proposals returns at most count proposals starting from the id
+func (p *Protocol) Proposals(ctx context.Context, sr protocol.StateReader, start, count uint64) ([]*Proposal, error) {
+	total, err := p.proposalCount(sr)
+	if err != nil {
+		return nil, err
+	}
+	if start == 0 {
+		// the id starts from 1
+		start = 1
+	}
+	proposals := make([]*Proposal, 0)
+	for id := start; id <= total && uint64(len(proposals)) < count; id++ {
+		proposal, err := p.Proposal(ctx, sr, id)
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals, nil
+}
+
+// ActiveProposals returns the proposals which are not tallied yet
+func (p *Protocol) ActiveProposals(ctx context.Context, sr protocol.StateReader) ([]*Proposal, error) {
+	ids, err := p.activeProposalIDs(sr)
+	if err != nil {
+		return nil, err
+	}
+	proposals := make([]*Proposal, 0, len(ids))
+	for _, id := range ids {
+		proposal, err := p.Proposal(ctx, sr, id)
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals, nil
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+func (p *Protocol) tallyProposals(ctx context.Context, sm protocol.StateManager, epochNum uint64) error {
+	ids, err := p.activeProposalIDs(sm)
+	if err != nil {
+		return err
+	}
+	active := make(proposalIDs, 0, len(ids))
+	for _, id := range ids {
+		proposal, err := p.proposal(sm, id)
+		if err != nil {
+			return err
+		}
+		if proposal.EndEpoch >= epochNum {
+			active = append(active, id)
+			continue
+		}
+		if proposal.Votes, err = p.votes(sm, id); err != nil {
+			return err
+		}
+		if err := p.tallyProposal(ctx, sm, proposal); err != nil {
+			return err
+		}
+		if proposal.passed(p.cfg.PassThreshold) {
+			proposal.Status = ProposalPassed
+		} else {
+			proposal.Status = ProposalRejected
+		}
+		if err := p.putProposal(sm, proposal); err != nil {
+			return err
+		}
+	}
+	if len(active) == len(ids) {
+		return nil
+	}
+	return p.putState(sm, _activeProposalsKey, active)
+}
+
+func (p *Protocol) tallyProposal(ctx context.Context, sr protocol.StateReader, proposal *Proposal) error {
+	weights := make([]*big.Int, len(proposal.Votes))
+	for i, v := range proposal.Votes {
+		weight, err := p.voterWeight(ctx, sr, v.Voter)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the weight of voter %s", v.Voter.String())
+		}
+		weights[i] = weight
+	}
+	proposal.tally(weights)
+	return nil
+}
+
+func (p *Protocol) proposal(sr protocol.StateReader, id uint64) (*Proposal, error) {
+	proposal := &Proposal{}
+	if _, err := p.state(sr, proposalKey(id), proposal); err != nil {
+		return nil, errors.Wrapf(err, "failed to get proposal %d", id)
+	}
+	return proposal, nil
+}
+
+func (p *Protocol) putProposal(sm protocol.StateManager, proposal *Proposal) error {
+	return p.putState(sm, proposalKey(proposal.ID), proposal)
+}
+
+func (p *Protocol) proposalCount(sr protocol.StateReader) (uint64, error) {
+	return p.count(sr, _proposalCountKey)
+}
+
+// vote records the choice of the voter, the former choice of the voter is replaced. A new voter is appended to the
+// voters of the proposal, so a vote writes a constant number of states regardless of the number of the voters
+func (p *Protocol) vote(sm protocol.StateManager, id uint64, voter address.Address, choice uint8) error {
+	_, err := p.state(sm, voteKey(id, voter), &Vote{})
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		count, err := p.count(sm, voterCountKey(id))
+		if err != nil {
+			return err
+		}
+		if err := p.putState(sm, voterKey(id, count), voterAddr{voter}); err != nil {
+			return err
+		}
+		if err := p.putState(sm, voterCountKey(id), counter(count+1)); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+	return p.putState(sm, voteKey(id, voter), &Vote{Voter: voter, Choice: choice})
+}
+
+// votes returns the votes of the proposal in the order of the first votes of the voters
+func (p *Protocol) votes(sr protocol.StateReader, id uint64) ([]*Vote, error) {
+	count, err := p.count(sr, voterCountKey(id))
+	if err != nil {
+		return nil, err
+	}
+	votes := make([]*Vote, 0, count)
+	for i := uint64(0); i < count; i++ {
+		var voter voterAddr
+		if _, err := p.state(sr, voterKey(id, i), &voter); err != nil {
+			return nil, errors.Wrapf(err, "failed to get voter %d of proposal %d", i, id)
+		}
+		vote := &Vote{}
+		if _, err := p.state(sr, voteKey(id, voter.Address), vote); err != nil {
+			return nil, errors.Wrapf(err, "failed to get the vote of %s on proposal %d", voter.String(), id)
+		}
+		votes = append(votes, vote)
+	}
+	return votes, nil
+}
+
+func (p *Protocol) count(sr protocol.StateReader, key []byte) (uint64, error) {
+	var c counter
+	_, err := p.state(sr, key, &c)
+	switch errors.Cause(err) {
+	case nil, state.ErrStateNotExist:
+		return uint64(c), nil
+	default:
+		return 0, err
+	}
+}
+
+func (p *Protocol) activeProposalIDs(sr protocol.StateReader) (proposalIDs, error) {
+	var ids proposalIDs
+	_, err := p.state(sr, _activeProposalsKey, &ids)
+	switch errors.Cause(err) {
+	case nil, state.ErrStateNotExist:
+		return ids, nil
+	default:
+		return nil, err
+	}
+}
+
+func (p *Protocol) state(sr protocol.StateReader, key []byte, value interface{}) (uint64, error) {
+	return sr.State(value, protocol.KeyOption(key), protocol.NamespaceOption(_governanceNamespace))
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	_, err := sm.PutState(value, protocol.KeyOption(key), protocol.NamespaceOption(_governanceNamespace))
+	return err
+}
+
+func (p *Protocol) settleAction(
+	ctx context.Context,
+	sm protocol.StateManager,
+	status uint64,
+	logs []*action.Log,
+	tLogs []*action.TransactionLog,
+) (*action.Receipt, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	gasFee := big.NewInt(0).Mul(actionCtx.GasPrice, big.NewInt(0).SetUint64(actionCtx.IntrinsicGas))
+	depositLog, err := p.depositGas(ctx, sm, gasFee)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deposit gas")
+	}
+	acc, err := accountutil.LoadAccount(sm, actionCtx.Caller)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SetPendingNonce(actionCtx.Nonce + 1); err != nil {
+		return nil, errors.Wrap(err, "failed to set nonce")
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, errors.Wrap(err, "failed to update nonce")
+	}
+	r := action.Receipt{
+		Status:          status,
+		BlockHeight:     blkCtx.BlockHeight,
+		ActionHash:      actionCtx.ActionHash,
+		GasConsumed:     actionCtx.IntrinsicGas,
+		ContractAddress: p.addr.String(),
+	}
+	r.AddLogs(logs...).AddTransactionLogs(depositLog).AddTransactionLogs(tLogs...)
+	return &r, nil
+}
+
+func proposalKey(id uint64) []byte {
+	key := append([]byte{}, _proposalKeyPrefix...)
+	return append(key, byteutil.Uint64ToBytesBigEndian(id)...)
+}
+
+func voteKey(id uint64, voter address.Address) []byte {
+	key := append([]byte{}, _voteKeyPrefix...)
+	key = append(key, byteutil.Uint64ToBytesBigEndian(id)...)
+	return append(key, voter.Bytes()...)
+}
+
+func voterKey(id, index uint64) []byte {
+	key := append([]byte{}, _voterKeyPrefix...)
+	key = append(key, byteutil.Uint64ToBytesBigEndian(id)...)
+	return append(key, byteutil.Uint64ToBytesBigEndian(index)...)
+}
+
+func voterCountKey(id uint64) []byte {
+	key := append([]byte{}, _voterCountKeyPrefix...)
+	return append(key, byteutil.Uint64ToBytesBigEndian(id)...)
+}
+
+func serializeProposals(proposals []*Proposal) ([]byte, error) {
+	pb := &governancepb.ProposalList{
+		Proposals: make([]*governancepb.Proposal, 0, len(proposals)),
+	}
+	for _, proposal := range proposals {
+		pb.Proposals = append(pb.Proposals, proposal.Proto())
+	}
+	return proto.Marshal(pb)
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/governance/governancepb"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestProtocol(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = 100
+	cfg := g.Governance
+	cfg.VotingEpochs = 2
+	weights := map[string]*big.Int{
+		identityset.Address(1).String(): big.NewInt(100),
+		identityset.Address(2).String(): big.NewInt(50),
+		identityset.Address(3).String(): big.NewInt(30),
+	}
+	p := NewProtocol(depositGas, func(_ context.Context, _ protocol.StateReader, voter address.Address) (*big.Int, error) {
+		if w, ok := weights[voter.String()]; ok {
+			return w, nil
+		}
+		return big.NewInt(0), nil
+	}, cfg)
+	r.Equal(_protocolID, p.Name())
+	r.Equal(action.GovernanceProtocolAddr().String(), ProtocolAddr().String())
+
+	registry := protocol.NewRegistry()
+	rp := rolldpos.NewProtocol(g.NumCandidateDelegates, g.NumDelegates, g.NumSubEpochs)
+	r.NoError(rp.Register(registry))
+	r.NoError(p.Register(registry))
+	r.Equal(p, FindProtocol(registry))
+
+	proposer := identityset.Address(0)
+	poor := identityset.Address(4)
+	for _, addr := range []address.Address{proposer, identityset.Address(1), identityset.Address(2), identityset.Address(3), poor} {
+		balance := unit.ConvertIotxToRau(1000)
+		if addr == poor {
+			// enough for the gas, but not for the proposal fee
+			balance = unit.ConvertIotxToRau(1)
+		}
+		acc, err := accountutil.LoadOrCreateAccount(sm, addr)
+		r.NoError(err)
+		r.NoError(acc.AddBalance(balance))
+		r.NoError(accountutil.StoreAccount(sm, addr, acc))
+	}
+	withHeight := func(height uint64) context.Context {
+		ctx := protocol.WithRegistry(context.Background(), registry)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+		ctx = genesis.WithGenesisContext(ctx, g)
+		return protocol.WithFeatureCtx(ctx)
+	}
+	nonces := map[string]uint64{}
+	gasPrice := big.NewInt(unit.Qev)
+	handle := func(ctx context.Context, caller address.Address, act action.Action) *action.Receipt {
+		intrinsic, err := act.(interface{ IntrinsicGas() (uint64, error) }).IntrinsicGas()
+		r.NoError(err)
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:       caller,
+			GasPrice:     gasPrice,
+			IntrinsicGas: intrinsic,
+			Nonce:        nonces[caller.String()],
+		})
+		nonces[caller.String()]++
+		r.NoError(p.Validate(ctx, act, sm))
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		return receipt
+	}
+	readProposal := func(ctx context.Context, id uint64) *governancepb.Proposal {
+		data, _, err := p.ReadState(ctx, sm, []byte("Proposal"), []byte(strconv.FormatUint(id, 10)))
+		r.NoError(err)
+		pb := &governancepb.Proposal{}
+		r.NoError(proto.Unmarshal(data, pb))
+		return pb
+	}
+	readProposals := func(ctx context.Context, method string, args ...[]byte) []*governancepb.Proposal {
+		data, _, err := p.ReadState(ctx, sm, []byte(method), args...)
+		r.NoError(err)
+		pb := &governancepb.ProposalList{}
+		r.NoError(proto.Unmarshal(data, pb))
+		return pb.Proposals
+	}
+
+	createHeight := uint64(200)
+	ctx := withHeight(createHeight)
+	epoch := rp.GetEpochNum(createHeight)
+
+	t.Run("validate", func(t *testing.T) {
+		actCtx := protocol.WithActionCtx(withHeight(g.ToBeEnabledBlockHeight-1), protocol.ActionCtx{})
+		r.ErrorIs(p.Validate(actCtx, action.NewCreateProposal(0, ParameterMinGasPrice, "1", "", 0, gasPrice), sm), action.ErrInvalidAct)
+		r.ErrorIs(p.Validate(actCtx, action.NewVoteProposal(0, 1, action.ProposalVoteYes, 0, gasPrice), sm), action.ErrInvalidAct)
+		for _, c := range []struct {
+			parameter, value string
+			valid            bool
+		}{
+			{ParameterBlockReward, "16000000000000000000", true},
+			{ParameterEpochReward, "-1", false},
+			{ParameterMinGasPrice, "1e12", false},
+			{ParameterBlockGasLimit, "50000000", true},
+			{ParameterBlockGasLimit, "0", false},
+			{ParameterUpgrade, "Upernavik at 39275561", true},
+			{"numDelegates", "36", false},
+		} {
+			err := p.Validate(ctx, action.NewCreateProposal(0, c.parameter, c.value, "", 0, gasPrice), sm)
+			if c.valid {
+				r.NoError(err)
+			} else {
+				r.ErrorIs(err, action.ErrInvalidAct)
+			}
+		}
+	})
+
+	t.Run("create proposal", func(t *testing.T) {
+		act := action.NewCreateProposal(0, ParameterMinGasPrice, "500000000000", "lower the min gas price", 0, gasPrice)
+		receipt := handle(ctx, poor, act)
+		r.EqualValues(iotextypes.ReceiptStatus_ErrNotEnoughBalance, receipt.Status)
+		r.Empty(readProposals(ctx, "Proposals", []byte("0"), []byte("10")))
+
+		receipt = handle(ctx, proposer, act)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Len(receipt.Logs(), 1)
+		topics := receipt.Logs()[0].Topics
+		r.Len(topics, 3)
+		r.Equal(uint64(1), byteutil.BytesToUint64BigEndian(topics[1][24:]))
+		tLogs := receipt.TransactionLogs()
+		r.Len(tLogs, 1)
+		r.Equal(iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND, tLogs[0].Type)
+		r.Equal(cfg.ProposalFee(), tLogs[0].Amount)
+		acc, err := accountutil.LoadAccount(sm, proposer)
+		r.NoError(err)
+		gas, err := act.IntrinsicGas()
+		r.NoError(err)
+		spent := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+		r.Equal(new(big.Int).Sub(unit.ConvertIotxToRau(1000), spent.Add(spent, cfg.ProposalFee())), acc.Balance)
+
+		pb := readProposal(ctx, 1)
+		r.Equal(proposer.String(), pb.Proposer)
+		r.Equal(ParameterMinGasPrice, pb.Parameter)
+		r.Equal("500000000000", pb.Value)
+		r.Equal(createHeight, pb.CreateHeight)
+		r.Equal(epoch, pb.StartEpoch)
+		r.Equal(epoch+1, pb.EndEpoch)
+		r.EqualValues(ProposalActive, pb.Status)
+	})
+
+	t.Run("vote proposal", func(t *testing.T) {
+		receipt := handle(ctx, identityset.Address(1), action.NewVoteProposal(0, 2, action.ProposalVoteYes, 0, gasPrice))
+		r.EqualValues(iotextypes.ReceiptStatus_Failure, receipt.Status)
+
+		for _, v := range []struct {
+			voter  int
+			choice uint8
+		}{
+			{1, action.ProposalVoteYes},
+			{2, action.ProposalVoteNo},
+			{3, action.ProposalVoteAbstain},
+			// the former choice is replaced
+			{2, action.ProposalVoteYes},
+		} {
+			receipt := handle(ctx, identityset.Address(v.voter), action.NewVoteProposal(0, 1, v.choice, 0, gasPrice))
+			r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+			r.Equal([]byte{v.choice}, receipt.Logs()[0].Data)
+		}
+		// the active proposal is tallied with the current weights
+		pb := readProposal(ctx, 1)
+		r.Len(pb.Votes, 3)
+		r.Equal("150", pb.YesVotes)
+		r.Equal("0", pb.NoVotes)
+		r.Equal("30", pb.AbstainVotes)
+		r.Len(readProposals(ctx, "ActiveProposals"), 1)
+	})
+
+	t.Run("tally", func(t *testing.T) {
+		// a proposal without votes is rejected
+		receipt := handle(ctx, proposer, action.NewCreateProposal(0, ParameterUpgrade, "Upernavik", "", 0, gasPrice))
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+
+		// voting is open until the end epoch
+		lastHeight := rp.GetEpochLastBlockHeight(epoch + 1)
+		receipt = handle(withHeight(lastHeight), identityset.Address(2), action.NewVoteProposal(0, 1, action.ProposalVoteNo, 0, gasPrice))
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.NoError(p.CreatePreStates(withHeight(lastHeight), sm))
+		r.Len(readProposals(ctx, "ActiveProposals"), 2)
+
+		weights[identityset.Address(2).String()] = big.NewInt(20)
+		tallyCtx := withHeight(lastHeight + 1)
+		r.NoError(p.CreatePreStates(tallyCtx, sm))
+		r.Empty(readProposals(tallyCtx, "ActiveProposals"))
+		proposals := readProposals(tallyCtx, "Proposals", []byte("1"), []byte("10"))
+		r.Len(proposals, 2)
+		r.EqualValues(ProposalPassed, proposals[0].Status)
+		r.Equal("100", proposals[0].YesVotes)
+		r.Equal("20", proposals[0].NoVotes)
+		r.Equal("30", proposals[0].AbstainVotes)
+		r.EqualValues(ProposalRejected, proposals[1].Status)
+		r.Equal("0", proposals[1].YesVotes)
+
+		// the tallied proposal is closed, and keeps its result
+		receipt = handle(tallyCtx, identityset.Address(3), action.NewVoteProposal(0, 1, action.ProposalVoteNo, 0, gasPrice))
+		r.EqualValues(iotextypes.ReceiptStatus_Failure, receipt.Status)
+		weights[identityset.Address(1).String()] = big.NewInt(0)
+		r.Equal("100", readProposal(tallyCtx, 1).YesVotes)
+		r.Len(readProposals(tallyCtx, "Proposals", []byte("2"), []byte("10")), 1)
+	})
+
+	_, _, err := p.ReadState(ctx, sm, []byte("Proposals"), []byte("1"), []byte(strconv.Itoa(_maxProposalsPerQuery+1)))
+	r.ErrorContains(err, "exceeds the limit")
+	_, _, err = p.ReadState(ctx, sm, []byte("Unknown"))
+	r.Error(err)
+}
+
+func depositGas(ctx context.Context, sm protocol.StateManager, gasFee *big.Int) (*action.TransactionLog, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	acc, err := accountutil.LoadAccount(sm, actionCtx.Caller)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SubBalance(gasFee); err != nil {
+		return nil, err
+	}
+	return nil, accountutil.StoreAccount(sm, actionCtx.Caller, acc)
+}
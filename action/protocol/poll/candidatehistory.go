@@ -0,0 +1,158 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// MaxCandidateHistoryEpochs is the max number of epochs to read the candidate history in one query
+const MaxCandidateHistoryEpochs = 100
+
+// CandidateHistory returns the votes, rank, productivity and probation status of the candidate in the epochs
+// [startEpoch, startEpoch+count) from the candidate indexer. The candidate is specified by its operator address
+// or name, and the epochs in which it is not a candidate are skipped.
+func (sh *Slasher) CandidateHistory(ctx context.Context, tipHeight uint64, candidate string, startEpoch, count uint64) (*pollpb.CandidateHistory, error) {
+	if sh.indexer == nil {
+		return nil, errors.New("candidate indexer is not available")
+	}
+	if count == 0 || count > MaxCandidateHistoryEpochs {
+		return nil, errors.Errorf("invalid number of epochs %d, should be in range [1, %d]", count, MaxCandidateHistoryEpochs)
+	}
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	if startEpoch == 0 {
+		startEpoch = 1
+	}
+	endEpoch := startEpoch + count - 1
+	if currentEpoch := rp.GetEpochNum(tipHeight); endEpoch > currentEpoch {
+		endEpoch = currentEpoch
+	}
+	history := &pollpb.CandidateHistory{}
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		record, err := sh.candidateEpochRecord(ctx, rp, tipHeight, candidate, epoch)
+		if err != nil {
+			if errors.Cause(err) == ErrIndexerNotExist {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to read the record of candidate %s in epoch %d", candidate, epoch)
+		}
+		if record != nil {
+			history.Records = append(history.Records, record)
+		}
+	}
+	return history, nil
+}
+
+func (sh *Slasher) candidateEpochRecord(
+	ctx context.Context,
+	rp *rolldpos.Protocol,
+	tipHeight uint64,
+	candidate string,
+	epoch uint64,
+) (*pollpb.CandidateEpochRecord, error) {
+	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
+	epochStartHeight := rp.GetEpochHeight(epoch)
+	candidates, err := sh.indexer.CandidateList(epochStartHeight)
+	if err != nil {
+		return nil, err
+	}
+	// the votes breakdown is only available for the epochs using native staking, and the candidate name is only
+	// recorded in it since the name is not part of the indexed candidate list
+	var votes []*pollpb.CandidateVotes
+	votesList, err := sh.indexer.CandidateVotes(epochStartHeight)
+	switch {
+	case errors.Cause(err) == ErrIndexerNotExist:
+	case err != nil:
+		return nil, err
+	default:
+		votes = votesList.CandidateVotes
+	}
+	var candVotes *pollpb.CandidateVotes
+	for _, v := range votes {
+		if v.Address == candidate || v.Name == candidate {
+			candVotes = v
+			candidate = v.Address
+			break
+		}
+	}
+	var cand *state.Candidate
+	for _, c := range candidates {
+		if c.Address == candidate {
+			cand = c
+			break
+		}
+	}
+	if cand == nil {
+		return nil, nil
+	}
+	record := &pollpb.CandidateEpochRecord{
+		EpochNumber:      epoch,
+		EpochStartHeight: epochStartHeight,
+		Address:          cand.Address,
+		TotalVotes:       cand.Votes.String(),
+		NativeVotes:      cand.Votes.String(),
+		ContractVotes:    "0",
+	}
+	if candVotes != nil {
+		contractVotes, ok := new(big.Int).SetString(candVotes.ContractVotes, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid contract staking votes %s", candVotes.ContractVotes)
+		}
+		record.Name = candVotes.Name
+		record.ContractVotes = candVotes.ContractVotes
+		record.NativeVotes = new(big.Int).Sub(cand.Votes, contractVotes).String()
+		record.SelfStake = candVotes.SelfStake
+	}
+	if featureWithHeightCtx.CalculateProbationList(epochStartHeight) {
+		probationList, err := sh.indexer.ProbationList(epochStartHeight)
+		if err != nil {
+			return nil, err
+		}
+		_, record.Probation = probationList.ProbationInfo[cand.Address]
+	}
+	filtered, err := sh.GetCandidatesFromIndexer(ctx, epochStartHeight)
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range filtered {
+		if c.Address == cand.Address {
+			record.Rank = uint64(i + 1)
+			break
+		}
+	}
+	blockProducers, err := sh.calculateBlockProducer(filtered)
+	if err != nil {
+		return nil, err
+	}
+	activeBlockProducers, err := sh.calculateActiveBlockProducer(ctx, blockProducers, epochStartHeight)
+	if err != nil {
+		return nil, err
+	}
+	for _, abp := range activeBlockProducers {
+		if abp.Address == cand.Address {
+			record.ActiveBlockProducer = true
+			break
+		}
+	}
+	if !record.ActiveBlockProducer {
+		return record, nil
+	}
+	numBlks, produce, err := rp.ProductivityByEpoch(epoch, tipHeight, sh.productivity)
+	if err != nil {
+		return nil, err
+	}
+	record.ProducedBlocks = produce[cand.Address]
+	record.ExpectedBlocks = numBlks / uint64(len(activeBlockProducers))
+	return record, nil
+}
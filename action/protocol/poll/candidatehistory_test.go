@@ -0,0 +1,152 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
+)
+
+func TestCandidateHistory(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	g := genesis.Default
+	g.EasterBlockHeight = 1
+	registry := protocol.NewRegistry()
+	rp := rolldpos.NewProtocol(36, 6, 5)
+	r.NoError(registry.Register("rolldpos", rp))
+	ctx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), registry), g)
+	ctx = protocol.WithFeatureWithHeightCtx(ctx)
+
+	var (
+		addrA  = identityset.Address(1).String()
+		addrB  = identityset.Address(2).String()
+		addrC  = identityset.Address(3).String()
+		epoch2 = rp.GetEpochHeight(2)
+		tip    = epoch2 + 9
+	)
+	indexer, err := NewCandidateIndexer(db.NewMemKVStore())
+	r.NoError(err)
+	sh, err := NewSlasher(
+		func(start, end uint64) (map[string]uint64, error) {
+			switch start {
+			case 1:
+				return map[string]uint64{addrA: 20, addrB: 10}, nil
+			case epoch2:
+				return map[string]uint64{addrB: 7}, nil
+			default:
+				return nil, nil
+			}
+		},
+		nil, nil, nil, indexer, 2, 2, g.DardanellesNumSubEpochs, g.ProductivityThreshold, g.ProbationEpochPeriod,
+		g.UnproductiveDelegateMaxCacheSize, 90)
+	r.NoError(err)
+
+	candidates := state.CandidateList{
+		{Address: addrA, Votes: big.NewInt(30), RewardAddress: addrA},
+		{Address: addrB, Votes: big.NewInt(22), RewardAddress: addrB},
+		{Address: addrC, Votes: big.NewInt(20), RewardAddress: addrC},
+	}
+	// epoch 1 has no votes breakdown so the candidates can only be found by address, and A is on probation in epoch 2
+	r.NoError(indexer.PutCandidateList(1, &candidates))
+	r.NoError(indexer.PutProbationList(1, vote.NewProbationList(90)))
+	r.NoError(indexer.PutCandidateList(epoch2, &candidates))
+	probationList := vote.NewProbationList(90)
+	probationList.ProbationInfo[addrA] = 1
+	r.NoError(indexer.PutProbationList(epoch2, probationList))
+	r.NoError(indexer.PutCandidateVotes(epoch2, &pollpb.CandidateVotesList{
+		CandidateVotes: []*pollpb.CandidateVotes{
+			{Address: addrA, Name: "a", SelfStake: "30", ContractVotes: "0"},
+			{Address: addrB, Name: "b", SelfStake: "10", ContractVotes: "2"},
+			{Address: addrC, Name: "c", SelfStake: "20", ContractVotes: "0"},
+		},
+	}))
+
+	t.Run("invalid number of epochs", func(t *testing.T) {
+		_, err := sh.CandidateHistory(ctx, tip, addrB, 1, 0)
+		r.Error(err)
+		_, err = sh.CandidateHistory(ctx, tip, addrB, 1, MaxCandidateHistoryEpochs+1)
+		r.Error(err)
+	})
+	t.Run("active block producer", func(t *testing.T) {
+		history, err := sh.CandidateHistory(ctx, tip, addrB, 0, 5)
+		r.NoError(err)
+		r.Len(history.Records, 2)
+		r.True(proto.Equal(&pollpb.CandidateEpochRecord{
+			EpochNumber:         1,
+			EpochStartHeight:    1,
+			Address:             addrB,
+			TotalVotes:          "22",
+			NativeVotes:         "22",
+			ContractVotes:       "0",
+			Rank:                2,
+			ActiveBlockProducer: true,
+			ProducedBlocks:      10,
+			ExpectedBlocks:      15,
+		}, history.Records[0]), history.Records[0].String())
+		r.True(proto.Equal(&pollpb.CandidateEpochRecord{
+			EpochNumber:         2,
+			EpochStartHeight:    epoch2,
+			Address:             addrB,
+			Name:                "b",
+			TotalVotes:          "22",
+			NativeVotes:         "20",
+			ContractVotes:       "2",
+			SelfStake:           "10",
+			Rank:                1,
+			ActiveBlockProducer: true,
+			ProducedBlocks:      7,
+			ExpectedBlocks:      5,
+		}, history.Records[1]), history.Records[1].String())
+	})
+	t.Run("candidate on probation", func(t *testing.T) {
+		history, err := sh.CandidateHistory(ctx, tip, "a", 2, 1)
+		r.NoError(err)
+		r.Len(history.Records, 1)
+		record := history.Records[0]
+		r.True(record.Probation)
+		r.EqualValues(3, record.Rank)
+		r.False(record.ActiveBlockProducer)
+		r.Zero(record.ProducedBlocks)
+	})
+	t.Run("not a candidate", func(t *testing.T) {
+		history, err := sh.CandidateHistory(ctx, tip, identityset.Address(4).String(), 1, 2)
+		r.NoError(err)
+		r.Empty(history.Records)
+	})
+	t.Run("read state", func(t *testing.T) {
+		sr := mock_chainmanager.NewMockStateReader(ctrl)
+		sr.EXPECT().Height().Return(tip, nil).AnyTimes()
+		data, height, err := sh.ReadState(ctx, sr, indexer, []byte("CandidateHistoryByEpoch"), []byte("1"), []byte("c"), []byte("2"))
+		r.NoError(err)
+		r.Equal(tip, height)
+		history := &pollpb.CandidateHistory{}
+		r.NoError(proto.Unmarshal(data, history))
+		r.Len(history.Records, 1)
+		r.Equal(addrC, history.Records[0].Address)
+		r.EqualValues(2, history.Records[0].Rank)
+		_, _, err = sh.ReadState(ctx, sr, indexer, []byte("CandidateHistoryByEpoch"), []byte("1"), []byte("c"))
+		r.Error(err)
+		_, _, err = sh.ReadState(ctx, sr, nil, []byte("CandidateHistoryByEpoch"), []byte("2"), []byte("c"), []byte("1"))
+		r.Error(err)
+	})
+}
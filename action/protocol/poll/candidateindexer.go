@@ -11,7 +11,9 @@ import (
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/log"
@@ -24,6 +26,8 @@ var (
 	CandidateNamespace = "candidates"
 	// ProbationNamespace is a namespace to store probationlist
 	ProbationNamespace = "kickout"
+	// CandidateVotesNamespace is a namespace to store the votes breakdown of candidates
+	CandidateVotesNamespace = "candidateVotes"
 	// ErrIndexerNotExist is an error that shows not exist in candidate indexer DB
 	ErrIndexerNotExist = errors.New("not exist in DB")
 )
@@ -79,6 +83,18 @@ func (cd *CandidateIndexer) PutProbationList(height uint64, probationList *vote.
 	return cd.kvStore.Put(ProbationNamespace, byteutil.Uint64ToBytes(height), probationListByte)
 }
 
+// PutCandidateVotes puts the votes breakdown of candidates into indexer
+func (cd *CandidateIndexer) PutCandidateVotes(height uint64, votes *pollpb.CandidateVotesList) error {
+	cd.mutex.Lock()
+	defer cd.mutex.Unlock()
+	votesByte, err := proto.Marshal(votes)
+	if err != nil {
+		return err
+	}
+	log.L().Debug("put candidate votes into candidate indexer", zap.Uint64("height", height))
+	return cd.kvStore.Put(CandidateVotesNamespace, byteutil.Uint64ToBytes(height), votesByte)
+}
+
 // CandidateList gets candidate list from indexer given epoch start height
 func (cd *CandidateIndexer) CandidateList(height uint64) (state.CandidateList, error) {
 	cd.mutex.RLock()
@@ -124,3 +140,22 @@ func (cd *CandidateIndexer) ProbationList(height uint64) (*vote.ProbationList, e
 	}
 	return bl, nil
 }
+
+// CandidateVotes gets the votes breakdown of candidates from indexer given epoch start height
+func (cd *CandidateIndexer) CandidateVotes(height uint64) (*pollpb.CandidateVotesList, error) {
+	cd.mutex.RLock()
+	defer cd.mutex.RUnlock()
+	log.L().Debug("get candidate votes from candidate indexer", zap.Uint64("height", height))
+	bytes, err := cd.kvStore.Get(CandidateVotesNamespace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, ErrIndexerNotExist
+		}
+		return nil, err
+	}
+	votes := &pollpb.CandidateVotesList{}
+	if err := proto.Unmarshal(bytes, votes); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
@@ -11,7 +11,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/state"
@@ -103,4 +105,17 @@ func TestCandidateIndexer(t *testing.T) {
 	for str, count := range probationList.ProbationInfo {
 		require.Equal(probationList2.ProbationInfo[str], count)
 	}
+
+	// PutCandidateVotes and CandidateVotes with height 1
+	_, err = indexer.CandidateVotes(uint64(1))
+	require.Equal(ErrIndexerNotExist, err)
+	votes := &pollpb.CandidateVotesList{
+		CandidateVotes: []*pollpb.CandidateVotes{
+			{Address: identityset.Address(1).String(), SelfStake: "10", ContractVotes: "5"},
+		},
+	}
+	require.NoError(indexer.PutCandidateVotes(uint64(1), votes))
+	votes2, err := indexer.CandidateVotes(uint64(1))
+	require.NoError(err)
+	require.True(proto.Equal(votes, votes2))
 }
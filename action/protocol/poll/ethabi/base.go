@@ -0,0 +1,29 @@
+package ethabi
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+)
+
+var (
+	errInvalidCallData  = errors.New("invalid call binary data")
+	errInvalidCallSig   = errors.New("invalid call sig")
+	errConvertBigNumber = errors.New("convert big number error")
+	errDecodeFailure    = errors.New("decode data error")
+)
+
+// BuildReadStateRequest decode eth_call data to StateContext
+func BuildReadStateRequest(data []byte) (protocol.StateContext, error) {
+	if len(data) < 4 {
+		return nil, errInvalidCallData
+	}
+
+	switch methodSig := hex.EncodeToString(data[:4]); methodSig {
+	case hex.EncodeToString(_candidateHistoryMethod.ID):
+		return newCandidateHistoryStateContext(data[4:])
+	default:
+		return nil, errInvalidCallSig
+	}
+}
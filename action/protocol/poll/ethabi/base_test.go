@@ -0,0 +1,22 @@
+package ethabi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReadStateRequest(t *testing.T) {
+	r := require.New(t)
+
+	data, _ := hex.DecodeString("1234")
+	ctx, err := BuildReadStateRequest(data)
+	r.Nil(ctx)
+	r.EqualError(errInvalidCallData, err.Error())
+
+	data, _ = hex.DecodeString("12345678")
+	ctx, err = BuildReadStateRequest(data)
+	r.Nil(ctx)
+	r.EqualError(errInvalidCallSig, err.Error())
+}
@@ -0,0 +1,239 @@
+package ethabi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/abiutil"
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+)
+
+const (
+	_candidateHistoryMethodName = "CandidateHistoryByEpoch"
+
+	_candidateHistoryInterfaceABI = `[
+	{
+		"inputs": [
+			{
+				"internalType": "string",
+				"name": "candidate",
+				"type": "string"
+			},
+			{
+				"internalType": "uint64",
+				"name": "startEpoch",
+				"type": "uint64"
+			},
+			{
+				"internalType": "uint64",
+				"name": "count",
+				"type": "uint64"
+			}
+		],
+		"name": "candidateHistory",
+		"outputs": [
+			{
+				"components": [
+					{
+						"internalType": "uint64",
+						"name": "epochNumber",
+						"type": "uint64"
+					},
+					{
+						"internalType": "uint64",
+						"name": "epochStartHeight",
+						"type": "uint64"
+					},
+					{
+						"internalType": "address",
+						"name": "operator",
+						"type": "address"
+					},
+					{
+						"internalType": "string",
+						"name": "name",
+						"type": "string"
+					},
+					{
+						"internalType": "uint256",
+						"name": "totalVotes",
+						"type": "uint256"
+					},
+					{
+						"internalType": "uint256",
+						"name": "nativeVotes",
+						"type": "uint256"
+					},
+					{
+						"internalType": "uint256",
+						"name": "contractVotes",
+						"type": "uint256"
+					},
+					{
+						"internalType": "uint256",
+						"name": "selfStake",
+						"type": "uint256"
+					},
+					{
+						"internalType": "uint64",
+						"name": "rank",
+						"type": "uint64"
+					},
+					{
+						"internalType": "bool",
+						"name": "activeBlockProducer",
+						"type": "bool"
+					},
+					{
+						"internalType": "uint64",
+						"name": "producedBlocks",
+						"type": "uint64"
+					},
+					{
+						"internalType": "uint64",
+						"name": "expectedBlocks",
+						"type": "uint64"
+					},
+					{
+						"internalType": "bool",
+						"name": "probation",
+						"type": "bool"
+					}
+				],
+				"internalType": "struct IPoll.CandidateEpochRecord[]",
+				"name": "",
+				"type": "tuple[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+)
+
+var _candidateHistoryMethod abi.Method
+
+func init() {
+	_candidateHistoryMethod = abiutil.MustLoadMethod(_candidateHistoryInterfaceABI, "candidateHistory")
+}
+
+type (
+	// CandidateHistoryStateContext context for CandidateHistory, the candidate is its operator address or name
+	CandidateHistoryStateContext struct {
+		*protocol.BaseStateContext
+	}
+
+	// candidateEpochRecordEth struct for eth
+	candidateEpochRecordEth struct {
+		EpochNumber         uint64
+		EpochStartHeight    uint64
+		Operator            common.Address
+		Name                string
+		TotalVotes          *big.Int
+		NativeVotes         *big.Int
+		ContractVotes       *big.Int
+		SelfStake           *big.Int
+		Rank                uint64
+		ActiveBlockProducer bool
+		ProducedBlocks      uint64
+		ExpectedBlocks      uint64
+		Probation           bool
+	}
+)
+
+func newCandidateHistoryStateContext(data []byte) (*CandidateHistoryStateContext, error) {
+	paramsMap := map[string]interface{}{}
+	if err := _candidateHistoryMethod.Inputs.UnpackIntoMap(paramsMap, data); err != nil {
+		return nil, err
+	}
+	candidate, ok := paramsMap["candidate"].(string)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	startEpoch, ok := paramsMap["startEpoch"].(uint64)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	count, ok := paramsMap["count"].(uint64)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	// the candidate given as an eth address is converted to io address
+	if common.IsHexAddress(candidate) {
+		addr, err := address.FromBytes(common.HexToAddress(candidate).Bytes())
+		if err != nil {
+			return nil, err
+		}
+		candidate = addr.String()
+	}
+	return &CandidateHistoryStateContext{
+		&protocol.BaseStateContext{
+			Parameter: &protocol.Parameters{
+				MethodName: []byte(_candidateHistoryMethodName),
+				Arguments: [][]byte{
+					[]byte(strconv.FormatUint(startEpoch, 10)),
+					[]byte(candidate),
+					[]byte(strconv.FormatUint(count, 10)),
+				},
+			},
+			Method: &_candidateHistoryMethod,
+		},
+	}, nil
+}
+
+// EncodeToEth encode proto to eth
+func (r *CandidateHistoryStateContext) EncodeToEth(resp *iotexapi.ReadStateResponse) (string, error) {
+	var result pollpb.CandidateHistory
+	if err := proto.Unmarshal(resp.Data, &result); err != nil {
+		return "", err
+	}
+	records := make([]candidateEpochRecordEth, len(result.Records))
+	for i, record := range result.Records {
+		operator, err := addrutil.IoAddrToEvmAddr(record.Address)
+		if err != nil {
+			return "", err
+		}
+		amounts := make([]*big.Int, 4)
+		for j, s := range []string{record.TotalVotes, record.NativeVotes, record.ContractVotes, record.SelfStake} {
+			if s == "" {
+				// the self-stake is unknown for the epochs without votes breakdown
+				amounts[j] = big.NewInt(0)
+				continue
+			}
+			amount, ok := new(big.Int).SetString(s, 10)
+			if !ok {
+				return "", errConvertBigNumber
+			}
+			amounts[j] = amount
+		}
+		records[i] = candidateEpochRecordEth{
+			EpochNumber:         record.EpochNumber,
+			EpochStartHeight:    record.EpochStartHeight,
+			Operator:            operator,
+			Name:                record.Name,
+			TotalVotes:          amounts[0],
+			NativeVotes:         amounts[1],
+			ContractVotes:       amounts[2],
+			SelfStake:           amounts[3],
+			Rank:                record.Rank,
+			ActiveBlockProducer: record.ActiveBlockProducer,
+			ProducedBlocks:      record.ProducedBlocks,
+			ExpectedBlocks:      record.ExpectedBlocks,
+			Probation:           record.Probation,
+		}
+	}
+	data, err := r.Method.Outputs.Pack(records)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
@@ -0,0 +1,105 @@
+package ethabi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestCandidateHistoryStateContext(t *testing.T) {
+	r := require.New(t)
+
+	data, err := _candidateHistoryMethod.Inputs.Pack("delegate", uint64(10), uint64(5))
+	r.NoError(err)
+	ctx, err := BuildReadStateRequest(append(_candidateHistoryMethod.ID, data...))
+	r.NoError(err)
+	r.IsType(&CandidateHistoryStateContext{}, ctx)
+	r.EqualValues("CandidateHistoryByEpoch", string(ctx.Parameters().MethodName))
+	r.Len(ctx.Parameters().Arguments, 3)
+	r.EqualValues("10", string(ctx.Parameters().Arguments[0]))
+	r.EqualValues("delegate", string(ctx.Parameters().Arguments[1]))
+	r.EqualValues("5", string(ctx.Parameters().Arguments[2]))
+
+	// eth address of the operator is converted to io address
+	operator := identityset.Address(1)
+	data, err = _candidateHistoryMethod.Inputs.Pack(operator.Hex(), uint64(10), uint64(5))
+	r.NoError(err)
+	ctx, err = newCandidateHistoryStateContext(data)
+	r.NoError(err)
+	r.EqualValues(operator.String(), string(ctx.Parameters().Arguments[1]))
+
+	_, err = newCandidateHistoryStateContext(data[:32])
+	r.Error(err)
+}
+
+func TestCandidateHistoryEncodeToEth(t *testing.T) {
+	r := require.New(t)
+
+	data, err := _candidateHistoryMethod.Inputs.Pack("delegate", uint64(1), uint64(2))
+	r.NoError(err)
+	ctx, err := newCandidateHistoryStateContext(data)
+	r.NoError(err)
+	history := &pollpb.CandidateHistory{
+		Records: []*pollpb.CandidateEpochRecord{
+			{
+				EpochNumber:      1,
+				EpochStartHeight: 1,
+				Address:          identityset.Address(1).String(),
+				TotalVotes:       "100",
+				NativeVotes:      "100",
+				ContractVotes:    "0",
+				Rank:             3,
+			},
+			{
+				EpochNumber:         2,
+				EpochStartHeight:    721,
+				Address:             identityset.Address(1).String(),
+				Name:                "delegate",
+				TotalVotes:          "120",
+				NativeVotes:         "100",
+				ContractVotes:       "20",
+				SelfStake:           "50",
+				Rank:                1,
+				ActiveBlockProducer: true,
+				ProducedBlocks:      29,
+				ExpectedBlocks:      30,
+				Probation:           true,
+			},
+		},
+	}
+	resp, err := proto.Marshal(history)
+	r.NoError(err)
+	h, err := ctx.EncodeToEth(&iotexapi.ReadStateResponse{Data: resp})
+	r.NoError(err)
+
+	out, err := _candidateHistoryMethod.Outputs.Unpack(func() []byte {
+		b, err := hex.DecodeString(h)
+		r.NoError(err)
+		return b
+	}())
+	r.NoError(err)
+	records := *abi.ConvertType(out[0], new([]candidateEpochRecordEth)).(*[]candidateEpochRecordEth)
+	r.Len(records, 2)
+	r.Equal(identityset.Address(1).Bytes(), records[0].Operator.Bytes())
+	r.Zero(records[0].SelfStake.Sign())
+	r.EqualValues(3, records[0].Rank)
+	r.Equal("delegate", records[1].Name)
+	r.EqualValues(20, records[1].ContractVotes.Int64())
+	r.EqualValues(50, records[1].SelfStake.Int64())
+	r.True(records[1].ActiveBlockProducer)
+	r.EqualValues(29, records[1].ProducedBlocks)
+	r.True(records[1].Probation)
+
+	history.Records[0].TotalVotes = "invalid"
+	resp, err = proto.Marshal(history)
+	r.NoError(err)
+	_, err = ctx.EncodeToEth(&iotexapi.ReadStateResponse{Data: resp})
+	r.Equal(errConvertBigNumber, err)
+}
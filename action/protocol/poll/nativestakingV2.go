@@ -8,10 +8,15 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/util"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
 	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -70,7 +75,50 @@ func (ns *nativeStakingV2) CreatePostSystemActions(ctx context.Context, sr proto
 }
 
 func (ns *nativeStakingV2) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
-	return handle(ctx, act, sm, ns.candIndexer, ns.addr.String())
+	receipt, err := handle(ctx, act, sm, ns.candIndexer, ns.addr.String())
+	if err != nil || receipt == nil {
+		return receipt, err
+	}
+	if ns.candIndexer != nil && protocol.MustGetFeatureCtx(ctx).IndexCandidateVotes {
+		// the votes breakdown is only for the query of candidate history, failing to index it does not fail the block
+		if err := ns.putCandidateVotes(ctx, sm, act.(*action.PutPollResult)); err != nil {
+			log.L().Error("failed to index candidate votes", zap.Error(err))
+		}
+	}
+	if protocol.MustGetFeatureCtx(ctx).SlashSelfStake {
+		// the poll result is put once per epoch, slash the delegates on probation of current epoch along with it
+		logs, tLogs, err := ns.slasher.slashSelfStake(ctx, sm, ns.stakingV2.SlashSelfStake)
+		if err != nil {
+			return nil, err
+		}
+		receipt.AddLogs(logs...).AddTransactionLogs(tLogs...)
+	}
+	return receipt, nil
+}
+
+// putCandidateVotes puts the name, self-stake and contract staking votes of the candidates in poll result into indexer
+func (ns *nativeStakingV2) putCandidateVotes(ctx context.Context, sm protocol.StateManager, r *action.PutPollResult) error {
+	votes, err := ns.stakingV2.CandidateVotes(ctx, sm)
+	if err != nil {
+		return errors.Wrap(err, "failed to get candidate votes")
+	}
+	list := &pollpb.CandidateVotesList{}
+	for _, cand := range r.Candidates() {
+		v, ok := votes[cand.Address]
+		if !ok {
+			continue
+		}
+		list.CandidateVotes = append(list.CandidateVotes, &pollpb.CandidateVotes{
+			Address:       cand.Address,
+			Name:          v.Name,
+			SelfStake:     v.SelfStake.String(),
+			ContractVotes: v.ContractVotes.String(),
+		})
+	}
+	if err := ns.candIndexer.PutCandidateVotes(r.Height(), list); err != nil {
+		return errors.Wrapf(err, "failed to put candidate votes into indexer at height %d", r.Height())
+	}
+	return nil
 }
 
 func (ns *nativeStakingV2) Validate(ctx context.Context, act action.Action, sr protocol.StateReader) error {
@@ -120,7 +168,26 @@ func (ns *nativeStakingV2) NextCandidates(ctx context.Context, sr protocol.State
 }
 
 func (ns *nativeStakingV2) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
-	return ns.slasher.ReadState(ctx, sr, ns.candIndexer, method, args...)
+	if string(method) != "SlashHistory" {
+		return ns.slasher.ReadState(ctx, sr, ns.candIndexer, method, args...)
+	}
+	// the arg is the operator address of the delegate
+	if len(args) != 1 {
+		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	operator, err := address.FromString(string(args[0]))
+	if err != nil {
+		return nil, uint64(0), errors.Wrapf(err, "invalid delegate address %s", args[0])
+	}
+	history, height, err := ns.slasher.SlashHistory(sr, operator)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	data, err := proto.Marshal(history)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
 }
 
 func (ns *nativeStakingV2) Register(r *protocol.Registry) error {
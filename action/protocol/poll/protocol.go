@@ -70,6 +70,10 @@ type (
 	// Productivity returns the number of produced blocks per producer
 	Productivity func(uint64, uint64) (map[string]uint64, error)
 
+	// SlashSelfStake slashes the rate in percentage of the self-stake of the candidate operated by the given address,
+	// and returns the index of the self-stake bucket and the slashed amount
+	SlashSelfStake func(context.Context, protocol.StateManager, address.Address, uint32, address.Address) (uint64, *big.Int, error)
+
 	// Protocol defines the protocol of handling votes
 	Protocol interface {
 		protocol.Protocol
@@ -172,6 +176,14 @@ func NewProtocol(
 		if !ok {
 			return nil, errors.Errorf("failed to parse score threshold %s", genesisConfig.ScoreThreshold)
 		}
+		if genesisConfig.SlashRate > 100 {
+			return nil, errors.Errorf("invalid slash rate %d", genesisConfig.SlashRate)
+		}
+		if genesisConfig.SlashBeneficiary != "" {
+			if _, err := address.FromString(genesisConfig.SlashBeneficiary); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse slash beneficiary %s", genesisConfig.SlashBeneficiary)
+			}
+		}
 	}
 
 	var stakingV1 Protocol
@@ -13,6 +13,7 @@ import (
 	"github.com/iotexproject/iotex-election/util"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
@@ -239,6 +240,27 @@ func (sh *Slasher) ReadState(
 			return nil, uint64(0), err
 		}
 		return data, height, nil
+	case "CandidateHistoryByEpoch":
+		// args are start epoch number, candidate operator address or name, and number of epochs
+		if indexer == nil {
+			return nil, uint64(0), errors.New("CandidateHistoryByEpoch is only available with candidate indexer")
+		}
+		if len(args) != 3 {
+			return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		count, err := strconv.ParseUint(string(args[2]), 10, 64)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		history, err := sh.CandidateHistory(ctx, targetHeight, string(args[1]), rp.GetEpochNum(epochStartHeight), count)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		data, err := proto.Marshal(history)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		return data, targetHeight, nil
 	default:
 		return nil, uint64(0), errors.New("corresponding method isn't found")
 	}
@@ -0,0 +1,202 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"sort"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// HandleSlashSelfStake is the topic of the receipt log of slashing the self-stake of a delegate
+	HandleSlashSelfStake = "slashSelfStake"
+
+	_slashHistoryKeyPrefix = "SlashHistory."
+)
+
+// slashHistory is the list of the slashing records of a delegate
+type slashHistory []*pollpb.SlashRecord
+
+// Serialize serializes the slash history into bytes
+func (h slashHistory) Serialize() ([]byte, error) {
+	return proto.Marshal(&pollpb.SlashHistory{Records: h})
+}
+
+// Deserialize deserializes bytes into the slash history
+func (h *slashHistory) Deserialize(data []byte) error {
+	pb := &pollpb.SlashHistory{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return err
+	}
+	*h = pb.GetRecords()
+	return nil
+}
+
+// SlashHistory returns the slashing records of the delegate with the operator address
+func (sh *Slasher) SlashHistory(sr protocol.StateReader, operator address.Address) (*pollpb.SlashHistory, uint64, error) {
+	height, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	history, err := readSlashHistory(sr, operator)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return &pollpb.SlashHistory{Records: history}, height, nil
+}
+
+// slashSelfStake slashes the self-stake of the delegates whose number of epochs on probation reaches the threshold in
+// the current probation list, and returns the receipt logs and transaction logs describing the penalty. Only the
+// delegates unproductive in the last epoch are slashed, the ones which have recovered are not slashed again for the
+// earlier epochs still counted in the probation list
+func (sh *Slasher) slashSelfStake(
+	ctx context.Context,
+	sm protocol.StateManager,
+	slash SlashSelfStake,
+) ([]*action.Log, []*action.TransactionLog, error) {
+	g := genesis.MustExtractGenesisContext(ctx)
+	if g.SlashProbationThreshold == 0 || g.SlashRate == 0 {
+		return nil, nil, nil
+	}
+	var beneficiary address.Address
+	if g.SlashBeneficiary != "" {
+		addr, err := address.FromString(g.SlashBeneficiary)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid slash beneficiary %s", g.SlashBeneficiary)
+		}
+		beneficiary = addr
+	}
+	probationList, _, err := sh.getProbationList(sm, false)
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+		return nil, nil, nil
+	case err != nil:
+		return nil, nil, errors.Wrap(err, "failed to read current probation list")
+	}
+	upd, err := sh.getUnprodDelegate(sm)
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+		return nil, nil, nil
+	case err != nil:
+		return nil, nil, errors.Wrap(err, "failed to read unproductive delegates")
+	}
+	// the most recent list is the unproductive delegates of the last epoch
+	lastUnproductive := make(map[string]struct{})
+	if list := upd.DelegateList(); len(list) > 0 {
+		for _, addr := range list[0] {
+			lastUnproductive[addr] = struct{}{}
+		}
+	}
+	operators := make([]string, 0, len(probationList.ProbationInfo))
+	for addr, count := range probationList.ProbationInfo {
+		if _, ok := lastUnproductive[addr]; ok && count >= g.SlashProbationThreshold {
+			operators = append(operators, addr)
+		}
+	}
+	if len(operators) == 0 {
+		return nil, nil, nil
+	}
+	// slash in a deterministic order
+	sort.Strings(operators)
+
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	var (
+		logs  []*action.Log
+		tLogs []*action.TransactionLog
+	)
+	for _, addr := range operators {
+		operator, err := address.FromString(addr)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid delegate address %s", addr)
+		}
+		index, amount, err := slash(ctx, sm, operator, g.SlashRate, beneficiary)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to slash the self-stake of delegate %s", addr)
+		}
+		if amount.Sign() == 0 {
+			continue
+		}
+		log.L().Info("Slash the self-stake of delegate",
+			zap.String("delegate", addr),
+			zap.Uint64("bucketIndex", index),
+			zap.String("amount", amount.String()),
+			zap.Uint32("probationEpochs", probationList.ProbationInfo[addr]),
+		)
+		history, err := readSlashHistory(sm, operator)
+		if err != nil {
+			return nil, nil, err
+		}
+		record := &pollpb.SlashRecord{
+			EpochNumber:     rp.GetEpochNum(blkCtx.BlockHeight),
+			Height:          blkCtx.BlockHeight,
+			Address:         addr,
+			BucketIndex:     index,
+			Amount:          amount.String(),
+			ProbationEpochs: uint64(probationList.ProbationInfo[addr]),
+		}
+		if beneficiary != nil {
+			record.Beneficiary = beneficiary.String()
+			tLogs = append(tLogs, &action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_WITHDRAW_BUCKET,
+				Sender:    address.StakingBucketPoolAddr,
+				Recipient: beneficiary.String(),
+				Amount:    amount,
+			})
+		}
+		history = append(history, record)
+		if _, err := sm.PutState(history, protocol.KeyOption(slashHistoryKey(operator)), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to put slash history of delegate %s", addr)
+		}
+		logs = append(logs, &action.Log{
+			Address: ProtocolAddr().String(),
+			Topics: action.Topics{
+				hash.BytesToHash256([]byte(HandleSlashSelfStake)),
+				hash.BytesToHash256(operator.Bytes()),
+				hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(index)),
+			},
+			Data:        amount.Bytes(),
+			BlockHeight: blkCtx.BlockHeight,
+			ActionHash:  actionCtx.ActionHash,
+		})
+	}
+	return logs, tLogs, nil
+}
+
+func readSlashHistory(sr protocol.StateReader, operator address.Address) (slashHistory, error) {
+	var history slashHistory
+	_, err := sr.State(&history, protocol.KeyOption(slashHistoryKey(operator)), protocol.NamespaceOption(protocol.SystemNamespace))
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to read slash history of delegate %s", operator.String())
+	}
+	return history, nil
+}
+
+func slashHistoryKey(operator address.Address) []byte {
+	key := candidatesutil.ConstructKey(_slashHistoryKeyPrefix + operator.String())
+	return key[:]
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll/pollpb"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestSlashSelfStake(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+
+	var (
+		addrA       = identityset.Address(1)
+		addrB       = identityset.Address(2)
+		addrC       = identityset.Address(3)
+		beneficiary = identityset.Address(4)
+		height      = uint64(100)
+	)
+	probationList := vote.NewProbationList(90)
+	probationList.ProbationInfo[addrA.String()] = 3
+	probationList.ProbationInfo[addrB.String()] = 2
+	probationList.ProbationInfo[addrC.String()] = 4
+	// A, B and C are unproductive in the last epoch
+	upd, err := vote.NewUnproductiveDelegate(6, 20)
+	r.NoError(err)
+	r.NoError(upd.AddRecentUPD([]string{addrA.String(), addrB.String(), addrC.String()}))
+	var probationErr error
+	sh, err := NewSlasher(nil, nil,
+		func(protocol.StateReader, bool) (*vote.ProbationList, uint64, error) {
+			return probationList, 0, probationErr
+		},
+		func(protocol.StateReader) (*vote.UnproductiveDelegate, error) {
+			return upd, nil
+		},
+		nil, 2, 2, 5, 85, 6, 20, 90)
+	r.NoError(err)
+	// C has no self-stake bucket
+	slashed := map[string]uint32{}
+	slash := func(_ context.Context, _ protocol.StateManager, operator address.Address, rate uint32, _ address.Address) (uint64, *big.Int, error) {
+		slashed[operator.String()] = rate
+		if address.Equal(operator, addrC) {
+			return 0, big.NewInt(0), nil
+		}
+		return 7, big.NewInt(int64(rate) * 10), nil
+	}
+
+	g := genesis.Default
+	g.SlashProbationThreshold = 3
+	g.SlashRate = 5
+	registry := protocol.NewRegistry()
+	rp := rolldpos.NewProtocol(36, 6, 5)
+	r.NoError(registry.Register("rolldpos", rp))
+	withGenesis := func(g genesis.Genesis) context.Context {
+		ctx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), registry), g)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+		return protocol.WithActionCtx(ctx, protocol.ActionCtx{})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := g
+		disabled.SlashProbationThreshold = 0
+		logs, tLogs, err := sh.slashSelfStake(withGenesis(disabled), sm, slash)
+		r.NoError(err)
+		r.Empty(logs)
+		r.Empty(tLogs)
+		r.Empty(slashed)
+	})
+	t.Run("no probation list", func(t *testing.T) {
+		probationErr = state.ErrStateNotExist
+		defer func() { probationErr = nil }()
+		logs, _, err := sh.slashSelfStake(withGenesis(g), sm, slash)
+		r.NoError(err)
+		r.Empty(logs)
+	})
+	t.Run("burn", func(t *testing.T) {
+		logs, tLogs, err := sh.slashSelfStake(withGenesis(g), sm, slash)
+		r.NoError(err)
+		r.Equal(map[string]uint32{addrA.String(): 5, addrC.String(): 5}, slashed)
+		r.Empty(tLogs)
+		r.Len(logs, 1)
+		r.Equal(ProtocolAddr().String(), logs[0].Address)
+		r.Len(logs[0].Topics, 3)
+		r.Equal(addrA.Bytes(), logs[0].Topics[1][12:])
+		r.Equal(uint64(7), byteutil.BytesToUint64BigEndian(logs[0].Topics[2][24:]))
+		r.Equal(big.NewInt(50).Bytes(), logs[0].Data)
+	})
+	t.Run("redirect", func(t *testing.T) {
+		redirect := g
+		redirect.SlashRate = 10
+		redirect.SlashBeneficiary = beneficiary.String()
+		logs, tLogs, err := sh.slashSelfStake(withGenesis(redirect), sm, slash)
+		r.NoError(err)
+		r.Len(logs, 1)
+		r.Len(tLogs, 1)
+		r.Equal(iotextypes.TransactionLogType_WITHDRAW_BUCKET, tLogs[0].Type)
+		r.Equal(address.StakingBucketPoolAddr, tLogs[0].Sender)
+		r.Equal(beneficiary.String(), tLogs[0].Recipient)
+		r.Equal(big.NewInt(100), tLogs[0].Amount)
+
+		invalid := g
+		invalid.SlashBeneficiary = "invalid"
+		_, _, err = sh.slashSelfStake(withGenesis(invalid), sm, slash)
+		r.Error(err)
+	})
+	t.Run("recovered", func(t *testing.T) {
+		// A and C are productive in the last epoch, but still on probation for the earlier epochs
+		recovered, err := vote.NewUnproductiveDelegate(6, 20)
+		r.NoError(err)
+		r.NoError(recovered.AddRecentUPD([]string{addrA.String(), addrC.String()}))
+		r.NoError(recovered.AddRecentUPD([]string{addrB.String()}))
+		upd, recovered = recovered, upd
+		defer func() { upd = recovered }()
+		slashed = map[string]uint32{}
+		logs, tLogs, err := sh.slashSelfStake(withGenesis(g), sm, slash)
+		r.NoError(err)
+		r.Empty(logs)
+		r.Empty(tLogs)
+		r.Empty(slashed)
+	})
+	t.Run("history", func(t *testing.T) {
+		ns := &nativeStakingV2{slasher: sh}
+		data, _, err := ns.ReadState(withGenesis(g), sm, []byte("SlashHistory"), []byte(addrA.String()))
+		r.NoError(err)
+		history := &pollpb.SlashHistory{}
+		r.NoError(proto.Unmarshal(data, history))
+		r.Len(history.Records, 2)
+		epoch := rp.GetEpochNum(height)
+		r.True(proto.Equal(&pollpb.SlashRecord{
+			EpochNumber:     epoch,
+			Height:          height,
+			Address:         addrA.String(),
+			BucketIndex:     7,
+			Amount:          "50",
+			ProbationEpochs: 3,
+		}, history.Records[0]), history.Records[0].String())
+		r.Equal("100", history.Records[1].Amount)
+		r.Equal(beneficiary.String(), history.Records[1].Beneficiary)
+
+		for _, addr := range []address.Address{addrB, addrC} {
+			data, _, err = ns.ReadState(withGenesis(g), sm, []byte("SlashHistory"), []byte(addr.String()))
+			r.NoError(err)
+			r.NoError(proto.Unmarshal(data, history))
+			r.Empty(history.Records)
+		}
+		_, _, err = ns.ReadState(withGenesis(g), sm, []byte("SlashHistory"))
+		r.Error(err)
+		_, _, err = ns.ReadState(withGenesis(g), sm, []byte("SlashHistory"), []byte("invalid"))
+		r.Error(err)
+	})
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// _productivityHistoryEpochs is the number of recent epochs whose productivity is used to estimate the block reward
+	_productivityHistoryEpochs = 24
+	_year                      = 365 * 24 * time.Hour
+	// _maxEstimationDuration is the max duration of the estimation
+	_maxEstimationDuration = 10 * _year
+	// _aprBasisPoints is the denominator of the annual percentage rate
+	_aprBasisPoints = 10000
+)
+
+var (
+	// ErrInvalidDuration indicates the duration of the estimation is invalid
+	ErrInvalidDuration = errors.New("invalid duration")
+	// ErrCandidateNotFound indicates the candidate to estimate does not exist
+	ErrCandidateNotFound = errors.New("candidate is not found")
+	// ErrContractStakingBucket indicates the reward of a contract staking bucket cannot be estimated
+	ErrContractStakingBucket = errors.New("contract staking bucket is not supported")
+)
+
+// RewardEstimation is the reward projected in a duration from the current votes and the productivity history
+type RewardEstimation struct {
+	BlockReward     *big.Int
+	EpochReward     *big.Int
+	FoundationBonus *big.Int
+	NumBlocks       uint64
+	NumEpochs       uint64
+	// APR is the annual percentage rate in basis points
+	APR uint64
+}
+
+// Total returns the total reward of the estimation
+func (e *RewardEstimation) Total() *big.Int {
+	total := new(big.Int).Add(e.BlockReward, e.EpochReward)
+	return total.Add(total, e.FoundationBonus)
+}
+
+// Serialize serializes the reward estimation into bytes
+func (e *RewardEstimation) Serialize() ([]byte, error) {
+	return proto.Marshal(&rewardingpb.RewardEstimation{
+		BlockReward:     e.BlockReward.String(),
+		EpochReward:     e.EpochReward.String(),
+		FoundationBonus: e.FoundationBonus.String(),
+		NumBlocks:       e.NumBlocks,
+		NumEpochs:       e.NumEpochs,
+		Apr:             e.APR,
+	})
+}
+
+// Deserialize deserializes bytes into the reward estimation
+func (e *RewardEstimation) Deserialize(data []byte) error {
+	gen := rewardingpb.RewardEstimation{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	var ok bool
+	if e.BlockReward, ok = new(big.Int).SetString(gen.BlockReward, 10); !ok {
+		return errors.Errorf("failed to set block reward %s", gen.BlockReward)
+	}
+	if e.EpochReward, ok = new(big.Int).SetString(gen.EpochReward, 10); !ok {
+		return errors.Errorf("failed to set epoch reward %s", gen.EpochReward)
+	}
+	if e.FoundationBonus, ok = new(big.Int).SetString(gen.FoundationBonus, 10); !ok {
+		return errors.Errorf("failed to set foundation bonus %s", gen.FoundationBonus)
+	}
+	e.NumBlocks = gen.NumBlocks
+	e.NumEpochs = gen.NumEpochs
+	e.APR = gen.Apr
+	return nil
+}
+
+// EstimateCandidateReward projects the block reward, epoch reward and foundation bonus of a candidate in the duration,
+// assuming the votes of the candidates stay the same and the candidate produces blocks as in the recent epochs.
+// The APR is the reward per weighted vote of the candidate. The epoch reward of a candidate which distributes the
+// reward to its voters includes the share of the voters.
+func (p *Protocol) EstimateCandidateReward(
+	ctx context.Context,
+	sr protocol.StateReader,
+	name string,
+	duration time.Duration,
+) (*RewardEstimation, error) {
+	if duration <= 0 || duration > _maxEstimationDuration {
+		return nil, ErrInvalidDuration
+	}
+	if p.productivity == nil {
+		return nil, errors.New("productivity is not available")
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, err
+	}
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	candidates, err := poll.MustGetProtocol(protocol.MustGetRegistry(ctx)).Candidates(ctx, sr)
+	if err != nil {
+		return nil, err
+	}
+	var cand *state.Candidate
+	for _, c := range candidates {
+		if bytes.Equal(c.CanName, []byte(name)) {
+			cand = c
+			break
+		}
+	}
+	if cand == nil {
+		return nil, errors.Wrapf(ErrCandidateNotFound, "candidate %s", name)
+	}
+	a := admin{}
+	if _, err := p.state(ctx, sr, _adminKey, &a); err != nil {
+		return nil, err
+	}
+	e := exempt{}
+	if _, err := p.state(ctx, sr, _exemptKey, &e); err != nil {
+		return nil, err
+	}
+	exemptAddrs := make(map[string]interface{})
+	for _, addr := range e.addrs {
+		exemptAddrs[addr.String()] = nil
+	}
+
+	blockInterval := genesis.MustExtractGenesisContext(ctx).BlockInterval
+	if p.blockInterval != nil {
+		blockInterval = p.blockInterval(height)
+	}
+	if blockInterval <= 0 {
+		return nil, errors.New("invalid block interval")
+	}
+	blocksPerEpoch := rp.NumDelegates() * rp.NumSubEpochs(height)
+	est := &RewardEstimation{
+		BlockReward:     big.NewInt(0),
+		EpochReward:     big.NewInt(0),
+		FoundationBonus: big.NewInt(0),
+		NumBlocks:       uint64(duration / blockInterval),
+	}
+	est.NumEpochs = est.NumBlocks / blocksPerEpoch
+
+	// the share of blocks the candidate produced in the recent epochs
+	produced, total, err := p.productivityHistory(rp, height, cand.Address)
+	if err != nil {
+		return nil, err
+	}
+	if total > 0 {
+		est.BlockReward.Mul(a.blockReward, new(big.Int).SetUint64(est.NumBlocks))
+		est.BlockReward.Mul(est.BlockReward, new(big.Int).SetUint64(produced))
+		est.BlockReward.Div(est.BlockReward, new(big.Int).SetUint64(total))
+	}
+
+	// the epoch reward is split by the votes of the top candidates, and prorated by the number of blocks
+	filtered := make([]*state.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if _, ok := exemptAddrs[c.Address]; !ok {
+			filtered = append(filtered, c)
+		}
+	}
+	if uint64(len(filtered)) > a.numDelegatesForEpochReward {
+		filtered = filtered[:a.numDelegatesForEpochReward]
+	}
+	totalVotes, rewarded := big.NewInt(0), false
+	for _, c := range filtered {
+		totalVotes.Add(totalVotes, c.Votes)
+		rewarded = rewarded || c == cand
+	}
+	if rewarded && totalVotes.Sign() > 0 {
+		est.EpochReward.Mul(a.epochReward, cand.Votes)
+		est.EpochReward.Mul(est.EpochReward, new(big.Int).SetUint64(est.NumBlocks))
+		est.EpochReward.Div(est.EpochReward, new(big.Int).Mul(totalVotes, new(big.Int).SetUint64(blocksPerEpoch)))
+	}
+
+	// the foundation bonus is granted to the top candidates in the bonus epochs
+	bonused := false
+	for i, count := 0, uint64(0); i < len(candidates) && count < a.numDelegatesForFoundationBonus; i++ {
+		if _, ok := exemptAddrs[candidates[i].Address]; ok {
+			continue
+		}
+		if candidates[i].Votes.Sign() == 0 {
+			continue
+		}
+		count++
+		if candidates[i] == cand {
+			bonused = true
+			break
+		}
+	}
+	if bonused {
+		epochNum := rp.GetEpochNum(height)
+		for epoch := epochNum; epoch < epochNum+est.NumEpochs; epoch++ {
+			if a.grantFoundationBonus(epoch) || (epoch >= p.cfg.FoundationBonusP2StartEpoch && epoch <= p.cfg.FoundationBonusP2EndEpoch) {
+				est.FoundationBonus.Add(est.FoundationBonus, a.foundationBonus)
+			}
+		}
+	}
+	est.APR = annualPercentageRate(est.Total(), cand.Votes, duration)
+	return est, nil
+}
+
+// EstimateBucketReward projects the reward of a native staking bucket in the duration, which is the share of the
+// epoch reward distributed by the candidate it votes for. The bucket earns nothing if the candidate does not
+// distribute the reward to its voters or the bucket is unstaked. The APR is the reward per staked amount.
+// The contract staking buckets are not supported, since their indices are not unique across the contracts.
+func (p *Protocol) EstimateBucketReward(
+	ctx context.Context,
+	sr protocol.StateReader,
+	index uint64,
+	duration time.Duration,
+) (*RewardEstimation, error) {
+	sp := staking.FindProtocol(protocol.MustGetRegistry(ctx))
+	if sp == nil {
+		return nil, errors.New("staking protocol is not registered")
+	}
+	name, amount, weight, err := sp.BucketVoteWeight(sr, index)
+	if err != nil {
+		return nil, err
+	}
+	cand, err := p.EstimateCandidateReward(ctx, sr, name, duration)
+	if err != nil {
+		return nil, err
+	}
+	est := &RewardEstimation{
+		BlockReward:     big.NewInt(0),
+		EpochReward:     big.NewInt(0),
+		FoundationBonus: big.NewInt(0),
+		NumBlocks:       cand.NumBlocks,
+		NumEpochs:       cand.NumEpochs,
+	}
+	rate, voters, ok, err := sp.CurrentRewardDistribution(ctx, sr, name)
+	if err != nil {
+		return nil, err
+	}
+	totalWeight := big.NewInt(0)
+	for _, v := range voters {
+		totalWeight.Add(totalWeight, v.Weight)
+	}
+	if ok && weight.Sign() > 0 && totalWeight.Sign() > 0 && rate < action.MaxCommissionRate {
+		est.EpochReward.Mul(cand.EpochReward, new(big.Int).SetUint64(action.MaxCommissionRate-rate))
+		est.EpochReward.Mul(est.EpochReward, weight)
+		est.EpochReward.Div(est.EpochReward, new(big.Int).Mul(totalWeight, new(big.Int).SetUint64(action.MaxCommissionRate)))
+	}
+	est.APR = annualPercentageRate(est.Total(), amount, duration)
+	return est, nil
+}
+
+// productivityHistory returns the number of blocks produced by the producer and the total number of blocks in the
+// recent completed epochs, or in the current epoch if there is no completed epoch
+func (p *Protocol) productivityHistory(rp *rolldpos.Protocol, height uint64, producer string) (uint64, uint64, error) {
+	if height == 0 {
+		return 0, 0, nil
+	}
+	epochNum := rp.GetEpochNum(height)
+	if epochNum == 1 {
+		total, produce, err := rp.ProductivityByEpoch(epochNum, height, p.productivity)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to get productivity of epoch %d", epochNum)
+		}
+		return produce[producer], total, nil
+	}
+	startEpoch := uint64(1)
+	if epochNum > _productivityHistoryEpochs {
+		startEpoch = epochNum - _productivityHistoryEpochs
+	}
+	p.productivityMutex.Lock()
+	defer p.productivityMutex.Unlock()
+	if p.epochProductivity == nil {
+		p.epochProductivity = make(map[uint64]map[string]uint64)
+	}
+	// the productivity of a completed epoch does not change, so only the new epochs are read
+	for epoch := range p.epochProductivity {
+		if epoch < startEpoch || epoch >= epochNum {
+			delete(p.epochProductivity, epoch)
+		}
+	}
+	var produced, total uint64
+	for epoch := startEpoch; epoch < epochNum; epoch++ {
+		produce, ok := p.epochProductivity[epoch]
+		if !ok {
+			var err error
+			if _, produce, err = rp.ProductivityByEpoch(epoch, height, p.productivity); err != nil {
+				return 0, 0, errors.Wrapf(err, "failed to get productivity of epoch %d", epoch)
+			}
+			p.epochProductivity[epoch] = produce
+		}
+		produced += produce[producer]
+		total += rp.GetEpochLastBlockHeight(epoch) - rp.GetEpochHeight(epoch) + 1
+	}
+	return produced, total, nil
+}
+
+func annualPercentageRate(reward, principal *big.Int, duration time.Duration) uint64 {
+	if principal.Sign() <= 0 || duration <= 0 {
+		return 0
+	}
+	rate := new(big.Int).Mul(reward, big.NewInt(int64(_year)))
+	rate.Mul(rate, big.NewInt(_aprBasisPoints))
+	rate.Div(rate, new(big.Int).Mul(principal, big.NewInt(int64(duration))))
+	if !rate.IsUint64() {
+		return 0
+	}
+	return rate.Uint64()
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type heightStateManager struct {
+	protocol.StateManager
+	height uint64
+}
+
+func (sm *heightStateManager) Height() (uint64, error) {
+	return sm.height, nil
+}
+
+func TestProtocol_EstimateReward(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		r := require.New(t)
+		rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+		height := rp.GetEpochHeight(3)
+		sm = &heightStateManager{StateManager: sm, height: height}
+		blocksPerEpoch := rp.NumDelegates() * rp.NumSubEpochs(height)
+		duration := time.Duration(10*blocksPerEpoch) * 5 * time.Second
+
+		_, err := p.EstimateCandidateReward(ctx, sm, "test1", duration)
+		r.EqualError(err, "productivity is not available")
+		p.blockInterval = func(uint64) time.Duration { return 5 * time.Second }
+		reads := 0
+		p.productivity = func(start, end uint64) (map[string]uint64, error) {
+			// the productivity of the completed epochs
+			r.Equal(rp.GetEpochNum(start), rp.GetEpochNum(end))
+			r.Equal(rp.GetEpochHeight(rp.GetEpochNum(start)), start)
+			r.Equal(rp.GetEpochLastBlockHeight(rp.GetEpochNum(start)), end)
+			r.Less(rp.GetEpochNum(start), uint64(3))
+			reads++
+			return map[string]uint64{identityset.Address(27).String(): blocksPerEpoch / 4}, nil
+		}
+		_, err = p.EstimateCandidateReward(ctx, sm, "test1", 0)
+		r.Equal(ErrInvalidDuration, err)
+		_, err = p.EstimateCandidateReward(ctx, sm, "test1", _maxEstimationDuration+time.Second)
+		r.Equal(ErrInvalidDuration, err)
+		_, err = p.EstimateCandidateReward(ctx, sm, "test1", duration)
+		r.Equal(ErrCandidateNotFound, errors.Cause(err))
+
+		g := genesis.MustExtractGenesisContext(ctx)
+		g.ToBeEnabledBlockHeight = 0
+		g.Staking.BootstrapCandidates = []genesis.BootstrapCandidate{
+			{
+				OwnerAddress:      identityset.Address(33).String(),
+				OperatorAddress:   identityset.Address(27).String(),
+				RewardAddress:     identityset.Address(0).String(),
+				Name:              "test1",
+				SelfStakingTokens: unit.ConvertIotxToRau(1200000).String(),
+			},
+		}
+		ctx = genesis.WithGenesisContext(ctx, g)
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		sp, err := staking.NewProtocol(staking.HelperCtx{
+			DepositGas:    DepositGas,
+			BlockInterval: func(uint64) time.Duration { return 5 * time.Second },
+		}, &staking.BuilderConfig{
+			Staking:                  g.Staking,
+			PersistStakingPatchBlock: math.MaxUint64,
+		}, nil, nil, nil, g.GreenlandBlockHeight)
+		r.NoError(err)
+		r.NoError(sp.Register(protocol.MustGetRegistry(ctx)))
+		v, err := sp.Start(ctx, sm)
+		r.NoError(err)
+		r.NoError(sm.WriteView(sp.Name(), v))
+		r.NoError(sp.CreateGenesisStates(ctx, sm))
+		candidates, err := poll.MustGetProtocol(protocol.MustGetRegistry(ctx)).Candidates(ctx, sm)
+		r.NoError(err)
+		candidates[0].CanName = []byte("test1")
+
+		// the candidate produced a quarter of the blocks, and has 40% of the votes of the top 4 candidates
+		est, err := p.EstimateCandidateReward(ctx, sm, "test1", duration)
+		r.NoError(err)
+		r.Equal(10*blocksPerEpoch, est.NumBlocks)
+		r.Equal(uint64(10), est.NumEpochs)
+		r.Equal(new(big.Int).SetUint64(10*10*blocksPerEpoch/4), est.BlockReward)
+		r.Equal(big.NewInt(400), est.EpochReward)
+		r.Equal(big.NewInt(50), est.FoundationBonus)
+		r.Equal(annualPercentageRate(est.Total(), candidates[0].Votes, duration), est.APR)
+		// the productivity of the completed epochs is read once
+		r.Equal(2, reads)
+		_, err = p.EstimateCandidateReward(ctx, sm, "test1", duration)
+		r.NoError(err)
+		r.Equal(2, reads)
+
+		// the bucket earns nothing before the candidate opts in
+		bucketEst, err := p.EstimateBucketReward(ctx, sm, 0, duration)
+		r.NoError(err)
+		r.Zero(bucketEst.Total().Sign())
+		r.Equal(est.NumBlocks, bucketEst.NumBlocks)
+		_, err = p.EstimateBucketReward(ctx, sm, 1, duration)
+		r.Error(err)
+
+		csm, err := staking.NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		cand := csm.GetByName("test1")
+		rate := uint64(1000)
+		cand.CommissionRate = &rate
+		r.NoError(csm.Upsert(cand))
+		r.NoError(csm.Commit(ctx))
+		// the self-stake bucket is the only voter, which shares the reward except the 10% commission
+		data, _, err := p.ReadState(ctx, sm, []byte("EstimateBucketReward"), []byte("0"), []byte(strconv.FormatInt(int64(duration/time.Second), 10)))
+		r.NoError(err)
+		bucketEst = &RewardEstimation{}
+		r.NoError(bucketEst.Deserialize(data))
+		r.Equal(big.NewInt(360), bucketEst.EpochReward)
+		r.Zero(bucketEst.BlockReward.Sign())
+		r.Zero(bucketEst.FoundationBonus.Sign())
+		r.Equal(annualPercentageRate(big.NewInt(360), unit.ConvertIotxToRau(1200000), duration), bucketEst.APR)
+
+		data, _, err = p.ReadState(ctx, sm, []byte("EstimateCandidateReward"), []byte("test1"), []byte(strconv.FormatInt(int64(duration/time.Second), 10)))
+		r.NoError(err)
+		readEst := &RewardEstimation{}
+		r.NoError(readEst.Deserialize(data))
+		r.Equal(est, readEst)
+		_, _, err = p.ReadState(ctx, sm, []byte("EstimateCandidateReward"), []byte("test1"))
+		r.Error(err)
+		_, _, err = p.ReadState(ctx, sm, []byte("EstimateCandidateReward"), []byte("test1"), []byte(strconv.FormatUint(math.MaxUint64, 10)))
+		r.Equal(ErrInvalidDuration, errors.Cause(err))
+		_, _, err = p.ReadState(ctx, sm, []byte("EstimateBucketReward"), []byte("0"), []byte("100"), []byte(identityset.Address(1).String()))
+		r.Equal(ErrContractStakingBucket, errors.Cause(err))
+	}, false)
+}
+
+func TestAnnualPercentageRate(t *testing.T) {
+	r := require.New(t)
+	r.Equal(uint64(1000), annualPercentageRate(big.NewInt(10), big.NewInt(100), _year))
+	r.Equal(uint64(2000), annualPercentageRate(big.NewInt(10), big.NewInt(100), _year/2))
+	r.Zero(annualPercentageRate(big.NewInt(10), big.NewInt(0), _year))
+	r.Zero(annualPercentageRate(big.NewInt(10), big.NewInt(100), 0))
+}
@@ -27,6 +27,10 @@ func BuildReadStateRequest(data []byte) (protocol.StateContext, error) {
 		return newAvailableBalanceStateContext()
 	case hex.EncodeToString(_unclaimedBalanceMethod.ID):
 		return newUnclaimedBalanceStateContext(data[4:])
+	case hex.EncodeToString(_estimateCandidateRewardMethod.ID):
+		return newEstimateCandidateRewardStateContext(data[4:])
+	case hex.EncodeToString(_estimateBucketRewardMethod.ID):
+		return newEstimateBucketRewardStateContext(data[4:])
 	default:
 		return nil, errInvalidCallSig
 	}
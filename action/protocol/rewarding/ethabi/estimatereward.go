@@ -0,0 +1,186 @@
+package ethabi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/abiutil"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+)
+
+const (
+	_estimateCandidateRewardMethodName = "EstimateCandidateReward"
+	_estimateBucketRewardMethodName    = "EstimateBucketReward"
+
+	_rewardEstimationOutputsABI = `[
+			{
+				"components": [
+					{
+						"internalType": "uint256",
+						"name": "blockReward",
+						"type": "uint256"
+					},
+					{
+						"internalType": "uint256",
+						"name": "epochReward",
+						"type": "uint256"
+					},
+					{
+						"internalType": "uint256",
+						"name": "foundationBonus",
+						"type": "uint256"
+					},
+					{
+						"internalType": "uint64",
+						"name": "numBlocks",
+						"type": "uint64"
+					},
+					{
+						"internalType": "uint64",
+						"name": "numEpochs",
+						"type": "uint64"
+					},
+					{
+						"internalType": "uint64",
+						"name": "apr",
+						"type": "uint64"
+					}
+				],
+				"internalType": "struct IRewarding.RewardEstimation",
+				"name": "",
+				"type": "tuple"
+			}
+		]`
+
+	_estimateRewardInterfaceABI = `[
+	{
+		"inputs": [
+			{
+				"internalType": "string",
+				"name": "candName",
+				"type": "string"
+			},
+			{
+				"internalType": "uint64",
+				"name": "duration",
+				"type": "uint64"
+			}
+		],
+		"name": "estimateCandidateReward",
+		"outputs": ` + _rewardEstimationOutputsABI + `,
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint64",
+				"name": "bucketIndex",
+				"type": "uint64"
+			},
+			{
+				"internalType": "uint64",
+				"name": "duration",
+				"type": "uint64"
+			}
+		],
+		"name": "estimateBucketReward",
+		"outputs": ` + _rewardEstimationOutputsABI + `,
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+)
+
+var (
+	_estimateCandidateRewardMethod abi.Method
+	_estimateBucketRewardMethod    abi.Method
+)
+
+func init() {
+	_estimateCandidateRewardMethod = abiutil.MustLoadMethod(_estimateRewardInterfaceABI, "estimateCandidateReward")
+	_estimateBucketRewardMethod = abiutil.MustLoadMethod(_estimateRewardInterfaceABI, "estimateBucketReward")
+}
+
+// EstimateRewardStateContext context for EstimateCandidateReward and EstimateBucketReward, the duration is in seconds
+type EstimateRewardStateContext struct {
+	*protocol.BaseStateContext
+}
+
+func newEstimateCandidateRewardStateContext(data []byte) (*EstimateRewardStateContext, error) {
+	paramsMap := map[string]interface{}{}
+	if err := _estimateCandidateRewardMethod.Inputs.UnpackIntoMap(paramsMap, data); err != nil {
+		return nil, err
+	}
+	candName, ok := paramsMap["candName"].(string)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	duration, ok := paramsMap["duration"].(uint64)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	return newEstimateRewardStateContext(&_estimateCandidateRewardMethod, _estimateCandidateRewardMethodName, candName, duration), nil
+}
+
+func newEstimateBucketRewardStateContext(data []byte) (*EstimateRewardStateContext, error) {
+	paramsMap := map[string]interface{}{}
+	if err := _estimateBucketRewardMethod.Inputs.UnpackIntoMap(paramsMap, data); err != nil {
+		return nil, err
+	}
+	index, ok := paramsMap["bucketIndex"].(uint64)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	duration, ok := paramsMap["duration"].(uint64)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	return newEstimateRewardStateContext(&_estimateBucketRewardMethod, _estimateBucketRewardMethodName, strconv.FormatUint(index, 10), duration), nil
+}
+
+func newEstimateRewardStateContext(method *abi.Method, methodName, target string, duration uint64) *EstimateRewardStateContext {
+	return &EstimateRewardStateContext{
+		&protocol.BaseStateContext{
+			Parameter: &protocol.Parameters{
+				MethodName: []byte(methodName),
+				Arguments:  [][]byte{[]byte(target), []byte(strconv.FormatUint(duration, 10))},
+			},
+			Method: method,
+		},
+	}
+}
+
+// EncodeToEth encode proto to eth
+func (r *EstimateRewardStateContext) EncodeToEth(resp *iotexapi.ReadStateResponse) (string, error) {
+	var result rewardingpb.RewardEstimation
+	if err := proto.Unmarshal(resp.Data, &result); err != nil {
+		return "", err
+	}
+	amounts := make([]*big.Int, 3)
+	for i, s := range []string{result.BlockReward, result.EpochReward, result.FoundationBonus} {
+		amount, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return "", errConvertBigNumber
+		}
+		amounts[i] = amount
+	}
+	data, err := r.Method.Outputs.Pack(struct {
+		BlockReward     *big.Int
+		EpochReward     *big.Int
+		FoundationBonus *big.Int
+		NumBlocks       uint64
+		NumEpochs       uint64
+		Apr             uint64
+	}{amounts[0], amounts[1], amounts[2], result.NumBlocks, result.NumEpochs, result.Apr})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
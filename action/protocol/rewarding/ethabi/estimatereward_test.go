@@ -0,0 +1,91 @@
+package ethabi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+)
+
+func TestEstimateCandidateRewardStateContext(t *testing.T) {
+	r := require.New(t)
+
+	data, err := _estimateCandidateRewardMethod.Inputs.Pack("delegate", uint64(86400))
+	r.NoError(err)
+	ctx, err := BuildReadStateRequest(append(_estimateCandidateRewardMethod.ID, data...))
+	r.NoError(err)
+	r.IsType(&EstimateRewardStateContext{}, ctx)
+	r.EqualValues("EstimateCandidateReward", string(ctx.Parameters().MethodName))
+	r.Len(ctx.Parameters().Arguments, 2)
+	r.EqualValues("delegate", string(ctx.Parameters().Arguments[0]))
+	r.EqualValues("86400", string(ctx.Parameters().Arguments[1]))
+
+	_, err = newEstimateCandidateRewardStateContext(data[:32])
+	r.Error(err)
+}
+
+func TestEstimateBucketRewardStateContext(t *testing.T) {
+	r := require.New(t)
+
+	data, err := _estimateBucketRewardMethod.Inputs.Pack(uint64(12), uint64(3600))
+	r.NoError(err)
+	ctx, err := BuildReadStateRequest(append(_estimateBucketRewardMethod.ID, data...))
+	r.NoError(err)
+	r.EqualValues("EstimateBucketReward", string(ctx.Parameters().MethodName))
+	r.Len(ctx.Parameters().Arguments, 2)
+	r.EqualValues("12", string(ctx.Parameters().Arguments[0]))
+	r.EqualValues("3600", string(ctx.Parameters().Arguments[1]))
+}
+
+func TestEstimateRewardEncodeToEth(t *testing.T) {
+	r := require.New(t)
+
+	ctx, err := newEstimateBucketRewardStateContext(func() []byte {
+		data, err := _estimateBucketRewardMethod.Inputs.Pack(uint64(1), uint64(1))
+		r.NoError(err)
+		return data
+	}())
+	r.NoError(err)
+	est := &rewardingpb.RewardEstimation{
+		BlockReward:     "10000",
+		EpochReward:     "20000",
+		FoundationBonus: "30000",
+		NumBlocks:       720,
+		NumEpochs:       1,
+		Apr:             512,
+	}
+	resp, err := proto.Marshal(est)
+	r.NoError(err)
+	data, err := ctx.EncodeToEth(&iotexapi.ReadStateResponse{Data: resp})
+	r.NoError(err)
+	raw, err := hex.DecodeString(data)
+	r.NoError(err)
+	out, err := _estimateBucketRewardMethod.Outputs.Unpack(raw)
+	r.NoError(err)
+	r.Len(out, 1)
+	ret := out[0].(struct {
+		BlockReward     *big.Int `json:"blockReward"`
+		EpochReward     *big.Int `json:"epochReward"`
+		FoundationBonus *big.Int `json:"foundationBonus"`
+		NumBlocks       uint64   `json:"numBlocks"`
+		NumEpochs       uint64   `json:"numEpochs"`
+		Apr             uint64   `json:"apr"`
+	})
+	r.Equal(big.NewInt(10000), ret.BlockReward)
+	r.Equal(big.NewInt(20000), ret.EpochReward)
+	r.Equal(big.NewInt(30000), ret.FoundationBonus)
+	r.Equal(uint64(720), ret.NumBlocks)
+	r.Equal(uint64(1), ret.NumEpochs)
+	r.Equal(uint64(512), ret.Apr)
+
+	est.EpochReward = "abc"
+	resp, err = proto.Marshal(est)
+	r.NoError(err)
+	_, err = ctx.EncodeToEth(&iotexapi.ReadStateResponse{Data: resp})
+	r.Equal(errConvertBigNumber, err)
+}
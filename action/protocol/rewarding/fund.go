@@ -92,31 +92,45 @@ func (p *Protocol) deposit(
 		return nil, err
 	}
 	// Add balance to fund
+	addToFund := func(sm protocol.StateManager) error {
+		return p.addToFund(ctx, sm, receiver, amount, sgdAmount)
+	}
+	if deferrer, ok := protocol.GetDepositDeferrerCtx(ctx); ok {
+		deferrer(addToFund)
+	} else if err := addToFund(sm); err != nil {
+		return nil, err
+	}
+	return &action.TransactionLog{
+		Type:      transactionLogType,
+		Sender:    actionCtx.Caller.String(),
+		Recipient: address.RewardingPoolAddr,
+		Amount:    amount,
+	}, nil
+}
+
+func (p *Protocol) addToFund(
+	ctx context.Context,
+	sm protocol.StateManager,
+	receiver address.Address,
+	amount, sgdAmount *big.Int,
+) error {
 	f := fund{}
 	if _, err := p.state(ctx, sm, _fundKey, &f); err != nil {
-		return nil, err
+		return err
 	}
 	f.totalBalance.Add(f.totalBalance, amount)
 	f.unclaimedBalance.Add(f.unclaimedBalance, amount)
 	if !isZero(sgdAmount) {
 		f.unclaimedBalance.Sub(f.unclaimedBalance, sgdAmount)
 		if f.unclaimedBalance.Sign() == -1 {
-			return nil, errors.New("no enough available balance")
+			return errors.New("no enough available balance")
 		}
 		// grant sgd amount to receiver
 		if err := p.grantToAccount(ctx, sm, receiver, sgdAmount); err != nil {
-			return nil, err
+			return err
 		}
 	}
-	if err := p.putState(ctx, sm, _fundKey, &f); err != nil {
-		return nil, err
-	}
-	return &action.TransactionLog{
-		Type:      transactionLogType,
-		Sender:    actionCtx.Caller.String(),
-		Recipient: address.RewardingPoolAddr,
-		Amount:    amount,
-	}, nil
+	return p.putState(ctx, sm, _fundKey, &f)
 }
 
 // TotalBalance returns the total balance of the rewarding fund
@@ -8,6 +8,9 @@ package rewarding
 import (
 	"context"
 	"math/big"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -19,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/pkg/log"
@@ -45,13 +49,40 @@ var (
 // reward amount, users to donate tokens to the fund, block producers to grant them block and epoch reward and,
 // beneficiaries to claim the balance into their personal account.
 type Protocol struct {
-	keyPrefix []byte
-	addr      address.Address
-	cfg       genesis.Rewarding
+	keyPrefix     []byte
+	addr          address.Address
+	cfg           genesis.Rewarding
+	productivity  poll.Productivity
+	blockInterval func(uint64) time.Duration
+
+	// the productivity of the completed epochs used to estimate the block reward, keyed by the epoch number
+	productivityMutex sync.Mutex
+	epochProductivity map[uint64]map[string]uint64
+}
+
+// Option is optional setting for rewarding protocol
+type Option func(*Protocol) error
+
+// WithProductivity sets the function returning the number of produced blocks per producer, which is used to
+// estimate the block reward
+func WithProductivity(productivity poll.Productivity) Option {
+	return func(p *Protocol) error {
+		p.productivity = productivity
+		return nil
+	}
+}
+
+// WithBlockInterval sets the function returning the block interval at a height, which is used to estimate the
+// number of blocks in a duration
+func WithBlockInterval(blockInterval func(uint64) time.Duration) Option {
+	return func(p *Protocol) error {
+		p.blockInterval = blockInterval
+		return nil
+	}
 }
 
 // NewProtocol instantiates a rewarding protocol instance.
-func NewProtocol(cfg genesis.Rewarding) *Protocol {
+func NewProtocol(cfg genesis.Rewarding, opts ...Option) *Protocol {
 	h := hash.Hash160b([]byte(_protocolID))
 	addr, err := address.FromBytes(h[:])
 	if err != nil {
@@ -60,11 +91,17 @@ func NewProtocol(cfg genesis.Rewarding) *Protocol {
 	if err = validateFoundationBonusExtension(cfg); err != nil {
 		log.L().Panic("failed to validate foundation bonus extension", zap.Error(err))
 	}
-	return &Protocol{
+	p := &Protocol{
 		keyPrefix: h[:],
 		addr:      addr,
 		cfg:       cfg,
 	}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			log.L().Panic("failed to execute rewarding protocol creation option", zap.Error(err))
+		}
+	}
+	return p
 }
 
 // ProtocolAddr returns the address generated from protocol id
@@ -289,6 +326,48 @@ func (p *Protocol) ReadState(
 			return nil, uint64(0), err
 		}
 		return []byte(balance.String()), height, nil
+	case "EstimateCandidateReward", "EstimateBucketReward":
+		// the optional 3rd argument of EstimateBucketReward is the address of the staking contract
+		if len(args) != 2 && (string(method) != "EstimateBucketReward" || len(args) != 3) {
+			return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		seconds, err := strconv.ParseUint(string(args[1]), 10, 64)
+		if err != nil {
+			return nil, uint64(0), errors.Wrap(err, "invalid duration")
+		}
+		if seconds > uint64(_maxEstimationDuration/time.Second) {
+			return nil, uint64(0), errors.Wrapf(ErrInvalidDuration, "duration %d seconds", seconds)
+		}
+		duration := time.Duration(seconds) * time.Second
+		var est *RewardEstimation
+		if string(method) == "EstimateCandidateReward" {
+			est, err = p.EstimateCandidateReward(ctx, sr, string(args[0]), duration)
+		} else {
+			index, perr := strconv.ParseUint(string(args[0]), 10, 64)
+			if perr != nil {
+				return nil, uint64(0), errors.Wrap(perr, "invalid bucket index")
+			}
+			if len(args) == 3 && len(args[2]) > 0 {
+				contract, perr := address.FromString(string(args[2]))
+				if perr != nil {
+					return nil, uint64(0), errors.Wrap(perr, "invalid contract address")
+				}
+				return nil, uint64(0), errors.Wrapf(ErrContractStakingBucket, "bucket %d of contract %s", index, contract.String())
+			}
+			est, err = p.EstimateBucketReward(ctx, sr, index, duration)
+		}
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		data, err := est.Serialize()
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		height, err := sr.Height()
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		return data, height, nil
 	default:
 		return nil, uint64(0), errors.New("corresponding method isn't found")
 	}
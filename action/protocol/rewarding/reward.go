@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/state"
@@ -182,10 +183,14 @@ func (p *Protocol) GrantEpochReward(
 	if err != nil {
 		return nil, err
 	}
-	addrs, amounts, err := p.splitEpochReward(epochStartHeight, sm, candidates, a.epochReward, a.numDelegatesForEpochReward, exemptAddrs, uqdMap)
+	rewardedCands, addrs, amounts, err := p.splitEpochReward(epochStartHeight, sm, candidates, a.epochReward, a.numDelegatesForEpochReward, exemptAddrs, uqdMap)
 	if err != nil {
 		return nil, err
 	}
+	var sp *staking.Protocol
+	if protocol.MustGetFeatureCtx(ctx).DistributeRewardToVoters {
+		sp = staking.FindProtocol(protocol.MustGetRegistry(ctx))
+	}
 	actualTotalReward := big.NewInt(0)
 	rewardLogs := make([]*action.Log, 0)
 	for i := range addrs {
@@ -197,25 +202,35 @@ func (p *Protocol) GrantEpochReward(
 		if amounts[i].Cmp(big.NewInt(0)) == 0 {
 			continue
 		}
-		if err := p.grantToAccount(ctx, sm, addrs[i], amounts[i]); err != nil {
-			return nil, err
+		// the candidate which opted in shares the reward except the commission with its voters
+		amount, voters, voterAmounts := amounts[i], []*staking.VoterWeight(nil), []*big.Int(nil)
+		if sp != nil {
+			rate, weights, ok, err := sp.RewardDistribution(ctx, sm, string(rewardedCands[i].CanName))
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				voters = weights
+				amount, voterAmounts = splitVoterReward(amounts[i], rate, voters)
+			}
 		}
-		rewardLog := rewardingpb.RewardLog{
-			Type:   rewardingpb.RewardLog_EPOCH_REWARD,
-			Addr:   addrs[i].String(),
-			Amount: amounts[i].String(),
+		if amount.Sign() > 0 {
+			l, err := p.grantWithLog(ctx, sm, rewardingpb.RewardLog_EPOCH_REWARD, addrs[i], amount)
+			if err != nil {
+				return nil, err
+			}
+			rewardLogs = append(rewardLogs, l)
 		}
-		data, err := proto.Marshal(&rewardLog)
-		if err != nil {
-			return nil, err
+		for j := range voters {
+			if voterAmounts[j].Sign() == 0 {
+				continue
+			}
+			l, err := p.grantWithLog(ctx, sm, rewardingpb.RewardLog_VOTER_REWARD, voters[j].Voter, voterAmounts[j])
+			if err != nil {
+				return nil, err
+			}
+			rewardLogs = append(rewardLogs, l)
 		}
-		rewardLogs = append(rewardLogs, &action.Log{
-			Address:     p.addr.String(),
-			Topics:      nil,
-			Data:        data,
-			BlockHeight: blkCtx.BlockHeight,
-			ActionHash:  actionCtx.ActionHash,
-		})
 		actualTotalReward = big.NewInt(0).Add(actualTotalReward, amounts[i])
 	}
 
@@ -402,6 +417,35 @@ func (p *Protocol) claimFromAccount(ctx context.Context, sm protocol.StateManage
 	return accountutil.StoreAccount(sm, addr, primAcc)
 }
 
+// grantWithLog grants the reward to the account, and returns the reward log
+func (p *Protocol) grantWithLog(
+	ctx context.Context,
+	sm protocol.StateManager,
+	rewardType rewardingpb.RewardLog_RewardType,
+	addr address.Address,
+	amount *big.Int,
+) (*action.Log, error) {
+	if err := p.grantToAccount(ctx, sm, addr, amount); err != nil {
+		return nil, err
+	}
+	rewardLog := rewardingpb.RewardLog{
+		Type:   rewardType,
+		Addr:   addr.String(),
+		Amount: amount.String(),
+	}
+	data, err := proto.Marshal(&rewardLog)
+	if err != nil {
+		return nil, err
+	}
+	return &action.Log{
+		Address:     p.addr.String(),
+		Topics:      nil,
+		Data:        data,
+		BlockHeight: protocol.MustGetBlockCtx(ctx).BlockHeight,
+		ActionHash:  protocol.MustGetActionCtx(ctx).ActionHash,
+	}, nil
+}
+
 func (p *Protocol) updateRewardHistory(ctx context.Context, sm protocol.StateManager, prefix []byte, index uint64) error {
 	var indexBytes [8]byte
 	enc.MachineEndian.PutUint64(indexBytes[:], index)
@@ -416,7 +460,7 @@ func (p *Protocol) splitEpochReward(
 	numDelegatesForEpochReward uint64,
 	exemptAddrs map[string]interface{},
 	uqd map[string]bool,
-) ([]address.Address, []*big.Int, error) {
+) ([]*state.Candidate, []address.Address, []*big.Int, error) {
 	filteredCandidates := make([]*state.Candidate, 0)
 	for _, candidate := range candidates {
 		if _, ok := exemptAddrs[candidate.Address]; ok {
@@ -426,7 +470,7 @@ func (p *Protocol) splitEpochReward(
 	}
 	candidates = filteredCandidates
 	if len(candidates) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 	// We at most allow numDelegatesForEpochReward delegates to get the epoch reward
 	if uint64(len(candidates)) > numDelegatesForEpochReward {
@@ -440,7 +484,7 @@ func (p *Protocol) splitEpochReward(
 		if candidate.RewardAddress != "" {
 			rewardAddr, err = address.FromString(candidate.RewardAddress)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 		} else {
 			log.S().Warnf("Candidate %s doesn't have a reward address", candidate.Address)
@@ -463,7 +507,32 @@ func (p *Protocol) splitEpochReward(
 		amountPerAddr = big.NewInt(0).Div(big.NewInt(0).Mul(totalAmount, candidate.Votes), totalWeight)
 		amounts = append(amounts, amountPerAddr)
 	}
-	return rewardAddrs, amounts, nil
+	return candidates, rewardAddrs, amounts, nil
+}
+
+// splitVoterReward splits the epoch reward of a candidate which distributes it to the voters, the voters share
+// the reward except the commission pro-rata to their vote weights, the rest goes to the candidate
+func splitVoterReward(amount *big.Int, commissionRate uint64, voters []*staking.VoterWeight) (*big.Int, []*big.Int) {
+	totalWeight := big.NewInt(0)
+	for _, v := range voters {
+		totalWeight.Add(totalWeight, v.Weight)
+	}
+	voterAmounts := make([]*big.Int, len(voters))
+	if totalWeight.Sign() == 0 || commissionRate >= action.MaxCommissionRate {
+		for i := range voterAmounts {
+			voterAmounts[i] = big.NewInt(0)
+		}
+		return new(big.Int).Set(amount), voterAmounts
+	}
+	maxRate := new(big.Int).SetUint64(action.MaxCommissionRate)
+	votersShare := new(big.Int).Mul(amount, new(big.Int).SetUint64(action.MaxCommissionRate-commissionRate))
+	votersShare.Div(votersShare, maxRate)
+	candidateAmount := new(big.Int).Set(amount)
+	for i, v := range voters {
+		voterAmounts[i] = new(big.Int).Div(new(big.Int).Mul(votersShare, v.Weight), totalWeight)
+		candidateAmount.Sub(candidateAmount, voterAmounts[i])
+	}
+	return candidateAmount, voterAmounts
 }
 
 func (p *Protocol) assertNoRewardYet(ctx context.Context, sm protocol.StateManager, prefix []byte, index uint64) error {
@@ -7,6 +7,7 @@ package rewarding
 
 import (
 	"context"
+	"math"
 	"math/big"
 	"testing"
 	"time"
@@ -19,12 +20,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db/batch"
@@ -204,6 +207,85 @@ func TestProtocol_GrantEpochReward(t *testing.T) {
 	}, true)
 }
 
+func TestProtocol_GrantEpochRewardToVoters(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		r := require.New(t)
+		_, err := p.Deposit(ctx, sm, big.NewInt(200), iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND)
+		r.NoError(err)
+
+		g := genesis.MustExtractGenesisContext(ctx)
+		g.ToBeEnabledBlockHeight = 0
+		g.Staking.BootstrapCandidates = []genesis.BootstrapCandidate{
+			{
+				OwnerAddress:      identityset.Address(33).String(),
+				OperatorAddress:   identityset.Address(27).String(),
+				RewardAddress:     identityset.Address(0).String(),
+				Name:              "test1",
+				SelfStakingTokens: unit.ConvertIotxToRau(1200000).String(),
+			},
+		}
+		ctx = genesis.WithGenesisContext(ctx, g)
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		sp, err := staking.NewProtocol(staking.HelperCtx{
+			DepositGas:    DepositGas,
+			BlockInterval: func(uint64) time.Duration { return 5 * time.Second },
+		}, &staking.BuilderConfig{
+			Staking:                  g.Staking,
+			PersistStakingPatchBlock: math.MaxUint64,
+		}, nil, nil, nil, g.GreenlandBlockHeight)
+		r.NoError(err)
+		r.NoError(sp.Register(protocol.MustGetRegistry(ctx)))
+		v, err := sp.Start(ctx, sm)
+		r.NoError(err)
+		r.NoError(sm.WriteView(sp.Name(), v))
+		r.NoError(sp.CreateGenesisStates(ctx, sm))
+		// the delegate of the 1st candidate distributes the reward to the voters with 10% commission
+		csm, err := staking.NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		cand := csm.GetByName("test1")
+		rate := uint64(1000)
+		cand.CommissionRate = &rate
+		r.NoError(csm.Upsert(cand))
+		r.NoError(csm.Commit(ctx))
+		// the voters are snapshotted at the start of the epoch
+		r.NoError(sp.CreatePreStates(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 1}), sm))
+		candidates, err := poll.MustGetProtocol(protocol.MustGetRegistry(ctx)).Candidates(ctx, sm)
+		r.NoError(err)
+		candidates[0].CanName = []byte("test1")
+
+		rewardLogs, err := p.GrantEpochReward(ctx, sm)
+		r.NoError(err)
+		var rl rewardingpb.RewardLog
+		r.NoError(proto.Unmarshal(rewardLogs[0].Data, &rl))
+		r.Equal(rewardingpb.RewardLog_EPOCH_REWARD, rl.Type)
+		r.Equal(identityset.Address(0).String(), rl.Addr)
+		r.Equal("4", rl.Amount)
+		r.NoError(proto.Unmarshal(rewardLogs[1].Data, &rl))
+		r.Equal(rewardingpb.RewardLog_VOTER_REWARD, rl.Type)
+		r.Equal(identityset.Address(33).String(), rl.Addr)
+		r.Equal("36", rl.Amount)
+		r.NoError(proto.Unmarshal(rewardLogs[2].Data, &rl))
+		r.Equal(rewardingpb.RewardLog_EPOCH_REWARD, rl.Type)
+		r.Equal(identityset.Address(28).String(), rl.Addr)
+
+		// the voter claims the reward
+		unclaimedBalance, _, err := p.UnclaimedBalance(ctx, sm, identityset.Address(33))
+		r.NoError(err)
+		r.Equal(big.NewInt(36), unclaimedBalance)
+		unclaimedBalance, _, err = p.UnclaimedBalance(ctx, sm, identityset.Address(0))
+		r.NoError(err)
+		r.Equal(big.NewInt(4+5), unclaimedBalance)
+		availableBalance, _, err := p.AvailableBalance(ctx, sm)
+		r.NoError(err)
+		r.Equal(big.NewInt(90+5), availableBalance)
+		_, err = p.Claim(ctx, sm, big.NewInt(36), identityset.Address(33))
+		r.NoError(err)
+		acc, err := accountutil.LoadAccount(sm, identityset.Address(33))
+		r.NoError(err)
+		r.Equal(big.NewInt(36), acc.Balance)
+	}, false)
+}
+
 func TestProtocol_ClaimReward(t *testing.T) {
 	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
 		// Deposit 20 token into the rewarding fund
@@ -481,3 +563,39 @@ func TestProtocol_NoRewardAddr(t *testing.T) {
 	assert.Equal(t, identityset.Address(1).String(), rl.Addr)
 	assert.Equal(t, "50", rl.Amount)
 }
+
+func TestSplitVoterReward(t *testing.T) {
+	r := require.New(t)
+	voters := []*staking.VoterWeight{
+		{Voter: identityset.Address(1), Weight: big.NewInt(1)},
+		{Voter: identityset.Address(2), Weight: big.NewInt(2)},
+		{Voter: identityset.Address(3), Weight: big.NewInt(0)},
+	}
+	toStrings := func(amounts []*big.Int) []string {
+		ret := make([]string, len(amounts))
+		for i := range amounts {
+			ret[i] = amounts[i].String()
+		}
+		return ret
+	}
+	for _, c := range []struct {
+		amount     int64
+		commission uint64
+		candidate  string
+		voters     []string
+	}{
+		// 10% commission, 900 shared by the voters
+		{1000, 1000, "100", []string{"300", "600", "0"}},
+		// the rounding dust goes to the candidate
+		{1001, 0, "1", []string{"333", "667", "0"}},
+		{1000, action.MaxCommissionRate, "1000", []string{"0", "0", "0"}},
+	} {
+		candidate, amounts := splitVoterReward(big.NewInt(c.amount), c.commission, voters)
+		r.Equal(c.candidate, candidate.String())
+		r.Equal(c.voters, toStrings(amounts))
+	}
+	// no votes
+	candidate, amounts := splitVoterReward(big.NewInt(1000), 1000, voters[2:])
+	r.Equal("1000", candidate.String())
+	r.Equal([]string{"0"}, toStrings(amounts))
+}
@@ -34,6 +34,7 @@ const (
 	RewardLog_BLOCK_REWARD     RewardLog_RewardType = 0
 	RewardLog_EPOCH_REWARD     RewardLog_RewardType = 1
 	RewardLog_FOUNDATION_BONUS RewardLog_RewardType = 2
+	RewardLog_VOTER_REWARD     RewardLog_RewardType = 3
 )
 
 // Enum value maps for RewardLog_RewardType.
@@ -42,11 +43,13 @@ var (
 		0: "BLOCK_REWARD",
 		1: "EPOCH_REWARD",
 		2: "FOUNDATION_BONUS",
+		3: "VOTER_REWARD",
 	}
 	RewardLog_RewardType_value = map[string]int32{
 		"BLOCK_REWARD":     0,
 		"EPOCH_REWARD":     1,
 		"FOUNDATION_BONUS": 2,
+		"VOTER_REWARD":     3,
 	}
 )
 
@@ -422,6 +425,93 @@ func (x *RewardLog) GetAmount() string {
 	return ""
 }
 
+type RewardEstimation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockReward     string `protobuf:"bytes,1,opt,name=blockReward,proto3" json:"blockReward,omitempty"`
+	EpochReward     string `protobuf:"bytes,2,opt,name=epochReward,proto3" json:"epochReward,omitempty"`
+	FoundationBonus string `protobuf:"bytes,3,opt,name=foundationBonus,proto3" json:"foundationBonus,omitempty"`
+	NumBlocks       uint64 `protobuf:"varint,4,opt,name=numBlocks,proto3" json:"numBlocks,omitempty"`
+	NumEpochs       uint64 `protobuf:"varint,5,opt,name=numEpochs,proto3" json:"numEpochs,omitempty"`
+	Apr             uint64 `protobuf:"varint,6,opt,name=apr,proto3" json:"apr,omitempty"`
+}
+
+func (x *RewardEstimation) Reset() {
+	*x = RewardEstimation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rewarding_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RewardEstimation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardEstimation) ProtoMessage() {}
+
+func (x *RewardEstimation) ProtoReflect() protoreflect.Message {
+	mi := &file_rewarding_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardEstimation.ProtoReflect.Descriptor instead.
+func (*RewardEstimation) Descriptor() ([]byte, []int) {
+	return file_rewarding_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RewardEstimation) GetBlockReward() string {
+	if x != nil {
+		return x.BlockReward
+	}
+	return ""
+}
+
+func (x *RewardEstimation) GetEpochReward() string {
+	if x != nil {
+		return x.EpochReward
+	}
+	return ""
+}
+
+func (x *RewardEstimation) GetFoundationBonus() string {
+	if x != nil {
+		return x.FoundationBonus
+	}
+	return ""
+}
+
+func (x *RewardEstimation) GetNumBlocks() uint64 {
+	if x != nil {
+		return x.NumBlocks
+	}
+	return 0
+}
+
+func (x *RewardEstimation) GetNumEpochs() uint64 {
+	if x != nil {
+		return x.NumEpochs
+	}
+	return 0
+}
+
+func (x *RewardEstimation) GetApr() uint64 {
+	if x != nil {
+		return x.Apr
+	}
+	return 0
+}
+
 var File_rewarding_proto protoreflect.FileDescriptor
 
 var file_rewarding_proto_rawDesc = []byte{
@@ -460,7 +550,7 @@ var file_rewarding_proto_rawDesc = []byte{
 	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x1e,
 	0x0a, 0x06, 0x45, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x22, 0xb6,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x22, 0xc8,
 	0x01, 0x0a, 0x09, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x4c, 0x6f, 0x67, 0x12, 0x35, 0x0a, 0x04,
 	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x72, 0x65, 0x77,
 	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x4c,
@@ -468,11 +558,26 @@ var file_rewarding_proto_rawDesc = []byte{
 	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
 	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22,
-	0x46, 0x0a, 0x0a, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a,
+	0x58, 0x0a, 0x0a, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a,
 	0x0c, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x57, 0x41, 0x52, 0x44, 0x10, 0x00, 0x12,
 	0x10, 0x0a, 0x0c, 0x45, 0x50, 0x4f, 0x43, 0x48, 0x5f, 0x52, 0x45, 0x57, 0x41, 0x52, 0x44, 0x10,
 	0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
-	0x42, 0x4f, 0x4e, 0x55, 0x53, 0x10, 0x02, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x42, 0x4f, 0x4e, 0x55, 0x53, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x56, 0x4f, 0x54, 0x45, 0x52,
+	0x5f, 0x52, 0x45, 0x57, 0x41, 0x52, 0x44, 0x10, 0x03, 0x22, 0xce, 0x01, 0x0a, 0x10, 0x52, 0x65,
+	0x77, 0x61, 0x72, 0x64, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20,
+	0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x77, 0x61,
+	0x72, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x6f, 0x6e, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x6e, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x75,
+	0x6d, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e,
+	0x75, 0x6d, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x61, 0x70, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -488,7 +593,7 @@ func file_rewarding_proto_rawDescGZIP() []byte {
 }
 
 var file_rewarding_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rewarding_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_rewarding_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_rewarding_proto_goTypes = []interface{}{
 	(RewardLog_RewardType)(0), // 0: rewardingpb.RewardLog.RewardType
 	(*Admin)(nil),             // 1: rewardingpb.Admin
@@ -497,6 +602,7 @@ var file_rewarding_proto_goTypes = []interface{}{
 	(*Account)(nil),           // 4: rewardingpb.Account
 	(*Exempt)(nil),            // 5: rewardingpb.Exempt
 	(*RewardLog)(nil),         // 6: rewardingpb.RewardLog
+	(*RewardEstimation)(nil),  // 7: rewardingpb.RewardEstimation
 }
 var file_rewarding_proto_depIdxs = []int32{
 	0, // 0: rewardingpb.RewardLog.type:type_name -> rewardingpb.RewardLog.RewardType
@@ -585,6 +691,18 @@ func file_rewarding_proto_init() {
 				return nil
 			}
 		}
+		file_rewarding_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RewardEstimation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -592,7 +710,7 @@ func file_rewarding_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rewarding_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
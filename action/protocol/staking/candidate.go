@@ -31,6 +31,11 @@ type (
 		Votes              *big.Int
 		SelfStakeBucketIdx uint64
 		SelfStake          *big.Int
+		// CommissionRate is the share of the epoch reward kept by the candidate in basis points, the rest is
+		// distributed to the voters, nil if the candidate has not opted in the distribution
+		CommissionRate *uint64
+		// DeactivatedHeight is the height at which the candidate exits, 0 if the candidate is active
+		DeactivatedHeight uint64
 	}
 
 	// CandidateList is a list of candidates which is sortable
@@ -45,7 +50,7 @@ type (
 
 // Clone returns a copy
 func (d *Candidate) Clone() *Candidate {
-	return &Candidate{
+	c := &Candidate{
 		Owner:              d.Owner,
 		Operator:           d.Operator,
 		Reward:             d.Reward,
@@ -54,19 +59,27 @@ func (d *Candidate) Clone() *Candidate {
 		Votes:              new(big.Int).Set(d.Votes),
 		SelfStakeBucketIdx: d.SelfStakeBucketIdx,
 		SelfStake:          new(big.Int).Set(d.SelfStake),
+		DeactivatedHeight:  d.DeactivatedHeight,
 	}
+	if d.CommissionRate != nil {
+		rate := *d.CommissionRate
+		c.CommissionRate = &rate
+	}
+	return c
 }
 
 // Equal tests equality of 2 candidates
 func (d *Candidate) Equal(c *Candidate) bool {
 	return d.Name == c.Name &&
 		d.SelfStakeBucketIdx == c.SelfStakeBucketIdx &&
+		d.DeactivatedHeight == c.DeactivatedHeight &&
 		address.Equal(d.Owner, c.Owner) &&
 		address.Equal(d.Operator, c.Operator) &&
 		address.Equal(d.Reward, c.Reward) &&
 		address.Equal(d.Identifier, c.Identifier) &&
 		d.Votes.Cmp(c.Votes) == 0 &&
-		d.SelfStake.Cmp(c.SelfStake) == 0
+		d.SelfStake.Cmp(c.SelfStake) == 0 &&
+		equalCommissionRate(d.CommissionRate, c.CommissionRate)
 }
 
 // Validate does the sanity check
@@ -97,6 +110,16 @@ func (d *Candidate) Validate() error {
 	return nil
 }
 
+// DistributesReward returns true if the candidate distributes the epoch reward to the voters
+func (d *Candidate) DistributesReward() bool {
+	return d.CommissionRate != nil
+}
+
+// IsDeactivated returns true if the candidate has exited
+func (d *Candidate) IsDeactivated() bool {
+	return d.DeactivatedHeight > 0
+}
+
 // isSelfStakeBucketSettled checks if self stake bucket is settled
 func (d *Candidate) isSelfStakeBucketSettled() bool {
 	return d.SelfStakeBucketIdx != candidateNoSelfStakeBucketIndex
@@ -212,6 +235,8 @@ func (d *Candidate) toProto() (*stakingpb.Candidate, error) {
 		Votes:              d.Votes.String(),
 		SelfStakeBucketIdx: d.SelfStakeBucketIdx,
 		SelfStake:          d.SelfStake.String(),
+		CommissionRate:     d.CommissionRate,
+		DeactivatedHeight:  d.DeactivatedHeight,
 	}, nil
 }
 
@@ -255,6 +280,12 @@ func (d *Candidate) fromProto(pb *stakingpb.Candidate) error {
 	if !ok {
 		return action.ErrInvalidAmount
 	}
+	d.CommissionRate = nil
+	if pb.CommissionRate != nil {
+		rate := pb.GetCommissionRate()
+		d.CommissionRate = &rate
+	}
+	d.DeactivatedHeight = pb.GetDeactivatedHeight()
 	return nil
 }
 
@@ -279,6 +310,13 @@ func (d *Candidate) toStateCandidate() *state.Candidate {
 	}
 }
 
+func equalCommissionRate(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func (l CandidateList) Len() int      { return len(l) }
 func (l CandidateList) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
 func (l CandidateList) Less(i, j int) bool {
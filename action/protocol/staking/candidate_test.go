@@ -52,6 +52,7 @@ func TestSer(t *testing.T) {
 			Votes:              big.NewInt(3000),
 			SelfStakeBucketIdx: 2,
 			SelfStake:          big.NewInt(3100000000),
+			DeactivatedHeight:  100,
 		},
 	}
 
@@ -89,6 +90,11 @@ func TestClone(t *testing.T) {
 	r.True(d.Equal(d2))
 	d.AddVote(big.NewInt(100))
 	r.False(d.Equal(d2))
+	d2 = d.Clone()
+	d2.DeactivatedHeight = 10
+	r.True(d2.IsDeactivated())
+	r.False(d.Equal(d2))
+	r.True(d2.Equal(d2.Clone()))
 	r.NoError(d.Collision(d2))
 	d.Owner = identityset.Address(0)
 	r.Equal(action.ErrInvalidCanName, d.Collision(d2))
@@ -0,0 +1,148 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// HandleCandidateDeactivate is the topic of the receipt log of CandidateDeactivate
+	HandleCandidateDeactivate = "candidateDeactivate"
+	// HandleCandidateDeactivateBucket is the topic of the receipt logs of CandidateDeactivate notifying the owners of
+	// the buckets voting for the deactivated candidate
+	HandleCandidateDeactivateBucket = "candidateDeactivateBucket"
+
+	// _releasedNamePrefix is the prefix of the name given to a deactivated candidate when its name is released,
+	// it is not a valid candidate name so that the released name cannot be registered or voted
+	_releasedNamePrefix = "#"
+)
+
+func (p *Protocol) handleCandidateDeactivate(ctx context.Context, act *action.CandidateDeactivate, csm CandidateStateManager,
+) (*receiptLog, []*action.Log, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleCandidateDeactivate, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, nil, fetchErr
+	}
+
+	// only owner can deactivate candidate
+	cand := csm.GetByOwner(actCtx.Caller)
+	if cand == nil {
+		return log, nil, errCandNotExist
+	}
+	if cand.IsDeactivated() {
+		return log, nil, errCandDeactivated
+	}
+	log.AddTopics(byteutil.Uint64ToBytesBigEndian(cand.SelfStakeBucketIdx), cand.GetIdentifier().Bytes())
+
+	// convert self-stake bucket to vote bucket, so that it can be unstaked as a normal bucket
+	if cand.SelfStake.Sign() > 0 {
+		bucket, err := p.fetchBucket(csm, cand.SelfStakeBucketIdx)
+		if err != nil {
+			return log, nil, err
+		}
+		if err := cand.SubVote(p.calculateVoteWeight(bucket, true)); err != nil {
+			return log, nil, err
+		}
+		if err := cand.AddVote(p.calculateVoteWeight(bucket, false)); err != nil {
+			return log, nil, err
+		}
+	}
+	cand.SelfStakeBucketIdx = candidateNoSelfStakeBucketIndex
+	cand.SelfStake = big.NewInt(0)
+	// the candidate is no longer active, and is removed from the candidate list at the next epoch. The buckets
+	// voting for it are kept, their owners can change the candidate or unstake them
+	cand.DeactivatedHeight = blkCtx.BlockHeight
+
+	if err := csm.Upsert(cand); err != nil {
+		return log, nil, csmErrorToHandleError(cand.GetIdentifier().String(), err)
+	}
+	bucketLogs, err := p.deactivatedCandidateBucketLogs(ctx, csm, cand)
+	if err != nil {
+		return log, nil, err
+	}
+
+	log.AddAddress(actCtx.Caller)
+	log.SetData(byteutil.Uint64ToBytesBigEndian(cand.DeactivatedHeight))
+	return log, bucketLogs, nil
+}
+
+// deactivatedCandidateBucketLogs returns a receipt log for each native and contract staking bucket voting for the
+// deactivated candidate, with the bucket index, the candidate and the bucket owner as the topics, and the contract
+// address as the data of a contract staking bucket, so that the voters can be notified to move their votes
+func (p *Protocol) deactivatedCandidateBucketLogs(ctx context.Context, csm CandidateStateManager, cand *Candidate) ([]*action.Log, error) {
+	var (
+		buckets []*VoteBucket
+		csr     = newCandidateStateReader(csm.SM())
+	)
+	indices, _, err := csr.candBucketIndices(cand.GetIdentifier())
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get bucket indices of candidate %s", cand.Name)
+	default:
+		if buckets, err = csr.getBucketsWithIndices(*indices); err != nil {
+			return nil, errors.Wrapf(err, "failed to get buckets of candidate %s", cand.Name)
+		}
+	}
+	// the contract staking indexers are not updated before the block is committed
+	contractBuckets, err := p.contractStakingBuckets(ctx, cand.GetIdentifier(), protocol.MustGetBlockCtx(ctx).BlockHeight-1)
+	if err != nil {
+		return nil, err
+	}
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	logs := make([]*action.Log, 0, len(buckets)+len(contractBuckets))
+	for _, b := range append(buckets, contractBuckets...) {
+		if b.isUnstaked() {
+			continue
+		}
+		log := newReceiptLog(p.addr.String(), HandleCandidateDeactivateBucket, featureCtx.NewStakingReceiptFormat)
+		log.AddTopics(byteutil.Uint64ToBytesBigEndian(b.Index), cand.GetIdentifier().Bytes(), b.Owner.Bytes())
+		if !b.isNative() {
+			addr, err := address.FromString(b.ContractAddress)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid contract address %s of bucket %d", b.ContractAddress, b.Index)
+			}
+			log.SetData(addr.Bytes())
+		}
+		logs = append(logs, log.Build(ctx, nil))
+	}
+	return logs, nil
+}
+
+// releaseCandidateName renames the deactivated candidate holding the name once the name release waiting period
+// is over, so that the name can be taken by another candidate
+func (p *Protocol) releaseCandidateName(ctx context.Context, csm CandidateStateManager, name string) error {
+	if !protocol.MustGetFeatureCtx(ctx).CandidateDeactivation {
+		return nil
+	}
+	cand := csm.GetByName(name)
+	if cand == nil || !cand.IsDeactivated() ||
+		protocol.MustGetBlockCtx(ctx).BlockHeight < cand.DeactivatedHeight+p.config.NameReleaseWaitingBlocks {
+		return nil
+	}
+	// the identifier is unique, so is the released name
+	cand.Name = _releasedNamePrefix + hex.EncodeToString(cand.GetIdentifier().Bytes())
+	if err := csm.Upsert(cand); err != nil {
+		return csmErrorToHandleError(cand.GetIdentifier().String(), err)
+	}
+	return nil
+}
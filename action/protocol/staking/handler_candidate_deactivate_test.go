@@ -0,0 +1,170 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestProtocol_HandleCandidateDeactivate(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, candidate2 := initAll(t, ctrl)
+	owner := candidate.Owner
+	gasPrice := big.NewInt(unit.Qev)
+	amount := unit.ConvertIotxToRau(200)
+	ctx, _ := initCreateStake(t, sm, owner, 10000, gasPrice, 10000, 1, 1, time.Now(), 10000, p, candidate, amount.String(), false)
+
+	registrant := identityset.Address(3)
+	r.NoError(setupAccount(sm, registrant, 1000))
+	nonces := map[string]uint64{owner.String(): 1}
+	handle := func(ctx context.Context, caller address.Address, act action.Action) *action.Receipt {
+		nonces[caller.String()]++
+		nonce := nonces[caller.String()]
+		intrinsic, err := act.(interface{ IntrinsicGas() (uint64, error) }).IntrinsicGas()
+		r.NoError(err)
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:       caller,
+			GasPrice:     gasPrice,
+			IntrinsicGas: intrinsic,
+			Nonce:        nonce,
+		})
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		return receipt
+	}
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = g.UpernavikBlockHeight + 1
+	withHeight := func(height uint64) context.Context {
+		ctx := protocol.WithBlockCtx(genesis.WithGenesisContext(ctx, g), protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       10000000,
+		})
+		return protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	}
+	// bucket 1 is the self-stake bucket of the candidate
+	act, err := action.NewCreateStake(0, candidate.Name, amount.String(), 1, false, nil, 10000, gasPrice)
+	r.NoError(err)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, handle(ctx, owner, act).Status)
+	// bucket 2 is voted by another voter
+	voter := identityset.Address(5)
+	r.NoError(setupAccount(sm, voter, 1000))
+	act, err = action.NewCreateStake(0, candidate.Name, amount.String(), 1, false, nil, 10000, gasPrice)
+	r.NoError(err)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, handle(ctx, voter, act).Status)
+	csm, err := NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	selfStakeBucket, err := csm.getBucket(1)
+	r.NoError(err)
+	votes := csm.GetByOwner(owner).Votes
+
+	deactivateHeight := g.ToBeEnabledBlockHeight
+	vctx := withHeight(deactivateHeight)
+
+	t.Run("validate", func(t *testing.T) {
+		deactivate := action.NewCandidateDeactivate(0, 10000, gasPrice)
+		r.ErrorIs(p.Validate(ctx, deactivate, sm), action.ErrInvalidAct)
+		r.NoError(p.Validate(vctx, deactivate, sm))
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		// caller is not a candidate owner
+		receipt := handle(vctx, registrant, action.NewCandidateDeactivate(0, 10000, gasPrice))
+		r.EqualValues(iotextypes.ReceiptStatus_ErrCandidateNotExist, receipt.Status)
+
+		receipt = handle(vctx, owner, action.NewCandidateDeactivate(0, 10000, gasPrice))
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		logs := receipt.Logs()
+		r.Len(logs, 4)
+		r.Len(logs[0].Topics, 3)
+		r.Equal(uint64(1), byteutil.BytesToUint64BigEndian(logs[0].Topics[1][24:]))
+		// the owners of the buckets voting for the candidate are notified
+		for i, owner := range []address.Address{owner, owner, voter} {
+			l := logs[i+1]
+			r.Len(l.Topics, 4)
+			r.Equal(hash.BytesToHash256([]byte(HandleCandidateDeactivateBucket)), l.Topics[0])
+			r.Equal(uint64(i), byteutil.BytesToUint64BigEndian(l.Topics[1][24:]))
+			r.Equal(candidate.GetIdentifier().Bytes(), l.Topics[2][12:])
+			r.Equal(owner.Bytes(), l.Topics[3][12:])
+			r.Empty(l.Data)
+		}
+
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		cand := csm.GetByOwner(owner)
+		r.True(cand.IsDeactivated())
+		r.Equal(deactivateHeight, cand.DeactivatedHeight)
+		r.Equal(uint64(candidateNoSelfStakeBucketIndex), cand.SelfStakeBucketIdx)
+		r.Zero(cand.SelfStake.Sign())
+		expected := new(big.Int).Sub(votes, p.calculateVoteWeight(selfStakeBucket, true))
+		r.Equal(expected.Add(expected, p.calculateVoteWeight(selfStakeBucket, false)), cand.Votes)
+		active, err := p.isActiveCandidate(vctx, csm, cand)
+		r.NoError(err)
+		r.False(active)
+
+		// cannot deactivate again
+		receipt = handle(vctx, owner, action.NewCandidateDeactivate(0, 10000, gasPrice))
+		r.EqualValues(iotextypes.ReceiptStatus_ErrCandidateNotExist, receipt.Status)
+	})
+
+	t.Run("deactivated candidate", func(t *testing.T) {
+		create, err := action.NewCreateStake(0, candidate.Name, amount.String(), 1, false, nil, 10000, gasPrice)
+		r.NoError(err)
+		update, err := action.NewCandidateUpdate(0, "newname", candidate.Operator.String(), candidate.Reward.String(), 10000, gasPrice)
+		r.NoError(err)
+		for _, act := range []action.Action{
+			create,
+			update,
+			action.NewCandidateActivate(0, 10000, gasPrice, 1),
+		} {
+			r.EqualValues(iotextypes.ReceiptStatus_ErrCandidateNotExist, handle(vctx, owner, act).Status)
+		}
+		// the former self-stake bucket can be moved to another candidate, but not back
+		change, err := action.NewChangeCandidate(0, candidate2.Name, 1, nil, 10000, gasPrice)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, handle(vctx, owner, change).Status)
+		change, err = action.NewChangeCandidate(0, candidate.Name, 1, nil, 10000, gasPrice)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_ErrCandidateNotExist, handle(vctx, owner, change).Status)
+	})
+
+	t.Run("name release", func(t *testing.T) {
+		register := func(ctx context.Context) *action.Receipt {
+			act, err := action.NewCandidateRegister(0, candidate.Name, identityset.Address(20).String(), registrant.String(), "", "0", 1, false, nil, 10000, gasPrice)
+			r.NoError(err)
+			return handle(ctx, registrant, act)
+		}
+		releaseHeight := deactivateHeight + p.config.NameReleaseWaitingBlocks
+		r.EqualValues(iotextypes.ReceiptStatus_ErrCandidateConflict, register(withHeight(releaseHeight-1)).Status)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, register(withHeight(releaseHeight)).Status)
+
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		r.True(address.Equal(registrant, csm.GetByName(candidate.Name).Owner))
+		cand := csm.GetByOwner(owner)
+		r.True(strings.HasPrefix(cand.Name, _releasedNamePrefix))
+		r.False(action.IsValidCandidateName(cand.Name))
+	})
+}
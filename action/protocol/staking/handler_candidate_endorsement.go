@@ -12,13 +12,14 @@ import (
 )
 
 const (
-	handleCandidateEndorsement = "candidateEndorsement"
+	// HandleCandidateEndorsement is the topic of the receipt log of CandidateEndorsement
+	HandleCandidateEndorsement = "candidateEndorsement"
 )
 
 func (p *Protocol) handleCandidateEndorsement(ctx context.Context, act *action.CandidateEndorsement, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
 	actCtx := protocol.MustGetActionCtx(ctx)
 	featureCtx := protocol.MustGetFeatureCtx(ctx)
-	log := newReceiptLog(p.addr.String(), handleCandidateEndorsement, featureCtx.NewStakingReceiptFormat)
+	log := newReceiptLog(p.addr.String(), HandleCandidateEndorsement, featureCtx.NewStakingReceiptFormat)
 
 	bucket, rErr := p.fetchBucket(csm, act.BucketIndex())
 	if rErr != nil {
@@ -34,6 +35,9 @@ func (p *Protocol) handleCandidateEndorsement(ctx context.Context, act *action.C
 	expireHeight := uint64(0)
 	if act.IsEndorse() {
 		// handle endorsement
+		if cand.IsDeactivated() {
+			return log, nil, errCandDeactivated
+		}
 		if err := p.validateEndorsement(ctx, csm, esm, actCtx.Caller, bucket, cand); err != nil {
 			return log, nil, err
 		}
@@ -33,6 +33,9 @@ func (p *Protocol) handleCandidateActivate(ctx context.Context, act *action.Cand
 	if cand == nil {
 		return log, nil, errCandNotExist
 	}
+	if cand.IsDeactivated() {
+		return log, nil, errCandDeactivated
+	}
 
 	if err := p.validateBucketSelfStake(ctx, csm, NewEndorsementStateManager(csm.SM()), bucket, cand); err != nil {
 		return log, nil, err
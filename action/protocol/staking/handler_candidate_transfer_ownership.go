@@ -95,6 +95,9 @@ func (p *Protocol) validateCandidateTransferOwnership(_ context.Context, act *ac
 			failureStatus: iotextypes.ReceiptStatus_ErrCandidateNotExist,
 		}
 	}
+	if candidate.IsDeactivated() {
+		return errCandDeactivated
+	}
 	//check if the new owner is self
 	if address.Equal(act.NewOwner(), caller) {
 		return &handleError{
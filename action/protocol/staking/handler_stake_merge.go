@@ -0,0 +1,118 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// HandleMergeStake is the topic of the receipt log of MergeStake
+	HandleMergeStake = "mergeStake"
+)
+
+func (p *Protocol) handleMergeStake(ctx context.Context, act *action.MergeStake, csm CandidateStateManager) (*receiptLog, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleMergeStake, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	bucket, fetchErr := p.fetchBucketAndValidate(featureCtx, csm, actionCtx.Caller, act.BucketIndex(), true, false)
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	log.AddTopics(byteutil.Uint64ToBytesBigEndian(bucket.Index), bucket.Candidate.Bytes())
+	if err := p.validateStakeMerge(ctx, csm, bucket, bucket); err != nil {
+		return log, err
+	}
+	sources := make([]*VoteBucket, 0, len(act.SourceIndices()))
+	for _, index := range act.SourceIndices() {
+		source, fetchErr := p.fetchBucketAndValidate(featureCtx, csm, actionCtx.Caller, index, true, false)
+		if fetchErr != nil {
+			return log, fetchErr
+		}
+		if err := p.validateStakeMerge(ctx, csm, bucket, source); err != nil {
+			return log, err
+		}
+		sources = append(sources, source)
+	}
+	candidate := csm.GetByIdentifier(bucket.Candidate)
+	if candidate == nil {
+		return log, errCandNotExist
+	}
+
+	for _, b := range append([]*VoteBucket{bucket}, sources...) {
+		if err := candidate.SubVote(p.calculateVoteWeight(b, false)); err != nil {
+			return log, &handleError{
+				err:           errors.Wrapf(err, "failed to subtract vote for candidate %s", bucket.Candidate.String()),
+				failureStatus: iotextypes.ReceiptStatus_ErrNotEnoughBalance,
+			}
+		}
+	}
+	for _, source := range sources {
+		// the merged bucket is locked until the latest stake start time of the buckets
+		bucket.StakedAmount.Add(bucket.StakedAmount, source.StakedAmount)
+		if source.StakeStartTime.After(bucket.StakeStartTime) {
+			bucket.StakeStartTime = source.StakeStartTime
+		}
+		if err := csm.delBucketAndIndex(source.Owner, source.Candidate, source.Index); err != nil {
+			return log, errors.Wrapf(err, "failed to delete bucket for candidate %s", source.Candidate.String())
+		}
+		// the staked amount stays the same, only the bucket count decreases
+		if err := csm.CreditBucketPool(big.NewInt(0)); err != nil {
+			return log, &handleError{
+				err:           errors.Wrapf(err, "failed to update staking bucket pool %s", err.Error()),
+				failureStatus: iotextypes.ReceiptStatus_ErrWriteAccount,
+			}
+		}
+		log.AddTopics(byteutil.Uint64ToBytesBigEndian(source.Index))
+	}
+	if err := csm.updateBucket(bucket.Index, bucket); err != nil {
+		return log, errors.Wrapf(err, "failed to update bucket for voter %s", bucket.Owner.String())
+	}
+	if err := candidate.AddVote(p.calculateVoteWeight(bucket, false)); err != nil {
+		return log, &handleError{
+			err:           errors.Wrapf(err, "failed to add vote for candidate %s", candidate.GetIdentifier().String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketAmount,
+		}
+	}
+	if err := csm.Upsert(candidate); err != nil {
+		return log, csmErrorToHandleError(candidate.GetIdentifier().String(), err)
+	}
+	return log, nil
+}
+
+// validateStakeMerge checks the bucket can be merged into the target bucket
+func (p *Protocol) validateStakeMerge(ctx context.Context, csm CandidateStateManager, target, bucket *VoteBucket) ReceiptError {
+	if err := validateBucketStake(bucket, true); err != nil {
+		return err
+	}
+	if err := validateBucketWithoutEndorsement(NewEndorsementStateManager(csm.SM()), bucket, protocol.MustGetBlockCtx(ctx).BlockHeight); err != nil {
+		return err
+	}
+	if err := validateBucketCandidate(bucket, target.Candidate); err != nil {
+		return err
+	}
+	if bucket.StakedDuration != target.StakedDuration || bucket.AutoStake != target.AutoStake {
+		return &handleError{
+			err:           errors.Errorf("bucket %d has different duration or auto-stake from bucket %d", bucket.Index, target.Index),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
+		}
+	}
+	return nil
+}
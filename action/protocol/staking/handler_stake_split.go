@@ -0,0 +1,114 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// HandleSplitStake is the topic of the receipt log of SplitStake
+	HandleSplitStake = "splitStake"
+)
+
+func (p *Protocol) handleSplitStake(ctx context.Context, act *action.SplitStake, csm CandidateStateManager) (*receiptLog, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleSplitStake, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	bucket, fetchErr := p.fetchBucketAndValidate(featureCtx, csm, actionCtx.Caller, act.BucketIndex(), true, false)
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	log.AddTopics(byteutil.Uint64ToBytesBigEndian(bucket.Index), bucket.Candidate.Bytes())
+	if err := p.validateStakeSplit(ctx, csm, bucket, act.Amount()); err != nil {
+		return log, err
+	}
+	candidate := csm.GetByIdentifier(bucket.Candidate)
+	if candidate == nil {
+		return log, errCandNotExist
+	}
+	if err := candidate.SubVote(p.calculateVoteWeight(bucket, false)); err != nil {
+		return log, &handleError{
+			err:           errors.Wrapf(err, "failed to subtract vote for candidate %s", bucket.Candidate.String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrNotEnoughBalance,
+		}
+	}
+
+	// the new bucket inherits everything of the original bucket but the amount
+	split := &VoteBucket{
+		Candidate:      bucket.Candidate,
+		Owner:          bucket.Owner,
+		StakedAmount:   new(big.Int).Set(act.Amount()),
+		StakedDuration: bucket.StakedDuration,
+		CreateTime:     bucket.CreateTime,
+		StakeStartTime: bucket.StakeStartTime,
+		AutoStake:      bucket.AutoStake,
+	}
+	splitIdx, err := csm.putBucketAndIndex(split)
+	if err != nil {
+		return log, errors.Wrap(err, "failed to put bucket and index")
+	}
+	split.Index = splitIdx
+	bucket.StakedAmount.Sub(bucket.StakedAmount, act.Amount())
+	if err := csm.updateBucket(bucket.Index, bucket); err != nil {
+		return log, errors.Wrapf(err, "failed to update bucket for voter %s", bucket.Owner.String())
+	}
+	log.AddTopics(byteutil.Uint64ToBytesBigEndian(splitIdx))
+
+	for _, b := range []*VoteBucket{bucket, split} {
+		if err := candidate.AddVote(p.calculateVoteWeight(b, false)); err != nil {
+			return log, &handleError{
+				err:           errors.Wrapf(err, "failed to add vote for candidate %s", candidate.GetIdentifier().String()),
+				failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketAmount,
+			}
+		}
+	}
+	if err := csm.Upsert(candidate); err != nil {
+		return log, csmErrorToHandleError(candidate.GetIdentifier().String(), err)
+	}
+
+	// the staked amount stays the same, only the bucket count increases
+	if err := csm.DebitBucketPool(big.NewInt(0), true); err != nil {
+		return log, &handleError{
+			err:           errors.Wrapf(err, "failed to update staking bucket pool %s", err.Error()),
+			failureStatus: iotextypes.ReceiptStatus_ErrWriteAccount,
+		}
+	}
+	return log, nil
+}
+
+func (p *Protocol) validateStakeSplit(ctx context.Context, csm CandidateStateManager, bucket *VoteBucket, amount *big.Int) ReceiptError {
+	if err := validateBucketStake(bucket, true); err != nil {
+		return err
+	}
+	if err := validateBucketWithoutEndorsement(NewEndorsementStateManager(csm.SM()), bucket, protocol.MustGetBlockCtx(ctx).BlockHeight); err != nil {
+		return err
+	}
+	// both buckets after splitting should meet the min stake amount
+	remaining := new(big.Int).Sub(bucket.StakedAmount, amount)
+	if amount.Sign() <= 0 || remaining.Sign() <= 0 ||
+		amount.Cmp(p.config.MinStakeAmount) < 0 || remaining.Cmp(p.config.MinStakeAmount) < 0 {
+		return &handleError{
+			err:           errors.Errorf("cannot split %s out of bucket %d with amount %s", amount, bucket.Index, bucket.StakedAmount),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketAmount,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestProtocol_HandleSplitMergeStake(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	caller := identityset.Address(1)
+	gasPrice := big.NewInt(unit.Qev)
+	amount := unit.ConvertIotxToRau(200)
+	ctx, _ := initCreateStake(t, sm, caller, 10000, gasPrice, 10000, 1, 1, time.Now(), 10000, p, candidate, amount.String(), true)
+
+	nonce := uint64(1)
+	handle := func(ctx context.Context, act action.Action) *action.Receipt {
+		nonce++
+		intrinsic, err := act.(interface{ IntrinsicGas() (uint64, error) }).IntrinsicGas()
+		r.NoError(err)
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:       caller,
+			GasPrice:     gasPrice,
+			IntrinsicGas: intrinsic,
+			Nonce:        nonce,
+		})
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		return receipt
+	}
+	// bucket 1 and 2 are the self-stake buckets of the candidates
+	for _, autoStake := range []bool{true, true, true, true, false} {
+		act, err := action.NewCreateStake(nonce+1, candidate.Name, amount.String(), 1, autoStake, nil, 10000, gasPrice)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, handle(ctx, act).Status)
+	}
+	checkVotes := func(indices ...uint64) {
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		votes := big.NewInt(0)
+		for _, idx := range indices {
+			bucket, err := csm.getBucket(idx)
+			r.NoError(err)
+			votes.Add(votes, p.calculateVoteWeight(bucket, false))
+		}
+		r.Equal(votes, csm.GetByOwner(candidate.Owner).Votes)
+	}
+	checkVotes(0, 1, 2, 3, 4, 5)
+
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = g.UpernavikBlockHeight + 1
+	vctx := protocol.WithBlockCtx(genesis.WithGenesisContext(ctx, g), protocol.BlockCtx{
+		BlockHeight:    g.ToBeEnabledBlockHeight,
+		BlockTimeStamp: time.Now(),
+		GasLimit:       10000000,
+	})
+	vctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(vctx))
+
+	t.Run("validate", func(t *testing.T) {
+		split, err := action.NewSplitStake(0, 3, amount.String(), nil, 10000, gasPrice)
+		r.NoError(err)
+		merge, err := action.NewMergeStake(0, 3, []uint64{4}, nil, 10000, gasPrice)
+		r.NoError(err)
+		r.ErrorIs(p.Validate(ctx, split, sm), action.ErrInvalidAct)
+		r.ErrorIs(p.Validate(ctx, merge, sm), action.ErrInvalidAct)
+		r.NoError(p.Validate(vctx, split, sm))
+		r.NoError(p.Validate(vctx, merge, sm))
+		split, err = action.NewSplitStake(0, 3, "1", nil, 10000, gasPrice)
+		r.NoError(err)
+		r.ErrorIs(p.Validate(vctx, split, sm), action.ErrInvalidAmount)
+	})
+
+	t.Run("split", func(t *testing.T) {
+		for _, v := range []struct {
+			index  uint64
+			amount *big.Int
+			status iotextypes.ReceiptStatus
+		}{
+			// self-stake bucket
+			{1, unit.ConvertIotxToRau(100), iotextypes.ReceiptStatus_ErrInvalidBucketType},
+			{10, unit.ConvertIotxToRau(100), iotextypes.ReceiptStatus_ErrInvalidBucketIndex},
+			// the remaining amount is less than the min stake amount
+			{0, unit.ConvertIotxToRau(150), iotextypes.ReceiptStatus_ErrInvalidBucketAmount},
+			{0, amount, iotextypes.ReceiptStatus_ErrInvalidBucketAmount},
+		} {
+			act, err := action.NewSplitStake(nonce+1, v.index, v.amount.String(), nil, 10000, gasPrice)
+			r.NoError(err)
+			r.EqualValues(v.status, handle(vctx, act).Status)
+		}
+
+		act, err := action.NewSplitStake(nonce+1, 0, unit.ConvertIotxToRau(100).String(), nil, 10000, gasPrice)
+		r.NoError(err)
+		receipt := handle(vctx, act)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		logs := receipt.Logs()
+		r.Len(logs, 1)
+		r.Len(logs[0].Topics, 4)
+		r.Equal(uint64(0), byteutil.BytesToUint64BigEndian(logs[0].Topics[1][24:]))
+		r.Equal(uint64(6), byteutil.BytesToUint64BigEndian(logs[0].Topics[3][24:]))
+
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		origin, err := csm.getBucket(0)
+		r.NoError(err)
+		split, err := csm.getBucket(6)
+		r.NoError(err)
+		r.Equal(unit.ConvertIotxToRau(100), origin.StakedAmount)
+		r.Equal(unit.ConvertIotxToRau(100), split.StakedAmount)
+		r.Equal(origin.Candidate, split.Candidate)
+		r.Equal(origin.Owner, split.Owner)
+		r.Equal(origin.StakedDuration, split.StakedDuration)
+		r.Equal(origin.StakeStartTime, split.StakeStartTime)
+		r.Equal(origin.AutoStake, split.AutoStake)
+		indices, _, err := newCandidateStateReader(sm).voterBucketIndices(caller)
+		r.NoError(err)
+		r.Equal(BucketIndices{0, 1, 2, 3, 4, 5, 6}, *indices)
+		checkVotes(0, 1, 2, 3, 4, 5, 6)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		for _, v := range []struct {
+			index   uint64
+			sources []uint64
+			status  iotextypes.ReceiptStatus
+		}{
+			// self-stake bucket
+			{1, []uint64{0}, iotextypes.ReceiptStatus_ErrInvalidBucketType},
+			{0, []uint64{10}, iotextypes.ReceiptStatus_ErrInvalidBucketIndex},
+			// different auto-stake
+			{0, []uint64{3, 5}, iotextypes.ReceiptStatus_ErrInvalidBucketType},
+		} {
+			act, err := action.NewMergeStake(nonce+1, v.index, v.sources, nil, 10000, gasPrice)
+			r.NoError(err)
+			r.EqualValues(v.status, handle(vctx, act).Status)
+		}
+
+		act, err := action.NewMergeStake(nonce+1, 0, []uint64{3, 6}, nil, 10000, gasPrice)
+		r.NoError(err)
+		receipt := handle(vctx, act)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		logs := receipt.Logs()
+		r.Len(logs, 1)
+		r.Len(logs[0].Topics, 5)
+		r.Equal(uint64(3), byteutil.BytesToUint64BigEndian(logs[0].Topics[3][24:]))
+		r.Equal(uint64(6), byteutil.BytesToUint64BigEndian(logs[0].Topics[4][24:]))
+
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		merged, err := csm.getBucket(0)
+		r.NoError(err)
+		r.Equal(unit.ConvertIotxToRau(400), merged.StakedAmount)
+		for _, idx := range []uint64{3, 6} {
+			_, err = csm.getBucket(idx)
+			r.Equal(state.ErrStateNotExist, errors.Cause(err))
+		}
+		indices, _, err := newCandidateStateReader(sm).voterBucketIndices(caller)
+		r.NoError(err)
+		r.Equal(BucketIndices{0, 1, 2, 4, 5}, *indices)
+		checkVotes(0, 1, 2, 4, 5)
+	})
+}
+
+func TestProtocol_MergeStakeStartTime(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	caller := identityset.Address(1)
+	ctx, _ := initCreateStake(t, sm, caller, 10000, big.NewInt(unit.Qev), 10000, 1, 1, time.Now(), 10000, p, candidate, unit.ConvertIotxToRau(200).String(), false)
+
+	csm, err := NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	now := time.Now().UTC()
+	later := now.Add(time.Hour)
+	cand := csm.GetByOwner(candidate.Owner)
+	for _, ts := range []time.Time{now, now, later, now} {
+		bucket := NewVoteBucket(candidate.GetIdentifier(), caller, unit.ConvertIotxToRau(100), 1, ts, false)
+		_, err = csm.putBucketAndIndex(bucket)
+		r.NoError(err)
+		r.NoError(cand.AddVote(p.calculateVoteWeight(bucket, false)))
+		r.NoError(csm.DebitBucketPool(bucket.StakedAmount, true))
+	}
+	r.NoError(csm.Upsert(cand))
+	r.NoError(csm.Commit(ctx))
+
+	act, err := action.NewMergeStake(2, 4, []uint64{3}, nil, 10000, big.NewInt(unit.Qev))
+	r.NoError(err)
+	ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+		Caller:       caller,
+		GasPrice:     big.NewInt(unit.Qev),
+		IntrinsicGas: 20000,
+		Nonce:        2,
+	})
+	receipt, err := p.Handle(ctx, act, sm)
+	r.NoError(err)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	csm, err = NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	merged, err := csm.getBucket(4)
+	r.NoError(err)
+	// the merged bucket is locked until the latest stake start time
+	r.Equal(later, merged.StakeStartTime)
+	r.Equal(unit.ConvertIotxToRau(200), merged.StakedAmount)
+	r.Equal(uint64(4), csm.(*candSM).bucketPool.Count())
+	r.Equal(unit.ConvertIotxToRau(600), csm.(*candSM).bucketPool.Total())
+}
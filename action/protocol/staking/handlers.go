@@ -44,6 +44,10 @@ var (
 		err:           ErrInvalidOwner,
 		failureStatus: iotextypes.ReceiptStatus_ErrCandidateNotExist,
 	}
+	errCandDeactivated = &handleError{
+		err:           errors.New("candidate is deactivated"),
+		failureStatus: iotextypes.ReceiptStatus_ErrCandidateNotExist,
+	}
 )
 
 type handleError struct {
@@ -76,6 +80,9 @@ func (p *Protocol) handleCreateStake(ctx context.Context, act *action.CreateStak
 	if candidate == nil {
 		return log, nil, errCandNotExist
 	}
+	if candidate.IsDeactivated() {
+		return log, nil, errCandDeactivated
+	}
 	bucket := NewVoteBucket(candidate.GetIdentifier(), actionCtx.Caller, act.Amount(), act.Duration(), blkCtx.BlockTimeStamp, act.AutoStake())
 	bucketIdx, err := csm.putBucketAndIndex(bucket)
 	if err != nil {
@@ -304,6 +311,9 @@ func (p *Protocol) handleChangeCandidate(ctx context.Context, act *action.Change
 	if candidate == nil {
 		return log, errCandNotExist
 	}
+	if candidate.IsDeactivated() {
+		return log, errCandDeactivated
+	}
 
 	bucket, fetchErr := p.fetchBucketAndValidate(featureCtx, csm, actionCtx.Caller, act.BucketIndex(), true, false)
 	if fetchErr != nil {
@@ -707,7 +717,10 @@ func (p *Protocol) handleCandidateRegister(ctx context.Context, act *action.Cand
 			failureStatus: iotextypes.ReceiptStatus_ErrCandidateAlreadyExist,
 		}
 	}
-	// cannot collide with existing name
+	// cannot collide with existing name, unless it is released by a deactivated candidate
+	if err := p.releaseCandidateName(ctx, csm, act.Name()); err != nil {
+		return log, nil, err
+	}
 	if csm.ContainsName(act.Name()) && (!ownerExist || act.Name() != c.Name) {
 		return log, nil, &handleError{
 			err:           action.ErrInvalidCanName,
@@ -827,8 +840,14 @@ func (p *Protocol) handleCandidateUpdate(ctx context.Context, act *action.Candid
 	if c == nil {
 		return log, errCandNotExist
 	}
+	if c.IsDeactivated() {
+		return log, errCandDeactivated
+	}
 
-	if len(act.Name()) != 0 {
+	if len(act.Name()) != 0 && act.Name() != c.Name {
+		if err := p.releaseCandidateName(ctx, csm, act.Name()); err != nil {
+			return log, err
+		}
 		c.Name = act.Name()
 	}
 
@@ -839,6 +858,10 @@ func (p *Protocol) handleCandidateUpdate(ctx context.Context, act *action.Candid
 	if act.RewardAddress() != nil {
 		c.Reward = act.RewardAddress()
 	}
+
+	if rate, ok := act.CommissionRate(); ok {
+		c.CommissionRate = &rate
+	}
 	log.AddTopics(c.GetIdentifier().Bytes())
 
 	if err := csm.Upsert(c); err != nil {
@@ -998,7 +1021,8 @@ func BucketIndexFromReceiptLog(log *iotextypes.Log) (uint64, bool) {
 	case hash.BytesToHash256([]byte(HandleCreateStake)), hash.BytesToHash256([]byte(HandleUnstake)),
 		hash.BytesToHash256([]byte(HandleWithdrawStake)), hash.BytesToHash256([]byte(HandleChangeCandidate)),
 		hash.BytesToHash256([]byte(HandleTransferStake)), hash.BytesToHash256([]byte(HandleDepositToStake)),
-		hash.BytesToHash256([]byte(HandleRestake)), hash.BytesToHash256([]byte(HandleCandidateRegister)):
+		hash.BytesToHash256([]byte(HandleRestake)), hash.BytesToHash256([]byte(HandleCandidateRegister)),
+		hash.BytesToHash256([]byte(HandleSplitStake)), hash.BytesToHash256([]byte(HandleMergeStake)):
 		return byteutil.BytesToUint64BigEndian(log.Topics[1][24:]), true
 	default:
 		return 0, false
@@ -969,6 +969,68 @@ func TestProtocol_HandleCandidateUpdate(t *testing.T) {
 	}
 }
 
+func TestProtocol_HandleCandidateUpdateCommission(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, _, _ := initAll(t, ctrl)
+	caller := identityset.Address(27)
+	require.NoError(setupAccount(sm, caller, 1201000))
+	act, err := action.NewCandidateRegister(1, "test", identityset.Address(28).String(), identityset.Address(29).String(), caller.String(), "1200000000000000000000000", uint32(10000), true, nil, uint64(1000000), big.NewInt(1000))
+	require.NoError(err)
+	handle := func(g genesis.Genesis, nonce uint64, act interface {
+		action.Action
+		IntrinsicGas() (uint64, error)
+	}) error {
+		intrinsic, _ := act.IntrinsicGas()
+		ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+			Caller:       caller,
+			GasPrice:     big.NewInt(1000),
+			IntrinsicGas: intrinsic,
+			Nonce:        nonce,
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    1,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       uint64(1000000),
+		})
+		ctx = genesis.WithGenesisContext(ctx, g)
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		if err := p.Validate(ctx, act, sm); err != nil {
+			return err
+		}
+		r, err := p.Handle(ctx, act, sm)
+		require.NoError(err)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		return nil
+	}
+	require.NoError(handle(genesis.Default, 1, act))
+	commission := func() *uint64 {
+		csm, err := NewCandidateStateManager(sm, false)
+		require.NoError(err)
+		return csm.GetByOwner(caller).CommissionRate
+	}
+	require.Nil(commission())
+
+	cu, err := action.NewCandidateUpdateWithCommission(2, "", "", "", 1500, uint64(1000000), big.NewInt(1000))
+	require.NoError(err)
+	// the commission rate is not enabled yet
+	require.Equal(action.ErrInvalidAct, errors.Cause(handle(genesis.Default, 2, cu)))
+
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = 0
+	require.NoError(handle(g, 2, cu))
+	require.EqualValues(1500, *commission())
+	// the commission rate is kept if not updated
+	cu, err = action.NewCandidateUpdate(3, "update", "", "", uint64(1000000), big.NewInt(1000))
+	require.NoError(err)
+	require.NoError(handle(g, 3, cu))
+	require.EqualValues(1500, *commission())
+
+	cu, err = action.NewCandidateUpdateWithCommission(4, "", "", "", action.MaxCommissionRate+1, uint64(1000000), big.NewInt(1000))
+	require.NoError(err)
+	require.Equal(action.ErrInvalidCommissionRate, errors.Cause(handle(g, 4, cu)))
+}
+
 func TestProtocol_HandleUnstake(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -95,6 +95,7 @@ type (
 		BootstrapCandidates              []genesis.BootstrapCandidate
 		PersistStakingPatchBlock         uint64
 		EndorsementWithdrawWaitingBlocks uint64
+		NameReleaseWaitingBlocks         uint64
 		MigrateContractAddress           string
 	}
 	// HelperCtx is the helper context for staking protocol
@@ -105,6 +106,13 @@ type (
 
 	// DepositGas deposits gas to some pool
 	DepositGas func(ctx context.Context, sm protocol.StateManager, amount *big.Int) (*action.TransactionLog, error)
+
+	// CandidateVotesBreakdown is the name and self-stake of a candidate, and the votes it receives from contract staking
+	CandidateVotesBreakdown struct {
+		Name          string
+		SelfStake     *big.Int
+		ContractVotes *big.Int
+	}
 )
 
 // FindProtocol return a registered protocol from registry
@@ -173,6 +181,7 @@ func NewProtocol(
 			BootstrapCandidates:              cfg.Staking.BootstrapCandidates,
 			PersistStakingPatchBlock:         cfg.PersistStakingPatchBlock,
 			EndorsementWithdrawWaitingBlocks: cfg.Staking.EndorsementWithdrawWaitingBlocks,
+			NameReleaseWaitingBlocks:         cfg.Staking.NameReleaseWaitingBlocks,
 			MigrateContractAddress:           migrateContractAddress,
 		},
 		candBucketsIndexer:       candBucketsIndexer,
@@ -305,6 +314,11 @@ func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager
 			return err
 		}
 	}
+	if featureCtx.DistributeRewardToVoters {
+		if err := p.snapshotVoters(ctx, sm); err != nil {
+			return err
+		}
+	}
 	if p.candBucketsIndexer == nil {
 		return nil
 	}
@@ -415,6 +429,7 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateStateManager) (*action.Receipt, error) {
 	var (
 		rLog              *receiptLog
+		bucketLogs        []*action.Log
 		tLogs             []*action.TransactionLog
 		err               error
 		logs              []*action.Log
@@ -454,6 +469,12 @@ func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateS
 		if err == nil {
 			nonceUpdateOption = noUpdateNonce
 		}
+	case *action.SplitStake:
+		rLog, err = p.handleSplitStake(ctx, act, csm)
+	case *action.MergeStake:
+		rLog, err = p.handleMergeStake(ctx, act, csm)
+	case *action.CandidateDeactivate:
+		rLog, bucketLogs, err = p.handleCandidateDeactivate(ctx, act, csm)
 	default:
 		return nil, nil
 	}
@@ -462,6 +483,7 @@ func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateS
 			logs = append(logs, l)
 		}
 	}
+	logs = append(logs, bucketLogs...)
 	if err == nil {
 		return p.settleAction(ctx, csm.SM(), uint64(iotextypes.ReceiptStatus_Success), logs, tLogs, gasConsumed, gasToBeDeducted, nonceUpdateOption)
 	}
@@ -507,11 +529,20 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action, sr protocol.
 		return p.validateCandidateTransferOwnershipAction(ctx, act)
 	case *action.MigrateStake:
 		return p.validateMigrateStake(ctx, act)
+	case *action.SplitStake:
+		return p.validateSplitStake(ctx, act)
+	case *action.MergeStake:
+		return p.validateMergeStake(ctx, act)
+	case *action.CandidateDeactivate:
+		return p.validateCandidateDeactivate(ctx, act)
 	}
 	return nil
 }
 
 func (p *Protocol) isActiveCandidate(ctx context.Context, csr CandidiateStateCommon, cand *Candidate) (bool, error) {
+	if cand.IsDeactivated() {
+		return false, nil
+	}
 	if cand.SelfStake.Cmp(p.config.RegistrationConsts.MinSelfStake) < 0 {
 		return false, nil
 	}
@@ -567,6 +598,78 @@ func (p *Protocol) ActiveCandidates(ctx context.Context, sr protocol.StateReader
 	return cand.toStateCandidateList()
 }
 
+// CandidateVotes returns the name, self-stake and the votes from contract staking of all candidates, keyed by operator address
+func (p *Protocol) CandidateVotes(ctx context.Context, sr protocol.StateReader) (map[string]*CandidateVotesBreakdown, error) {
+	srHeight, err := sr.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get StateReader height")
+	}
+	c, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get CandidateVotes")
+	}
+	list := c.AllCandidates()
+	votes := make(map[string]*CandidateVotesBreakdown, len(list))
+	for _, cand := range list {
+		// same as ActiveCandidates, the contract indexer is not updated before the block is committed
+		csVotes, err := p.contractStakingVotes(ctx, cand.GetIdentifier(), srHeight-1)
+		if err != nil {
+			return nil, err
+		}
+		votes[cand.Operator.String()] = &CandidateVotesBreakdown{
+			Name:          cand.Name,
+			SelfStake:     new(big.Int).Set(cand.SelfStake),
+			ContractVotes: csVotes,
+		}
+	}
+	return votes, nil
+}
+
+// VoterWeight returns the total vote weight of the native and contract staking buckets owned by the voter, the
+// unstaked buckets are not counted
+func (p *Protocol) VoterWeight(ctx context.Context, sr protocol.StateReader, voter address.Address) (*big.Int, error) {
+	srHeight, err := sr.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get StateReader height")
+	}
+	c, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get VoterWeight")
+	}
+	weight := big.NewInt(0)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	indices, _, err := c.voterBucketIndices(voter)
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+	case err != nil:
+		return nil, err
+	default:
+		buckets, err := c.getBucketsWithIndices(*indices)
+		if err != nil {
+			return nil, err
+		}
+		for _, bucket := range buckets {
+			if bucket == nil || bucket.isUnstaked() {
+				continue
+			}
+			selfStake, err := isSelfStakeBucket(featureCtx, c, bucket)
+			if err != nil {
+				return nil, err
+			}
+			weight.Add(weight, p.calculateVoteWeight(bucket, selfStake))
+		}
+	}
+	// same as ActiveCandidates, the contract indexer is not updated before the block is committed
+	buckets, err := p.contractStakingBucketsByOwner(ctx, voter, srHeight-1)
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range buckets {
+		weight.Add(weight, p.contractStakingVoteWeight(featureCtx, bucket))
+	}
+	return weight, nil
+}
+
 // ReadState read the state on blockchain via protocol
 func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
 	m := iotexapi.ReadStakingDataMethod{}
@@ -746,14 +849,20 @@ func (p *Protocol) needToWriteCandsMap(ctx context.Context, height uint64) bool
 func (p *Protocol) contractStakingVotes(ctx context.Context, candidate address.Address, height uint64) (*big.Int, error) {
 	featureCtx := protocol.MustGetFeatureCtx(ctx)
 	votes := big.NewInt(0)
-	indexers := []ContractStakingIndexer{}
-	if p.contractStakingIndexer != nil && featureCtx.AddContractStakingVotes {
-		indexers = append(indexers, p.contractStakingIndexer)
+	btks, err := p.contractStakingBuckets(ctx, candidate, height)
+	if err != nil {
+		return nil, err
 	}
-	if p.contractStakingIndexerV2 != nil && !featureCtx.LimitedStakingContract {
-		indexers = append(indexers, p.contractStakingIndexerV2)
+	for _, b := range btks {
+		votes.Add(votes, p.contractStakingVoteWeight(featureCtx, b))
 	}
-	for _, indexer := range indexers {
+	return votes, nil
+}
+
+// contractStakingBuckets returns the staked contract staking buckets voting for the candidate
+func (p *Protocol) contractStakingBuckets(ctx context.Context, candidate address.Address, height uint64) ([]*VoteBucket, error) {
+	var buckets []*VoteBucket
+	for _, indexer := range p.contractStakingIndexers(ctx) {
 		btks, err := indexer.BucketsByCandidate(candidate, height)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get BucketsByCandidate from contractStakingIndexer")
@@ -762,14 +871,49 @@ func (p *Protocol) contractStakingVotes(ctx context.Context, candidate address.A
 			if b.isUnstaked() {
 				continue
 			}
-			if featureCtx.FixContractStakingWeightedVotes {
-				votes.Add(votes, p.calculateVoteWeight(b, false))
-			} else {
-				votes.Add(votes, b.StakedAmount)
+			buckets = append(buckets, b)
+		}
+	}
+	return buckets, nil
+}
+
+// contractStakingBucketsByOwner returns the contract staking buckets owned by the owner, the unstaked buckets are
+// not included
+func (p *Protocol) contractStakingBucketsByOwner(ctx context.Context, owner address.Address, height uint64) ([]*VoteBucket, error) {
+	var buckets []*VoteBucket
+	for _, indexer := range p.contractStakingIndexers(ctx) {
+		btks, err := indexer.Buckets(height)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get Buckets from contractStakingIndexer")
+		}
+		for _, b := range btks {
+			if b.isUnstaked() || !address.Equal(b.Owner, owner) {
+				continue
 			}
+			buckets = append(buckets, b)
 		}
 	}
-	return votes, nil
+	return buckets, nil
+}
+
+// contractStakingIndexers returns the contract staking indexers whose buckets are counted as votes
+func (p *Protocol) contractStakingIndexers(ctx context.Context) []ContractStakingIndexer {
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	indexers := []ContractStakingIndexer{}
+	if p.contractStakingIndexer != nil && featureCtx.AddContractStakingVotes {
+		indexers = append(indexers, p.contractStakingIndexer)
+	}
+	if p.contractStakingIndexerV2 != nil && !featureCtx.LimitedStakingContract {
+		indexers = append(indexers, p.contractStakingIndexerV2)
+	}
+	return indexers
+}
+
+func (p *Protocol) contractStakingVoteWeight(featureCtx protocol.FeatureCtx, b *VoteBucket) *big.Int {
+	if featureCtx.FixContractStakingWeightedVotes {
+		return p.calculateVoteWeight(b, false)
+	}
+	return b.StakedAmount
 }
 
 func readCandCenterStateFromStateDB(sr protocol.StateReader) (CandidateList, CandidateList, CandidateList, error) {
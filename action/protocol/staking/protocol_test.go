@@ -500,6 +500,17 @@ func TestProtocol_ActiveCandidates(t *testing.T) {
 		require.NoError(err)
 		require.Len(cands, 1)
 		require.EqualValues(103, cands[0].Votes.Sub(cands[0].Votes, originCandVotes).Uint64())
+
+		votes, err := p.CandidateVotes(ctx, sm)
+		require.NoError(err)
+		require.Len(votes, 1)
+		breakdown := votes[identityset.Address(23).String()]
+		require.Equal("test1", breakdown.Name)
+		require.Equal(selfStake, breakdown.SelfStake)
+		require.EqualValues(103, breakdown.ContractVotes.Uint64())
+		csIndexerHeight = 0
+		_, err = p.CandidateVotes(ctx, sm)
+		require.ErrorContains(err, "invalid height")
 	})
 }
 
@@ -634,3 +645,39 @@ func TestIsSelfStakeBucket(t *testing.T) {
 		r.False(selfStake)
 	})
 }
+
+func TestProtocol_VoterWeight(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	voter := identityset.Address(3)
+	ctx, _ := initCreateStake(t, sm, voter, 10000, big.NewInt(unit.Qev), 10000, 1, 1, time.Now(), 10000, p, candidate, unit.ConvertIotxToRau(200).String(), false)
+
+	csr, err := ConstructBaseView(sm)
+	r.NoError(err)
+	bucket, err := csr.getBucket(0)
+	r.NoError(err)
+	weight, err := p.VoterWeight(ctx, sm, voter)
+	r.NoError(err)
+	r.Equal(p.calculateVoteWeight(bucket, false), weight)
+
+	// voter without bucket
+	weight, err = p.VoterWeight(ctx, sm, identityset.Address(4))
+	r.NoError(err)
+	r.Zero(weight.Sign())
+
+	// the contract staking buckets of the voter are counted
+	csIndexer := NewMockContractStakingIndexerWithBucketType(ctrl)
+	p.contractStakingIndexer = csIndexer
+	contractBucket := NewVoteBucket(candidate.Owner, voter, big.NewInt(100), 1, time.Now(), true)
+	unstaked := NewVoteBucket(candidate.Owner, voter, big.NewInt(100), 1, time.Now(), true)
+	unstaked.UnstakeStartTime = unstaked.StakeStartTime.Add(time.Hour)
+	others := NewVoteBucket(candidate.Owner, identityset.Address(4), big.NewInt(100), 1, time.Now(), true)
+	csIndexer.EXPECT().Buckets(gomock.Any()).Return([]*VoteBucket{contractBucket, unstaked, others}, nil).AnyTimes()
+	g := genesis.Default
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(genesis.WithGenesisContext(ctx, g), protocol.BlockCtx{BlockHeight: g.QuebecBlockHeight}))
+	weight, err = p.VoterWeight(ctx, sm, voter)
+	r.NoError(err)
+	expected := p.calculateVoteWeight(bucket, false)
+	r.Equal(expected.Add(expected, p.contractStakingVoteWeight(protocol.MustGetFeatureCtx(ctx), contractBucket)), weight)
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking/stakingpb"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var _voterSnapshotKey = []byte("voterSnapshot")
+
+type (
+	// VoterWeight is the total vote weight of the buckets of a voter voting for a candidate
+	VoterWeight struct {
+		Voter  address.Address
+		Weight *big.Int
+	}
+
+	// voterSnapshot is the commission rates and the vote weights of the voters of the candidates distributing
+	// the reward, which is taken at the start of an epoch
+	voterSnapshot struct {
+		height          uint64
+		voters          map[string][]*VoterWeight
+		commissionRates map[string]uint64
+	}
+)
+
+// RewardDistribution returns the commission rate of the candidate and the vote weights of its voters at the start
+// of the current epoch, who own the native and contract staking buckets voting for the candidate, sorted by the
+// voter address. The buckets staked or moved to the candidate later in the epoch do not share the reward, and a
+// commission rate updated later in the epoch takes effect from the next epoch.
+// It returns false if the candidate does not exist or has not opted in distributing the reward to the voters.
+func (p *Protocol) RewardDistribution(ctx context.Context, sr protocol.StateReader, name string) (uint64, []*VoterWeight, bool, error) {
+	_, cand, err := distributingCandidate(sr, name)
+	if err != nil || cand == nil {
+		return 0, nil, false, err
+	}
+	snapshot := voterSnapshot{}
+	_, err = sr.State(&snapshot, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_voterSnapshotKey))
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return *cand.CommissionRate, nil, true, nil
+	default:
+		return 0, nil, false, errors.Wrap(err, "failed to get the voter snapshot")
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	if snapshot.height != rp.GetEpochHeight(rp.GetEpochNum(blkCtx.BlockHeight)) {
+		// the candidate opted in after the start of the epoch
+		return *cand.CommissionRate, nil, true, nil
+	}
+	id := cand.GetIdentifier().String()
+	rate, ok := snapshot.commissionRates[id]
+	if !ok {
+		// the candidate opted in after the start of the epoch
+		return *cand.CommissionRate, nil, true, nil
+	}
+	return rate, snapshot.voters[id], true, nil
+}
+
+// CurrentRewardDistribution is the same as RewardDistribution, except that it returns the current vote weights of
+// the voters, which the projection of the future reward is based on
+func (p *Protocol) CurrentRewardDistribution(ctx context.Context, sr protocol.StateReader, name string) (uint64, []*VoterWeight, bool, error) {
+	csr, cand, err := distributingCandidate(sr, name)
+	if err != nil || cand == nil {
+		return 0, nil, false, err
+	}
+	// the contract staking indexers are not updated before the block is committed
+	height, err := sr.Height()
+	if err != nil {
+		return 0, nil, false, err
+	}
+	voters, err := p.voterWeights(ctx, csr, cand, height-1)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	return *cand.CommissionRate, voters, true, nil
+}
+
+// distributingCandidate returns the candidate of the name, or nil if it does not exist or does not distribute the
+// reward to its voters
+func distributingCandidate(sr protocol.StateReader, name string) (CandidateStateReader, *Candidate, error) {
+	csr, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, nil, err
+	}
+	cand := csr.GetCandidateByName(name)
+	if cand == nil || !cand.DistributesReward() {
+		return nil, nil, nil
+	}
+	return csr, cand, nil
+}
+
+// snapshotVoters takes the snapshot of the voters of the candidates distributing the reward at the start of an epoch
+func (p *Protocol) snapshotVoters(ctx context.Context, sm protocol.StateManager) error {
+	reg, ok := protocol.GetRegistry(ctx)
+	if !ok {
+		return nil
+	}
+	rp := rolldpos.FindProtocol(reg)
+	if rp == nil {
+		return nil
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	if rp.GetEpochHeight(rp.GetEpochNum(blkCtx.BlockHeight)) != blkCtx.BlockHeight {
+		return nil
+	}
+	csr, err := ConstructBaseView(sm)
+	if err != nil {
+		return err
+	}
+	snapshot := voterSnapshot{
+		height:          blkCtx.BlockHeight,
+		voters:          make(map[string][]*VoterWeight),
+		commissionRates: make(map[string]uint64),
+	}
+	for _, cand := range csr.AllCandidates() {
+		if !cand.DistributesReward() {
+			continue
+		}
+		// the contract staking indexers are not updated before the block is committed
+		voters, err := p.voterWeights(ctx, csr, cand, blkCtx.BlockHeight-1)
+		if err != nil {
+			return err
+		}
+		snapshot.voters[cand.GetIdentifier().String()] = voters
+		snapshot.commissionRates[cand.GetIdentifier().String()] = *cand.CommissionRate
+	}
+	_, err = sm.PutState(&snapshot, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_voterSnapshotKey))
+	return err
+}
+
+// voterWeights returns the vote weights of the voters of the candidate, sorted by the voter address
+func (p *Protocol) voterWeights(ctx context.Context, csr CandidateStateReader, cand *Candidate, height uint64) ([]*VoterWeight, error) {
+	weights := make(map[string]*VoterWeight)
+	addWeight := func(voter address.Address, weight *big.Int) {
+		if weight.Sign() == 0 {
+			return
+		}
+		vw, ok := weights[voter.String()]
+		if !ok {
+			vw = &VoterWeight{Voter: voter, Weight: big.NewInt(0)}
+			weights[voter.String()] = vw
+		}
+		vw.Weight.Add(vw.Weight, weight)
+	}
+
+	indices, _, err := csr.candBucketIndices(cand.GetIdentifier())
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get bucket indices of candidate %s", cand.Name)
+	default:
+		buckets, err := csr.getBucketsWithIndices(*indices)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get buckets of candidate %s", cand.Name)
+		}
+		for _, b := range buckets {
+			if b.isUnstaked() {
+				continue
+			}
+			addWeight(b.Owner, p.calculateVoteWeight(b, csr.ContainsSelfStakingBucket(b.Index)))
+		}
+	}
+
+	buckets, err := p.contractStakingBuckets(ctx, cand.GetIdentifier(), height)
+	if err != nil {
+		return nil, err
+	}
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	for _, b := range buckets {
+		addWeight(b.Owner, p.contractStakingVoteWeight(featureCtx, b))
+	}
+
+	voters := make([]*VoterWeight, 0, len(weights))
+	for _, vw := range weights {
+		voters = append(voters, vw)
+	}
+	sort.Slice(voters, func(i, j int) bool { return voters[i].Voter.String() < voters[j].Voter.String() })
+	return voters, nil
+}
+
+// Serialize serializes the voter snapshot into bytes
+func (vs *voterSnapshot) Serialize() ([]byte, error) {
+	pb := &stakingpb.VoterSnapshot{
+		Height: vs.height,
+	}
+	for cand, voters := range vs.voters {
+		cv := &stakingpb.CandidateVoters{
+			Candidate:      cand,
+			CommissionRate: vs.commissionRates[cand],
+		}
+		for _, v := range voters {
+			cv.Voters = append(cv.Voters, &stakingpb.VoterWeight{
+				Voter:  v.Voter.String(),
+				Weight: v.Weight.String(),
+			})
+		}
+		pb.Candidates = append(pb.Candidates, cv)
+	}
+	sort.Slice(pb.Candidates, func(i, j int) bool { return pb.Candidates[i].Candidate < pb.Candidates[j].Candidate })
+	return proto.Marshal(pb)
+}
+
+// Deserialize deserializes bytes into the voter snapshot
+func (vs *voterSnapshot) Deserialize(buf []byte) error {
+	pb := &stakingpb.VoterSnapshot{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal voter snapshot")
+	}
+	vs.height = pb.GetHeight()
+	vs.voters = make(map[string][]*VoterWeight, len(pb.GetCandidates()))
+	vs.commissionRates = make(map[string]uint64, len(pb.GetCandidates()))
+	for _, cv := range pb.GetCandidates() {
+		voters := make([]*VoterWeight, 0, len(cv.GetVoters()))
+		for _, v := range cv.GetVoters() {
+			voter, err := address.FromString(v.GetVoter())
+			if err != nil {
+				return err
+			}
+			weight, ok := new(big.Int).SetString(v.GetWeight(), 10)
+			if !ok {
+				return errors.Errorf("invalid vote weight %s", v.GetWeight())
+			}
+			voters = append(voters, &VoterWeight{Voter: voter, Weight: weight})
+		}
+		vs.voters[cv.GetCandidate()] = voters
+		vs.commissionRates[cv.GetCandidate()] = cv.GetCommissionRate()
+	}
+	return nil
+}
+
+// BucketVoteWeight returns the name of the candidate which the native staking bucket votes for, the staked amount
+// and the vote weight of the bucket, the weight is zero if the bucket is unstaked
+func (p *Protocol) BucketVoteWeight(sr protocol.StateReader, index uint64) (string, *big.Int, *big.Int, error) {
+	csr, err := ConstructBaseView(sr)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	b, err := csr.getBucket(index)
+	if err != nil {
+		return "", nil, nil, errors.Wrapf(err, "failed to get bucket %d", index)
+	}
+	cand := csr.GetByIdentifier(b.Candidate)
+	if cand == nil {
+		return "", nil, nil, errors.Wrapf(ErrInvalidOwner, "candidate of bucket %d does not exist", index)
+	}
+	weight := big.NewInt(0)
+	if !b.isUnstaked() {
+		weight = p.calculateVoteWeight(b, csr.ContainsSelfStakingBucket(b.Index))
+	}
+	return cand.Name, b.StakedAmount, weight, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestProtocol_RewardDistribution(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManagerWithoutHeightFunc(ctrl)
+	csIndexer := NewMockContractStakingIndexerWithBucketType(ctrl)
+
+	selfStake, _ := new(big.Int).SetString("1200000000000000000000000", 10)
+	cfg := genesis.Default.Staking
+	cfg.BootstrapCandidates = []genesis.BootstrapCandidate{
+		{
+			OwnerAddress:      identityset.Address(22).String(),
+			OperatorAddress:   identityset.Address(23).String(),
+			RewardAddress:     identityset.Address(23).String(),
+			Name:              "test1",
+			SelfStakingTokens: selfStake.String(),
+		},
+	}
+	p, err := NewProtocol(HelperCtx{
+		DepositGas:    nil,
+		BlockInterval: getBlockInterval,
+	}, &BuilderConfig{
+		Staking:                  cfg,
+		PersistStakingPatchBlock: math.MaxUint64,
+	}, nil, csIndexer, nil, genesis.Default.GreenlandBlockHeight)
+	require.NoError(err)
+
+	rp := rolldpos.NewProtocol(23, 4, 3)
+	reg := protocol.NewRegistry()
+	require.NoError(reg.Register("rolldpos", rp))
+	// the first block of an epoch, when the voters are snapshotted
+	blkHeight := rp.GetEpochHeight(rp.GetEpochNum(genesis.Default.QuebecBlockHeight) + 1)
+	ctx := protocol.WithBlockCtx(
+		genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), reg), genesis.Default),
+		protocol.BlockCtx{
+			BlockHeight: blkHeight,
+		},
+	)
+	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	sm.EXPECT().Height().Return(blkHeight, nil).AnyTimes()
+	v, err := p.Start(ctx, sm)
+	require.NoError(err)
+	require.NoError(sm.WriteView(_protocolID, v))
+	require.NoError(p.CreateGenesisStates(ctx, sm))
+
+	contractBucket := NewVoteBucket(identityset.Address(22), identityset.Address(25), big.NewInt(100), 1, time.Now(), true)
+	csIndexer.EXPECT().BucketsByCandidate(gomock.Any(), blkHeight-1).DoAndReturn(func(cand address.Address, _ uint64) ([]*VoteBucket, error) {
+		require.Equal(identityset.Address(22).String(), cand.String())
+		return []*VoteBucket{contractBucket}, nil
+	}).AnyTimes()
+
+	// the candidate has not opted in
+	_, _, ok, err := p.RewardDistribution(ctx, sm, "test1")
+	require.NoError(err)
+	require.False(ok)
+	_, _, ok, err = p.RewardDistribution(ctx, sm, "notexist")
+	require.NoError(err)
+	require.False(ok)
+
+	csm, err := NewCandidateStateManager(sm, false)
+	require.NoError(err)
+	cand := csm.GetByName("test1")
+	selfStakeBucket, err := csm.getBucket(cand.SelfStakeBucketIdx)
+	require.NoError(err)
+	rate := uint64(1000)
+	cand.CommissionRate = &rate
+	require.NoError(csm.Upsert(cand))
+	vote := NewVoteBucket(identityset.Address(22), identityset.Address(24), big.NewInt(200), 1, time.Now(), true)
+	_, err = csm.putBucketAndIndex(vote)
+	require.NoError(err)
+	unstaked := NewVoteBucket(identityset.Address(22), identityset.Address(26), big.NewInt(300), 1, time.Now(), true)
+	unstaked.UnstakeStartTime = unstaked.StakeStartTime.Add(time.Hour)
+	_, err = csm.putBucketAndIndex(unstaked)
+	require.NoError(err)
+	require.NoError(csm.Commit(ctx))
+
+	// the unstaked bucket does not share the reward
+	expected := map[string]*big.Int{
+		identityset.Address(22).String(): p.calculateVoteWeight(selfStakeBucket, true),
+		identityset.Address(24).String(): p.calculateVoteWeight(vote, false),
+		identityset.Address(25).String(): p.contractStakingVoteWeight(protocol.MustGetFeatureCtx(ctx), contractBucket),
+	}
+	checkVoters := func(voters []*VoterWeight, expected map[string]*big.Int) {
+		require.Len(voters, len(expected))
+		for i, v := range voters {
+			if i > 0 {
+				require.Less(voters[i-1].Voter.String(), v.Voter.String())
+			}
+			require.Equal(expected[v.Voter.String()], v.Weight, v.Voter.String())
+		}
+	}
+	commission, voters, ok, err := p.CurrentRewardDistribution(ctx, sm, "test1")
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(rate, commission)
+	checkVoters(voters, expected)
+
+	// the voters are not snapshotted at the start of the epoch
+	commission, voters, ok, err = p.RewardDistribution(ctx, sm, "test1")
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(rate, commission)
+	require.Empty(voters)
+
+	require.NoError(p.snapshotVoters(ctx, sm))
+	_, voters, ok, err = p.RewardDistribution(ctx, sm, "test1")
+	require.NoError(err)
+	require.True(ok)
+	checkVoters(voters, expected)
+
+	// the bucket staked and the commission rate updated after the snapshot do not apply to the epoch
+	csm, err = NewCandidateStateManager(sm, false)
+	require.NoError(err)
+	late := NewVoteBucket(identityset.Address(22), identityset.Address(27), big.NewInt(400), 1, time.Now(), true)
+	_, err = csm.putBucketAndIndex(late)
+	require.NoError(err)
+	cand = csm.GetByName("test1")
+	newRate := uint64(5000)
+	cand.CommissionRate = &newRate
+	require.NoError(csm.Upsert(cand))
+	require.NoError(csm.Commit(ctx))
+	lastCtx := protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: rp.GetEpochLastBlockHeight(rp.GetEpochNum(blkHeight))})
+	commission, voters, ok, err = p.RewardDistribution(lastCtx, sm, "test1")
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(rate, commission)
+	checkVoters(voters, expected)
+	commission, voters, ok, err = p.CurrentRewardDistribution(ctx, sm, "test1")
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(newRate, commission)
+	require.Len(voters, len(expected)+1)
+
+	// the snapshot of the last epoch is not used
+	nextCtx := protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: rp.GetEpochHeight(rp.GetEpochNum(blkHeight) + 1)})
+	commission, voters, ok, err = p.RewardDistribution(nextCtx, sm, "test1")
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(newRate, commission)
+	require.Empty(voters)
+
+	name, amount, weight, err := p.BucketVoteWeight(sm, vote.Index)
+	require.NoError(err)
+	require.Equal("test1", name)
+	require.Equal(vote.StakedAmount, amount)
+	require.Equal(p.calculateVoteWeight(vote, false), weight)
+	_, _, weight, err = p.BucketVoteWeight(sm, unstaked.Index)
+	require.NoError(err)
+	require.Zero(weight.Sign())
+	_, _, _, err = p.BucketVoteWeight(sm, 100)
+	require.Error(err)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// SlashSelfStake slashes the rate in percentage of the self-stake bucket of the candidate operated by the operator.
+// The slashed amount is burnt if the beneficiary is nil, otherwise it is sent to the beneficiary. It returns the index
+// of the self-stake bucket and the slashed amount, which is 0 if the candidate has no self-stake bucket, or if the
+// self-stake bucket is an endorsement bucket owned by someone else than the candidate owner
+func (p *Protocol) SlashSelfStake(
+	ctx context.Context,
+	sm protocol.StateManager,
+	operator address.Address,
+	rate uint32,
+	beneficiary address.Address,
+) (uint64, *big.Int, error) {
+	if rate > 100 {
+		return 0, nil, errors.Errorf("invalid slash rate %d", rate)
+	}
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
+	height, err := sm.Height()
+	if err != nil {
+		return 0, nil, err
+	}
+	csm, err := NewCandidateStateManager(sm, featureWithHeightCtx.ReadStateFromDB(height))
+	if err != nil {
+		return 0, nil, err
+	}
+	var candidate *Candidate
+	for _, c := range csm.DirtyView().candCenter.All() {
+		if address.Equal(c.Operator, operator) {
+			candidate = c
+			break
+		}
+	}
+	if candidate == nil || candidate.SelfStakeBucketIdx == candidateNoSelfStakeBucketIndex {
+		return 0, big.NewInt(0), nil
+	}
+	bucket, err := csm.getBucket(candidate.SelfStakeBucketIdx)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to get self-stake bucket of candidate %s", candidate.GetIdentifier().String())
+	}
+	if !address.Equal(bucket.Owner, candidate.Owner) {
+		// the endorser does not answer for the misbehavior of the candidate
+		return bucket.Index, big.NewInt(0), nil
+	}
+	amount := new(big.Int).Mul(bucket.StakedAmount, big.NewInt(int64(rate)))
+	amount.Div(amount, big.NewInt(100))
+	if amount.Sign() == 0 {
+		return bucket.Index, amount, nil
+	}
+	selfStake, err := isSelfStakeBucket(featureCtx, csm, bucket)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// update bucket
+	prevWeightedVotes := p.calculateVoteWeight(bucket, selfStake)
+	bucket.StakedAmount.Sub(bucket.StakedAmount, amount)
+	if err := csm.updateBucket(bucket.Index, bucket); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to update bucket %d", bucket.Index)
+	}
+
+	// update candidate
+	if err := candidate.SubVote(prevWeightedVotes); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to subtract vote for candidate %s", candidate.GetIdentifier().String())
+	}
+	if err := candidate.AddVote(p.calculateVoteWeight(bucket, selfStake)); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to add vote for candidate %s", candidate.GetIdentifier().String())
+	}
+	if selfStake {
+		if err := candidate.SubSelfStake(amount); err != nil {
+			return 0, nil, errors.Wrapf(err, "failed to subtract self stake for candidate %s", candidate.GetIdentifier().String())
+		}
+	}
+	if err := csm.Upsert(candidate); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to put state of candidate %s", candidate.GetIdentifier().String())
+	}
+
+	// update bucket pool
+	if err := csm.CreditBucketPool(amount); err != nil {
+		return 0, nil, errors.Wrap(err, "failed to update staking bucket pool")
+	}
+	if beneficiary == nil {
+		return bucket.Index, amount, nil
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if featureCtx.CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadOrCreateAccount(sm, beneficiary, accountCreationOpts...)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to load or create the account of beneficiary %s", beneficiary.String())
+	}
+	if err := acc.AddBalance(amount); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to add balance to beneficiary %s", beneficiary.String())
+	}
+	if err := accountutil.StoreAccount(sm, beneficiary, acc); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to store account %s", beneficiary.String())
+	}
+	return bucket.Index, amount, nil
+}
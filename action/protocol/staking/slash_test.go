@@ -0,0 +1,113 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestProtocol_SlashSelfStake(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	owner := candidate.Owner
+	gasPrice := big.NewInt(unit.Qev)
+	amount := unit.ConvertIotxToRau(200)
+	ctx, _ := initCreateStake(t, sm, owner, 10000, gasPrice, 10000, 1, 1, time.Now(), 10000, p, candidate, amount.String(), false)
+
+	// bucket 1 is the self-stake bucket of the candidate
+	act, err := action.NewCreateStake(0, candidate.Name, amount.String(), 1, false, nil, 10000, gasPrice)
+	r.NoError(err)
+	intrinsic, err := act.IntrinsicGas()
+	r.NoError(err)
+	receipt, err := p.Handle(protocol.WithActionCtx(ctx, protocol.ActionCtx{
+		Caller:       owner,
+		GasPrice:     gasPrice,
+		IntrinsicGas: intrinsic,
+		Nonce:        2,
+	}), act, sm)
+	r.NoError(err)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	csm, err := NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	bucket, err := csm.getBucket(1)
+	r.NoError(err)
+	cand := csm.GetByOwner(owner)
+	votes, selfStake := cand.Votes, cand.SelfStake
+	pool := new(big.Int).Set(csm.DirtyView().bucketPool.Total())
+
+	t.Run("invalid rate", func(t *testing.T) {
+		_, _, err := p.SlashSelfStake(ctx, sm, candidate.Operator, 101, nil)
+		r.Error(err)
+	})
+	t.Run("not a candidate", func(t *testing.T) {
+		_, slashed, err := p.SlashSelfStake(ctx, sm, identityset.Address(20), 10, nil)
+		r.NoError(err)
+		r.Zero(slashed.Sign())
+	})
+	t.Run("burn", func(t *testing.T) {
+		index, slashed, err := p.SlashSelfStake(ctx, sm, candidate.Operator, 10, nil)
+		r.NoError(err)
+		r.Equal(uint64(1), index)
+		r.Equal(unit.ConvertIotxToRau(20), slashed)
+
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		slashedBucket, err := csm.getBucket(1)
+		r.NoError(err)
+		r.Equal(unit.ConvertIotxToRau(180), slashedBucket.StakedAmount)
+		cand := csm.GetByOwner(owner)
+		r.Equal(new(big.Int).Sub(selfStake, slashed), cand.SelfStake)
+		expected := new(big.Int).Sub(votes, p.calculateVoteWeight(bucket, true))
+		r.Equal(expected.Add(expected, p.calculateVoteWeight(slashedBucket, true)), cand.Votes)
+		r.Equal(new(big.Int).Sub(pool, slashed), csm.DirtyView().bucketPool.Total())
+	})
+	t.Run("redirect", func(t *testing.T) {
+		beneficiary := identityset.Address(21)
+		_, slashed, err := p.SlashSelfStake(ctx, sm, candidate.Operator, 50, beneficiary)
+		r.NoError(err)
+		r.Equal(unit.ConvertIotxToRau(90), slashed)
+		acc, err := accountutil.LoadAccount(sm, beneficiary)
+		r.NoError(err)
+		r.Equal(slashed, acc.Balance)
+
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		r.Equal(new(big.Int).Sub(pool, unit.ConvertIotxToRau(110)), csm.DirtyView().bucketPool.Total())
+	})
+	t.Run("endorsement bucket", func(t *testing.T) {
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		endorsed, err := csm.getBucket(1)
+		r.NoError(err)
+		endorsed.Owner = identityset.Address(22)
+		r.NoError(csm.updateBucket(endorsed.Index, endorsed))
+		staked := new(big.Int).Set(endorsed.StakedAmount)
+
+		index, slashed, err := p.SlashSelfStake(ctx, sm, candidate.Operator, 50, nil)
+		r.NoError(err)
+		r.Equal(uint64(1), index)
+		r.Zero(slashed.Sign())
+		csm, err = NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		endorsed, err = csm.getBucket(1)
+		r.NoError(err)
+		r.Equal(staked, endorsed.StakedAmount)
+	})
+}
@@ -82,6 +82,14 @@ func (p *Protocol) validateCandidateUpdate(ctx context.Context, act *action.Cand
 			return action.ErrInvalidCanName
 		}
 	}
+	if rate, ok := act.CommissionRate(); ok {
+		if !protocol.MustGetFeatureCtx(ctx).DistributeRewardToVoters {
+			return errors.Wrap(action.ErrInvalidAct, "commission rate is disabled")
+		}
+		if rate > action.MaxCommissionRate {
+			return action.ErrInvalidCommissionRate
+		}
+	}
 	return nil
 }
 
@@ -113,3 +121,27 @@ func (p *Protocol) validateMigrateStake(ctx context.Context, act *action.Migrate
 	}
 	return nil
 }
+
+func (p *Protocol) validateSplitStake(ctx context.Context, act *action.SplitStake) error {
+	if !protocol.MustGetFeatureCtx(ctx).SplitMergeNativeStake {
+		return errors.Wrap(action.ErrInvalidAct, "split stake is disabled")
+	}
+	if act.Amount().Cmp(p.config.MinStakeAmount) == -1 {
+		return errors.Wrap(action.ErrInvalidAmount, "split amount is less than the minimum stake amount")
+	}
+	return nil
+}
+
+func (p *Protocol) validateMergeStake(ctx context.Context, act *action.MergeStake) error {
+	if !protocol.MustGetFeatureCtx(ctx).SplitMergeNativeStake {
+		return errors.Wrap(action.ErrInvalidAct, "merge stake is disabled")
+	}
+	return nil
+}
+
+func (p *Protocol) validateCandidateDeactivate(ctx context.Context, act *action.CandidateDeactivate) error {
+	if !protocol.MustGetFeatureCtx(ctx).CandidateDeactivation {
+		return errors.Wrap(action.ErrInvalidAct, "candidate deactivate is disabled")
+	}
+	return nil
+}
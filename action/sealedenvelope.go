@@ -31,7 +31,7 @@ type SealedEnvelope struct {
 func (sealed *SealedEnvelope) envelopeHash() (hash.Hash256, error) {
 	switch sealed.encoding {
 	case iotextypes.Encoding_TX_CONTAINER:
-		act, ok := sealed.Action().(*txContainer)
+		act, ok := txContainerOf(sealed.Envelope)
 		if !ok {
 			return hash.ZeroHash256, ErrInvalidAct
 		}
@@ -81,7 +81,7 @@ func (sealed *SealedEnvelope) Hash() (hash.Hash256, error) {
 func (sealed *SealedEnvelope) calcHash() (hash.Hash256, error) {
 	switch sealed.encoding {
 	case iotextypes.Encoding_TX_CONTAINER:
-		act, ok := sealed.Action().(*txContainer)
+		act, ok := txContainerOf(sealed.Envelope)
 		if !ok {
 			return hash.ZeroHash256, ErrInvalidAct
 		}
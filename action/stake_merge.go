@@ -0,0 +1,218 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// MergeStakePayloadGas represents the MergeStake payload gas per uint
+	MergeStakePayloadGas = uint64(100)
+	// MergeStakeBaseIntrinsicGas represents the base intrinsic gas for MergeStake
+	MergeStakeBaseIntrinsicGas = uint64(10000)
+	// MergeStakeSourceGas represents the MergeStake gas per source bucket
+	MergeStakeSourceGas = uint64(10000)
+
+	_mergeStakeInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "uint64",
+					"name": "bucketIndex",
+					"type": "uint64"
+				},
+				{
+					"internalType": "uint64[]",
+					"name": "sourceIndices",
+					"type": "uint64[]"
+				},
+				{
+					"internalType": "uint8[]",
+					"name": "data",
+					"type": "uint8[]"
+				}
+			],
+			"name": "mergeStake",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// _mergeStakeMethod is the interface of the abi encoding of merge stake action
+	_mergeStakeMethod abi.Method
+	_                 EthCompatibleAction = (*MergeStake)(nil)
+
+	// ErrInvalidMergeSources indicates the source buckets of a merge are invalid
+	ErrInvalidMergeSources = errors.New("invalid merge source buckets")
+)
+
+// MergeStake defines the action of merging the source buckets into the target bucket, all of which must have
+// the same candidate, duration and auto-stake. It has no native proto, and can only be sent as an ethereum
+// transaction in a tx container
+type MergeStake struct {
+	AbstractAction
+
+	bucketIndex   uint64
+	sourceIndices []uint64
+	payload       []byte
+}
+
+func init() {
+	mergeStakeInterface, err := abi.JSON(strings.NewReader(_mergeStakeInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	_mergeStakeMethod, ok = mergeStakeInterface.Methods["mergeStake"]
+	if !ok {
+		panic("fail to load the method")
+	}
+}
+
+// NewMergeStake returns a MergeStake instance
+func NewMergeStake(
+	nonce uint64,
+	index uint64,
+	sourceIndices []uint64,
+	payload []byte,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) (*MergeStake, error) {
+	return &MergeStake{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		bucketIndex:   index,
+		sourceIndices: sourceIndices,
+		payload:       payload,
+	}, nil
+}
+
+// BucketIndex returns the index of the target bucket
+func (ms *MergeStake) BucketIndex() uint64 { return ms.bucketIndex }
+
+// SourceIndices returns the indices of the buckets merged into the target bucket
+func (ms *MergeStake) SourceIndices() []uint64 { return ms.sourceIndices }
+
+// Payload returns the payload bytes
+func (ms *MergeStake) Payload() []byte { return ms.payload }
+
+// IntrinsicGas returns the intrinsic gas of a MergeStake
+func (ms *MergeStake) IntrinsicGas() (uint64, error) {
+	payloadSize := uint64(len(ms.Payload()))
+	gas, err := CalculateIntrinsicGas(MergeStakeBaseIntrinsicGas, MergeStakePayloadGas, payloadSize)
+	if err != nil {
+		return 0, err
+	}
+	return CalculateIntrinsicGas(gas, MergeStakeSourceGas, uint64(len(ms.sourceIndices)))
+}
+
+// Cost returns the total cost of a MergeStake
+func (ms *MergeStake) Cost() (*big.Int, error) {
+	intrinsicGas, err := ms.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the MergeStake")
+	}
+	mergeStakeFee := big.NewInt(0).Mul(ms.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return mergeStakeFee, nil
+}
+
+func (ms *MergeStake) isContainerOnly() bool {
+	return true
+}
+
+// SanityCheck validates the variables in the action
+func (ms *MergeStake) SanityCheck() error {
+	if len(ms.sourceIndices) == 0 {
+		return errors.Wrap(ErrInvalidMergeSources, "no source bucket")
+	}
+	seen := make(map[uint64]struct{}, len(ms.sourceIndices))
+	for _, index := range ms.sourceIndices {
+		if index == ms.bucketIndex {
+			return errors.Wrapf(ErrInvalidMergeSources, "target bucket %d is a source", index)
+		}
+		if _, ok := seen[index]; ok {
+			return errors.Wrapf(ErrInvalidMergeSources, "duplicate source bucket %d", index)
+		}
+		seen[index] = struct{}{}
+	}
+
+	return ms.AbstractAction.SanityCheck()
+}
+
+// EncodeABIBinary encodes data in abi encoding
+func (ms *MergeStake) EncodeABIBinary() ([]byte, error) {
+	return ms.encodeABIBinary()
+}
+
+func (ms *MergeStake) encodeABIBinary() ([]byte, error) {
+	sourceIndices := ms.sourceIndices
+	if sourceIndices == nil {
+		sourceIndices = []uint64{}
+	}
+	data, err := _mergeStakeMethod.Inputs.Pack(ms.bucketIndex, sourceIndices, ms.payload)
+	if err != nil {
+		return nil, err
+	}
+	return append(_mergeStakeMethod.ID, data...), nil
+}
+
+// NewMergeStakeFromABIBinary decodes data into mergeStake action
+func NewMergeStakeFromABIBinary(data []byte) (*MergeStake, error) {
+	var (
+		paramsMap = map[string]interface{}{}
+		ok        bool
+		ms        MergeStake
+	)
+	// sanity check
+	if len(data) <= 4 || !bytes.Equal(_mergeStakeMethod.ID[:], data[:4]) {
+		return nil, errDecodeFailure
+	}
+	if err := _mergeStakeMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	if ms.bucketIndex, ok = paramsMap["bucketIndex"].(uint64); !ok {
+		return nil, errDecodeFailure
+	}
+	if ms.sourceIndices, ok = paramsMap["sourceIndices"].([]uint64); !ok {
+		return nil, errDecodeFailure
+	}
+	if ms.payload, ok = paramsMap["data"].([]byte); !ok {
+		return nil, errDecodeFailure
+	}
+	return &ms, nil
+}
+
+// ToEthTx converts action to eth-compatible tx
+func (ms *MergeStake) ToEthTx(_ uint32) (*types.Transaction, error) {
+	data, err := ms.encodeABIBinary()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    ms.Nonce(),
+		GasPrice: ms.GasPrice(),
+		Gas:      ms.GasLimit(),
+		To:       &_stakingProtocolEthAddr,
+		Value:    big.NewInt(0),
+		Data:     data,
+	}), nil
+}
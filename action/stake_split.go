@@ -0,0 +1,199 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// SplitStakePayloadGas represents the SplitStake payload gas per uint
+	SplitStakePayloadGas = uint64(100)
+	// SplitStakeBaseIntrinsicGas represents the base intrinsic gas for SplitStake
+	SplitStakeBaseIntrinsicGas = uint64(10000)
+
+	_splitStakeInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "uint64",
+					"name": "bucketIndex",
+					"type": "uint64"
+				},
+				{
+					"internalType": "uint256",
+					"name": "amount",
+					"type": "uint256"
+				},
+				{
+					"internalType": "uint8[]",
+					"name": "data",
+					"type": "uint8[]"
+				}
+			],
+			"name": "splitStake",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// _splitStakeMethod is the interface of the abi encoding of split stake action
+	_splitStakeMethod abi.Method
+	_                 EthCompatibleAction = (*SplitStake)(nil)
+)
+
+// SplitStake defines the action of splitting the amount out of a bucket into a new bucket, which has the same
+// candidate, duration and auto-stake as the original bucket. It has no native proto, and can only be sent as an
+// ethereum transaction in a tx container
+type SplitStake struct {
+	AbstractAction
+
+	bucketIndex uint64
+	amount      *big.Int
+	payload     []byte
+}
+
+func init() {
+	splitStakeInterface, err := abi.JSON(strings.NewReader(_splitStakeInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	_splitStakeMethod, ok = splitStakeInterface.Methods["splitStake"]
+	if !ok {
+		panic("fail to load the method")
+	}
+}
+
+// NewSplitStake returns a SplitStake instance
+func NewSplitStake(
+	nonce uint64,
+	index uint64,
+	amount string,
+	payload []byte,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) (*SplitStake, error) {
+	split, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return nil, errors.Wrapf(ErrInvalidAmount, "amount %s", amount)
+	}
+	return &SplitStake{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		bucketIndex: index,
+		amount:      split,
+		payload:     payload,
+	}, nil
+}
+
+// Amount returns the amount split into the new bucket
+func (ss *SplitStake) Amount() *big.Int { return ss.amount }
+
+// Payload returns the payload bytes
+func (ss *SplitStake) Payload() []byte { return ss.payload }
+
+// BucketIndex returns the index of the bucket to split
+func (ss *SplitStake) BucketIndex() uint64 { return ss.bucketIndex }
+
+// IntrinsicGas returns the intrinsic gas of a SplitStake
+func (ss *SplitStake) IntrinsicGas() (uint64, error) {
+	payloadSize := uint64(len(ss.Payload()))
+	return CalculateIntrinsicGas(SplitStakeBaseIntrinsicGas, SplitStakePayloadGas, payloadSize)
+}
+
+// Cost returns the total cost of a SplitStake
+func (ss *SplitStake) Cost() (*big.Int, error) {
+	intrinsicGas, err := ss.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the SplitStake")
+	}
+	splitStakeFee := big.NewInt(0).Mul(ss.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return splitStakeFee, nil
+}
+
+func (ss *SplitStake) isContainerOnly() bool {
+	return true
+}
+
+// SanityCheck validates the variables in the action
+func (ss *SplitStake) SanityCheck() error {
+	if ss.Amount().Sign() <= 0 {
+		return errors.Wrap(ErrInvalidAmount, "negative value")
+	}
+
+	return ss.AbstractAction.SanityCheck()
+}
+
+// EncodeABIBinary encodes data in abi encoding
+func (ss *SplitStake) EncodeABIBinary() ([]byte, error) {
+	return ss.encodeABIBinary()
+}
+
+func (ss *SplitStake) encodeABIBinary() ([]byte, error) {
+	data, err := _splitStakeMethod.Inputs.Pack(ss.bucketIndex, ss.amount, ss.payload)
+	if err != nil {
+		return nil, err
+	}
+	return append(_splitStakeMethod.ID, data...), nil
+}
+
+// NewSplitStakeFromABIBinary decodes data into splitStake action
+func NewSplitStakeFromABIBinary(data []byte) (*SplitStake, error) {
+	var (
+		paramsMap = map[string]interface{}{}
+		ok        bool
+		ss        SplitStake
+	)
+	// sanity check
+	if len(data) <= 4 || !bytes.Equal(_splitStakeMethod.ID[:], data[:4]) {
+		return nil, errDecodeFailure
+	}
+	if err := _splitStakeMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	if ss.bucketIndex, ok = paramsMap["bucketIndex"].(uint64); !ok {
+		return nil, errDecodeFailure
+	}
+	if ss.amount, ok = paramsMap["amount"].(*big.Int); !ok {
+		return nil, errDecodeFailure
+	}
+	if ss.payload, ok = paramsMap["data"].([]byte); !ok {
+		return nil, errDecodeFailure
+	}
+	return &ss, nil
+}
+
+// ToEthTx converts action to eth-compatible tx
+func (ss *SplitStake) ToEthTx(_ uint32) (*types.Transaction, error) {
+	data, err := ss.encodeABIBinary()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    ss.Nonce(),
+		GasPrice: ss.GasPrice(),
+		Gas:      ss.GasLimit(),
+		To:       &_stakingProtocolEthAddr,
+		Value:    big.NewInt(0),
+		Data:     data,
+	}), nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSplitStake(t *testing.T) {
+	require := require.New(t)
+	_, err := NewSplitStake(1, 10, "abc", nil, 1000000, big.NewInt(10))
+	require.Equal(ErrInvalidAmount, errors.Cause(err))
+	ss, err := NewSplitStake(1, 10, "0", nil, 1000000, big.NewInt(10))
+	require.NoError(err)
+	require.Equal(ErrInvalidAmount, errors.Cause(ss.SanityCheck()))
+
+	ss, err = NewSplitStake(1, 10, "100", []byte("payload"), 1000000, big.NewInt(10))
+	require.NoError(err)
+	require.NoError(ss.SanityCheck())
+	require.Equal(uint64(10), ss.BucketIndex())
+	require.Equal("100", ss.Amount().String())
+	require.Equal([]byte("payload"), ss.Payload())
+	gas, err := ss.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(uint64(10700), gas)
+	cost, err := ss.Cost()
+	require.NoError(err)
+	require.Equal("107000", cost.String())
+
+	data, err := ss.EncodeABIBinary()
+	require.NoError(err)
+	ss2, err := NewSplitStakeFromABIBinary(data)
+	require.NoError(err)
+	require.Equal(ss.BucketIndex(), ss2.BucketIndex())
+	require.Equal(ss.Amount(), ss2.Amount())
+	require.Equal(ss.Payload(), ss2.Payload())
+	_, err = NewDepositToStakeFromABIBinary(data)
+	require.Error(err)
+	act, err := newStakingActionFromABIBinary(data)
+	require.NoError(err)
+	require.IsType(&SplitStake{}, act)
+}
+
+func TestMergeStake(t *testing.T) {
+	require := require.New(t)
+	for _, v := range []struct {
+		sources []uint64
+		err     error
+	}{
+		{nil, ErrInvalidMergeSources},
+		{[]uint64{3, 10}, ErrInvalidMergeSources},
+		{[]uint64{3, 4, 3}, ErrInvalidMergeSources},
+		{[]uint64{3, 4}, nil},
+	} {
+		ms, err := NewMergeStake(1, 10, v.sources, nil, 1000000, big.NewInt(10))
+		require.NoError(err)
+		require.Equal(v.err, errors.Cause(ms.SanityCheck()))
+	}
+
+	ms, err := NewMergeStake(1, 10, []uint64{3, 4, 300}, []byte("payload"), 1000000, big.NewInt(10))
+	require.NoError(err)
+	require.Equal(uint64(10), ms.BucketIndex())
+	require.Equal([]uint64{3, 4, 300}, ms.SourceIndices())
+	gas, err := ms.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(uint64(40700), gas)
+	cost, err := ms.Cost()
+	require.NoError(err)
+	require.Equal("407000", cost.String())
+
+	data, err := ms.EncodeABIBinary()
+	require.NoError(err)
+	ms2, err := NewMergeStakeFromABIBinary(data)
+	require.NoError(err)
+	require.Equal(ms.BucketIndex(), ms2.BucketIndex())
+	require.Equal(ms.SourceIndices(), ms2.SourceIndices())
+	require.Equal(ms.Payload(), ms2.Payload())
+	act, err := newStakingActionFromABIBinary(data)
+	require.NoError(err)
+	require.IsType(&MergeStake{}, act)
+}
+
+func TestSplitMergeStakeEnvelope(t *testing.T) {
+	require := require.New(t)
+	split, err := NewSplitStake(1, 10, "100", nil, 1000000, big.NewInt(10))
+	require.NoError(err)
+	merge, err := NewMergeStake(1, 10, []uint64{3, 4}, nil, 1000000, big.NewInt(10))
+	require.NoError(err)
+	for _, act := range []EthCompatibleAction{split, merge} {
+		require.True(IsContainerOnly(act.(Action)))
+		// the action has no native proto, and is only carried by the tx container
+		selp := signedTxContainer(require, act, identityset.PrivateKey(27))
+		h1, err := selp.Hash()
+		require.NoError(err)
+		ser, err := proto.Marshal(selp.Proto())
+		require.NoError(err)
+		pb := &iotextypes.Action{}
+		require.NoError(proto.Unmarshal(ser, pb))
+		selp2, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(pb)
+		require.NoError(err)
+		require.IsType(&txContainer{}, selp2.Action())
+		require.NoError(selp2.Action().(TxContainer).Unfold(selp2, context.Background(), stakingChecker))
+		require.IsType(act, selp2.Action())
+		require.EqualValues(iotextypes.Encoding_TX_CONTAINER, selp2.Encoding())
+		h2, err := selp2.Hash()
+		require.NoError(err)
+		require.Equal(h1, h2)
+		require.NoError(selp2.VerifySignature())
+	}
+	deposit, err := NewDepositToStake(1, 10, "100", nil, 1000000, big.NewInt(10))
+	require.NoError(err)
+	require.False(IsContainerOnly(deposit))
+}
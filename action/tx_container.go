@@ -6,6 +6,7 @@
 package action
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 
@@ -20,9 +21,44 @@ var (
 	_ TxContainer = (*txContainer)(nil)
 )
 
-type txContainer struct {
-	raw []byte
-	tx  *types.Transaction
+type (
+	txContainer struct {
+		raw []byte
+		tx  *types.Transaction
+	}
+
+	// containerOnlyAction is implemented by the actions which have no native proto, such an action is only
+	// carried by the ethereum transaction in a tx container
+	containerOnlyAction interface {
+		isContainerOnly() bool
+	}
+
+	// unfoldedEnvelope is the envelope of a container only action unfolded from a tx container, it is still
+	// serialized and hashed as the tx container
+	unfoldedEnvelope struct {
+		Envelope
+		container Envelope
+	}
+)
+
+// IsContainerOnly returns whether the action is only carried by the ethereum transaction in a tx container
+func IsContainerOnly(act Action) bool {
+	a, ok := act.(containerOnlyAction)
+	return ok && a.isContainerOnly()
+}
+
+// txContainerOf returns the tx container of the envelope, whether it is unfolded or not
+func txContainerOf(elp Envelope) (*txContainer, bool) {
+	if unfolded, ok := elp.(*unfoldedEnvelope); ok {
+		elp = unfolded.container
+	}
+	act, ok := elp.Action().(*txContainer)
+	return act, ok
+}
+
+// Proto returns the proto of the tx container
+func (elp *unfoldedEnvelope) Proto() *iotextypes.ActionCore {
+	return elp.container.Proto()
 }
 
 func (etx *txContainer) hash() hash.Hash256 {
@@ -86,7 +122,9 @@ func (etx *txContainer) Unfold(selp *SealedEnvelope, ctx context.Context, checke
 	if err != nil {
 		return err
 	}
-	if isContract {
+	if to := etx.tx.To(); to != nil && bytes.Equal(to.Bytes(), _governanceProtocolEthAddr.Bytes()) {
+		elp, err = elpBuilder.BuildGovernanceAction(etx.tx)
+	} else if isContract {
 		elp, err = elpBuilder.BuildExecution(etx.tx)
 	} else if isStaking {
 		elp, err = elpBuilder.BuildStakingAction(etx.tx)
@@ -102,6 +140,13 @@ func (etx *txContainer) Unfold(selp *SealedEnvelope, ctx context.Context, checke
 	if err != nil {
 		return err
 	}
+	if IsContainerOnly(elp.Action()) {
+		selp.Envelope = &unfoldedEnvelope{
+			Envelope:  elp,
+			container: selp.Envelope,
+		}
+		return nil
+	}
 	selp.Envelope = elp
 	selp.encoding = encoding
 	selp.hash = hash.ZeroHash256
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+// signedTxContainer returns the action signed as an ethereum transaction in a tx container
+func signedTxContainer(r *require.Assertions, act EthCompatibleAction, sk crypto.PrivateKey) *SealedEnvelope {
+	tx, err := act.ToEthTx(_evmNetworkID)
+	r.NoError(err)
+	signer, err := NewEthSigner(iotextypes.Encoding_ETHEREUM_EIP155, _evmNetworkID)
+	r.NoError(err)
+	tx, err = types.SignTx(tx, signer, sk.EcdsaPrivateKey().(*ecdsa.PrivateKey))
+	r.NoError(err)
+	raw, err := tx.MarshalBinary()
+	r.NoError(err)
+	_, sig, pubkey, err := ExtractTypeSigPubkey(tx)
+	r.NoError(err)
+	selp, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(&iotextypes.Action{
+		Core: &iotextypes.ActionCore{
+			Version:  version.ProtocolVersion,
+			Nonce:    tx.Nonce(),
+			GasLimit: tx.Gas(),
+			GasPrice: tx.GasPrice().String(),
+			Action: &iotextypes.ActionCore_TxContainer{
+				TxContainer: &iotextypes.TxContainer{Raw: raw},
+			},
+		},
+		SenderPubKey: pubkey.Bytes(),
+		Signature:    sig,
+		Encoding:     iotextypes.Encoding_TX_CONTAINER,
+	})
+	r.NoError(err)
+	return selp
+}
+
+func stakingChecker(context.Context, *common.Address) (bool, bool, bool, error) {
+	return false, true, false, nil
+}
+
+func TestUnfoldContainerOnlyAction(t *testing.T) {
+	r := require.New(t)
+	cu, err := NewCandidateUpdateWithCommission(_cuNonce, _cuName, _cuOperatorAddrStr, _cuRewardAddrStr, 1500, _cuGasLimit, _cuGasPrice)
+	r.NoError(err)
+	selp := signedTxContainer(r, cu, _senderKey)
+	h, err := selp.Hash()
+	r.NoError(err)
+	r.NoError(selp.Action().(TxContainer).Unfold(selp, context.Background(), stakingChecker))
+
+	// the action is unfolded, but still serialized and hashed as the tx container
+	_, ok := selp.Action().(*CandidateUpdate)
+	r.True(ok)
+	r.EqualValues(iotextypes.Encoding_TX_CONTAINER, selp.Encoding())
+	h2, err := selp.Hash()
+	r.NoError(err)
+	r.Equal(h, h2)
+	r.NoError(selp.VerifySignature())
+	ser, err := proto.Marshal(selp.Proto())
+	r.NoError(err)
+	pb := &iotextypes.Action{}
+	r.NoError(proto.Unmarshal(ser, pb))
+	selp2, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(pb)
+	r.NoError(err)
+	_, ok = selp2.Action().(*txContainer)
+	r.True(ok)
+	h2, err = selp2.Hash()
+	r.NoError(err)
+	r.Equal(h, h2)
+
+	// the other actions are converted to the ethereum encoding
+	cu, err = NewCandidateUpdate(_cuNonce, _cuName, _cuOperatorAddrStr, _cuRewardAddrStr, _cuGasLimit, _cuGasPrice)
+	r.NoError(err)
+	selp = signedTxContainer(r, cu, _senderKey)
+	r.NoError(selp.Action().(TxContainer).Unfold(selp, context.Background(), stakingChecker))
+	r.EqualValues(iotextypes.Encoding_ETHEREUM_EIP155, selp.Encoding())
+	r.NotNil(selp.Proto().GetCore().GetCandidateUpdate())
+}
@@ -78,6 +78,14 @@ type ActPool interface {
 	ReceiveBlock(*block.Block) error
 
 	AddActionEnvelopeValidators(...action.SealedEnvelopeValidator)
+	// AddSubscriber adds a subscriber which is notified of the actions accepted into the pool
+	AddSubscriber(Subscriber)
+}
+
+// Subscriber is the interface to listen to the actions accepted into actpool
+type Subscriber interface {
+	// OnAdded is called when an action is accepted into the pool, it should not block
+	OnAdded(*action.SealedEnvelope)
 }
 
 // SortedActions is a slice of actions that implements sort.Interface to sort by Value.
@@ -103,6 +111,8 @@ type actPool struct {
 	senderBlackList          map[string]bool
 	jobQueue                 []chan workerJob
 	worker                   []*queueWorker
+	subs                     []Subscriber
+	subsMutex                sync.RWMutex
 }
 
 // NewActPool constructs a new actpool
@@ -157,6 +167,21 @@ func (ap *actPool) AddActionEnvelopeValidators(fs ...action.SealedEnvelopeValida
 	ap.actionEnvelopeValidators = append(ap.actionEnvelopeValidators, fs...)
 }
 
+// AddSubscriber adds a subscriber which is notified of the actions accepted into the pool
+func (ap *actPool) AddSubscriber(sub Subscriber) {
+	ap.subsMutex.Lock()
+	defer ap.subsMutex.Unlock()
+	ap.subs = append(ap.subs, sub)
+}
+
+func (ap *actPool) notifySubscribers(act *action.SealedEnvelope) {
+	ap.subsMutex.RLock()
+	defer ap.subsMutex.RUnlock()
+	for _, sub := range ap.subs {
+		sub.OnAdded(act)
+	}
+}
+
 // Reset resets actpool state
 // Step I: remove all the actions in actpool that have already been committed to block
 // Step II: update pending balance of each account if it still exists in pool
@@ -243,12 +268,16 @@ func (ap *actPool) Add(ctx context.Context, act *action.SealedEnvelope) error {
 		return ErrGasTooHigh
 	}
 
-	return ap.enqueue(
+	if err := ap.enqueue(
 		ctx,
 		act,
 		atomic.LoadUint64(&ap.gasInPool) > ap.cfg.MaxGasLimitPerPool-intrinsicGas ||
 			uint64(ap.allActions.Count()) >= ap.cfg.MaxNumActsPerPool,
-	)
+	); err != nil {
+		return err
+	}
+	ap.notifySubscribers(act)
+	return nil
 }
 
 func checkSelpData(act *action.SealedEnvelope) error {
@@ -1178,3 +1178,39 @@ func TestValidateMinGasPrice(t *testing.T) {
 	mgp := ap.MinGasPrice()
 	require.IsType(t, &big.Int{}, mgp)
 }
+
+type testSubscriber struct {
+	acts []*action.SealedEnvelope
+}
+
+func (s *testSubscriber) OnAdded(act *action.SealedEnvelope) {
+	s.acts = append(s.acts, act)
+}
+
+func TestActPool_AddSubscriber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	ap, err := NewActPool(genesis.Default, sf, getActPoolCfg())
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+	sub := &testSubscriber{}
+	ap.AddSubscriber(sub)
+
+	tsf1, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr1, _priKey1, uint64(2), big.NewInt(200), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+	require.NoError(ap.Add(ctx, tsf1))
+	require.Equal(action.ErrInsufficientFunds, errors.Cause(ap.Add(ctx, tsf2)))
+	// only the accepted action is published
+	require.Equal([]*action.SealedEnvelope{tsf1}, sub.acts)
+}
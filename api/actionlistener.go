@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// _pendingActionLogSize is the number of latest pending actions kept for the pending transaction filters
+	_pendingActionLogSize = 4096
+)
+
+type web3PendingActionListener struct {
+	streamHandle streamHandler
+	assemble     func(*action.SealedEnvelope) (interface{}, error)
+}
+
+// NewWeb3PendingActionListener returns a new websocket listener of the actions accepted into actpool,
+// the action hash is streamed if assemble is nil, otherwise the assembled transaction is streamed
+func NewWeb3PendingActionListener(handler streamHandler, assemble func(*action.SealedEnvelope) (interface{}, error)) apitypes.ActionResponder {
+	return &web3PendingActionListener{
+		streamHandle: handler,
+		assemble:     assemble,
+	}
+}
+
+// Respond to new block
+func (pl *web3PendingActionListener) Respond(string, *block.Block) error {
+	return nil
+}
+
+// RespondAction to new pending action
+func (pl *web3PendingActionListener) RespondAction(id string, selp *action.SealedEnvelope) error {
+	actHash, err := selp.Hash()
+	if err != nil {
+		return err
+	}
+	var result interface{} = "0x" + hex.EncodeToString(actHash[:])
+	if pl.assemble != nil {
+		if result, err = pl.assemble(selp); err != nil {
+			// the action is not an ethereum transaction
+			log.L().Debug("Failed to assemble the pending action", zap.String("actHash", hex.EncodeToString(actHash[:])), zap.Error(err))
+			return nil
+		}
+	}
+	if _, err := pl.streamHandle(&streamResponse{
+		id:     id,
+		result: result,
+	}); err != nil {
+		log.L().Info(
+			"Error when streaming the pending action",
+			zap.String("actHash", hex.EncodeToString(actHash[:])),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// Exit send to error channel
+func (pl *web3PendingActionListener) Exit() {}
+
+// pendingActionLog keeps the hashes of the latest actions accepted into actpool in a ring buffer,
+// each action is assigned an increasing sequence number
+type pendingActionLog struct {
+	mutex  sync.RWMutex
+	hashes []hash.Hash256
+	next   uint64
+}
+
+func newPendingActionLog(size int) *pendingActionLog {
+	return &pendingActionLog{
+		hashes: make([]hash.Hash256, size),
+	}
+}
+
+func (l *pendingActionLog) add(h hash.Hash256) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.hashes[l.next%uint64(len(l.hashes))] = h
+	l.next++
+}
+
+// since returns the hashes of the actions since the sequence number, and the sequence number of the next action,
+// the actions which have been dropped out of the buffer are skipped
+func (l *pendingActionLog) since(seq uint64) ([]hash.Hash256, uint64) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	if seq >= l.next {
+		return nil, l.next
+	}
+	size := uint64(len(l.hashes))
+	if l.next-seq > size {
+		seq = l.next - size
+	}
+	ret := make([]hash.Hash256, 0, l.next-seq)
+	for ; seq < l.next; seq++ {
+		ret = append(ret, l.hashes[seq%size])
+	}
+	return ret, l.next
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
+)
+
+func TestWeb3PendingActionListener(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	actHash, err := selp.Hash()
+	require.NoError(err)
+	writer := mock_apitypes.NewMockWeb3ResponseWriter(ctrl)
+
+	t.Run("stream hash", func(t *testing.T) {
+		listener := NewWeb3PendingActionListener(writer.Write, nil)
+		require.NoError(listener.Respond("streamid_1", nil))
+		writer.EXPECT().Write(&streamResponse{
+			id:     "streamid_1",
+			result: "0x" + hex.EncodeToString(actHash[:]),
+		}).Return(0, nil).Times(1)
+		require.NoError(listener.RespondAction("streamid_1", selp))
+	})
+
+	t.Run("stream transaction", func(t *testing.T) {
+		listener := NewWeb3PendingActionListener(writer.Write, func(selp *action.SealedEnvelope) (interface{}, error) {
+			return selp.Nonce(), nil
+		})
+		writer.EXPECT().Write(&streamResponse{
+			id:     "streamid_1",
+			result: uint64(1),
+		}).Return(0, nil).Times(1)
+		require.NoError(listener.RespondAction("streamid_1", selp))
+	})
+
+	t.Run("skip unsupported action", func(t *testing.T) {
+		listener := NewWeb3PendingActionListener(writer.Write, func(*action.SealedEnvelope) (interface{}, error) {
+			return nil, errUnsupportedAction
+		})
+		require.NoError(listener.RespondAction("streamid_1", selp))
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		listener := NewWeb3PendingActionListener(writer.Write, nil)
+		writer.EXPECT().Write(gomock.Any()).Return(0, errors.New("closed")).Times(1)
+		require.ErrorContains(listener.RespondAction("streamid_1", selp), "closed")
+	})
+}
+
+func TestPendingActionLog(t *testing.T) {
+	require := require.New(t)
+
+	l := newPendingActionLog(3)
+	hashes, next := l.since(0)
+	require.Empty(hashes)
+	require.Zero(next)
+
+	h := func(i byte) hash.Hash256 { return hash.Hash256b([]byte{i}) }
+	for i := byte(0); i < 2; i++ {
+		l.add(h(i))
+	}
+	hashes, next = l.since(0)
+	require.Equal([]hash.Hash256{h(0), h(1)}, hashes)
+	require.EqualValues(2, next)
+	hashes, next = l.since(1)
+	require.Equal([]hash.Hash256{h(1)}, hashes)
+	require.EqualValues(2, next)
+	hashes, next = l.since(5)
+	require.Empty(hashes)
+	require.EqualValues(2, next)
+
+	// the oldest actions are dropped out of the buffer
+	for i := byte(2); i < 5; i++ {
+		l.add(h(i))
+	}
+	hashes, next = l.since(0)
+	require.Equal([]hash.Hash256{h(2), h(3), h(4)}, hashes)
+	require.EqualValues(5, next)
+}
@@ -6,6 +6,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/iotexproject/iotex-core/gasstation"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
 )
@@ -25,6 +27,75 @@ type Config struct {
 	BatchRequestLimit int `yaml:"batchRequestLimit"`
 	// WebsocketRateLimit is the maximum number of messages per second per client.
 	WebsocketRateLimit int `yaml:"websocketRateLimit"`
+	// RateLimit is the per-client rate limiting of the http, websocket and grpc endpoints.
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	// GraphQLPort is the port of the EIP-1767 graphql endpoint, 0 disables it.
+	GraphQLPort int `yaml:"graphqlPort"`
+	// ResponseCache is the cache of the responses to immutable historical queries.
+	ResponseCache ResponseCacheConfig `yaml:"responseCache"`
+	// IPCPath is the path of the unix domain socket serving web3 JSON-RPC, empty disables it.
+	IPCPath string `yaml:"ipcPath"`
+	// IPCOnlyNamespaces are the web3 namespaces only served on the unix domain socket, they are
+	// served on all endpoints if IPCPath is empty.
+	IPCOnlyNamespaces []string `yaml:"ipcOnlyNamespaces"`
+	// SlowQuery is the log of the requests slower than a threshold.
+	SlowQuery SlowQueryConfig `yaml:"slowQuery"`
+}
+
+// SlowQueryConfig is the config of the slow query log
+type SlowQueryConfig struct {
+	// Threshold is the duration beyond which a request is logged as slow, 0 disables the slow query log.
+	Threshold time.Duration `yaml:"threshold"`
+	// Size is the number of the slowest recent requests kept for debug_slowQueries.
+	Size int `yaml:"size"`
+	// Window is how long a slow request is kept as recent, 0 keeps it until a slower one evicts it.
+	Window time.Duration `yaml:"window"`
+	// MaxParamsLength is the maximum length of the logged params, the longer ones are truncated.
+	MaxParamsLength int `yaml:"maxParamsLength"`
+}
+
+// ResponseCacheConfig is the config of the response cache
+type ResponseCacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Size is the number of responses kept in memory.
+	Size int `yaml:"size"`
+	// RedisURL is the optional redis shared by nodes behind the in-memory cache.
+	RedisURL string `yaml:"redisURL"`
+	// Expiration is the expiration of the responses in redis.
+	Expiration time.Duration `yaml:"expiration"`
+}
+
+// RateLimitConfig is the config of per-client rate limiting
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// IPRate is the number of request costs per second allowed for each client IP without api key.
+	IPRate float64 `yaml:"ipRate"`
+	// IPBurst is the bucket size of each client IP without api key.
+	IPBurst int `yaml:"ipBurst"`
+	// MaxTrackedIPs is the maximum number of client IPs tracked, the least recently seen ones are evicted.
+	MaxTrackedIPs int `yaml:"maxTrackedIPs"`
+	// TrustForwardedFor uses the first address of X-Forwarded-For as the client IP,
+	// only enable it if the node is behind a trusted proxy.
+	TrustForwardedFor bool `yaml:"trustForwardedFor"`
+	// APIKeys are the keys granted with their own limits, passed in X-API-Key header or x-api-key grpc metadata.
+	APIKeys []APIKeyConfig `yaml:"apiKeys"`
+	// DefaultCost is the cost of a method not in MethodCosts.
+	DefaultCost int `yaml:"defaultCost"`
+	// MethodCosts is the cost of web3 or grpc methods, keyed by the method name.
+	MethodCosts map[string]int `yaml:"methodCosts"`
+	// QuotaPeriod is the period the quota of api keys is reset.
+	QuotaPeriod time.Duration `yaml:"quotaPeriod"`
+}
+
+// APIKeyConfig is the config of an api key
+type APIKeyConfig struct {
+	// Name is the name of the key used in logs and metrics.
+	Name  string  `yaml:"name"`
+	Key   string  `yaml:"key"`
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+	// Quota is the total request costs allowed in a quota period, 0 means unlimited.
+	Quota uint64 `yaml:"quota"`
 }
 
 // DefaultConfig is the default config
@@ -38,4 +109,44 @@ var DefaultConfig = Config{
 	RangeQueryLimit:    1000,
 	BatchRequestLimit:  _defaultBatchRequestLimit,
 	WebsocketRateLimit: 5,
+	RateLimit: RateLimitConfig{
+		Enabled:       false,
+		IPRate:        50,
+		IPBurst:       100,
+		MaxTrackedIPs: 10000,
+		APIKeys:       []APIKeyConfig{},
+		DefaultCost:   1,
+		MethodCosts: map[string]int{
+			"eth_getLogs":                  10,
+			"eth_getFilterLogs":            10,
+			"eth_getBlockReceipts":         5,
+			"eth_call":                     2,
+			"eth_estimateGas":              2,
+			"eth_createAccessList":         5,
+			"eth_simulateV1":               10,
+			"debug_traceTransaction":       20,
+			"debug_traceCall":              20,
+			"debug_getStateDiff":           10,
+			"iotex_getStakingHistory":      5,
+			"graphql":                      10,
+			"GetLogs":                      10,
+			"ReadContract":                 2,
+			"EstimateActionGasConsumption": 2,
+			"TraceTransactionStructLogs":   20,
+		},
+		QuotaPeriod: 24 * time.Hour,
+	},
+	ResponseCache: ResponseCacheConfig{
+		Enabled:    false,
+		Size:       10000,
+		Expiration: 24 * time.Hour,
+	},
+	IPCPath:           "",
+	IPCOnlyNamespaces: []string{"admin", "debug"},
+	SlowQuery: SlowQueryConfig{
+		Threshold:       2 * time.Second,
+		Size:            100,
+		Window:          time.Hour,
+		MaxParamsLength: 512,
+	},
 }
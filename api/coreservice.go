@@ -12,9 +12,12 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
 	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
@@ -23,6 +26,7 @@ import (
 	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
 
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -139,6 +143,8 @@ type (
 		EstimateGasForNonExecution(action.Action) (uint64, error)
 		// EstimateExecutionGasConsumption estimate gas consumption for execution action
 		EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address) (uint64, error)
+		// CreateAccessList creates the access list of execution and returns it with the receipt of execution
+		CreateAccessList(ctx context.Context, callerAddr address.Address, exec *action.Execution) (types.AccessList, *action.Receipt, error)
 		// LogsInBlockByHash filter logs in the block by hash
 		LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error)
 		// LogsInRange filter logs among [start, end] blocks
@@ -155,6 +161,9 @@ type (
 		ChainListener() apitypes.Listener
 		// SimulateExecution simulates execution
 		SimulateExecution(context.Context, address.Address, *action.Execution) ([]byte, *action.Receipt, error)
+		// SimulateExecutions simulates the calls in order on one working set of the tip state, so that each call
+		// sees the state changes of the preceding ones, the calls are traced if the trace config is not nil
+		SimulateExecutions(context.Context, []*apitypes.SimulationCall, *tracers.TraceConfig) ([]*apitypes.SimulationResult, error)
 		// SyncingProgress returns the syncing status of node
 		SyncingProgress() (uint64, uint64, uint64)
 		// TipHeight returns the tip of the chain
@@ -163,6 +172,11 @@ type (
 		PendingNonce(address.Address) (uint64, error)
 		// ReceiveBlock broadcasts the block to api subscribers
 		ReceiveBlock(blk *block.Block) error
+		// ReceivePendingAction broadcasts the action accepted into actpool to api subscribers
+		ReceivePendingAction(selp *action.SealedEnvelope)
+		// PendingActionHashes returns the hashes of the actions accepted into actpool since the sequence number,
+		// and the sequence number of the next action
+		PendingActionHashes(since uint64) ([]hash.Hash256, uint64)
 		// BlockHashByBlockHeight returns block hash by block height
 		BlockHashByBlockHeight(blkHeight uint64) (hash.Hash256, error)
 		// TraceTransaction returns the trace result of a transaction
@@ -178,6 +192,12 @@ type (
 			data []byte,
 			config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error)
 
+		// StateDiff returns the states changed by the block at the height
+		StateDiff(height uint64) ([]*factory.StateChange, error)
+		// StakingHistory returns the lifecycle events of the staking buckets owned by the address,
+		// together with the total number of events
+		StakingHistory(owner address.Address, offset, limit uint64) ([]*blockindex.StakingEvent, uint64, error)
+
 		// Track tracks the api call
 		Track(ctx context.Context, start time.Time, method string, size int64, success bool)
 	}
@@ -201,7 +221,12 @@ type (
 		messageBatcher    *batch.Manager
 		apiStats          *nodestats.APILocalStats
 		sgdIndexer        blockindex.SGDRegistry
+		stateDiffIndexer  blockindex.StateDiffIndexer
+		stakingHistory    blockindex.StakingHistoryIndexer
 		getBlockTime      evm.GetBlockTime
+		pendingActions    chan *action.SealedEnvelope
+		pendingActionLog  *pendingActionLog
+		stopStreaming     context.CancelFunc
 	}
 
 	// jobDesc provides a struct to get and store logs in core.LogsInRange
@@ -245,6 +270,20 @@ func WithSGDIndexer(sgdIndexer blockindex.SGDRegistry) Option {
 	}
 }
 
+// WithStateDiffIndexer is the option to return state diffs of blocks through API.
+func WithStateDiffIndexer(stateDiffIndexer blockindex.StateDiffIndexer) Option {
+	return func(svr *coreService) {
+		svr.stateDiffIndexer = stateDiffIndexer
+	}
+}
+
+// WithStakingHistoryIndexer is the option to return staking histories of owners through API.
+func WithStakingHistoryIndexer(stakingHistory blockindex.StakingHistoryIndexer) Option {
+	return func(svr *coreService) {
+		svr.stakingHistory = stakingHistory
+	}
+}
+
 type intrinsicGasCalculator interface {
 	IntrinsicGas() (uint64, error)
 }
@@ -268,7 +307,7 @@ func newCoreService(
 	getBlockTime evm.GetBlockTime,
 	opts ...Option,
 ) (CoreService, error) {
-	if cfg == (Config{}) {
+	if reflect.DeepEqual(cfg, Config{}) {
 		log.L().Warn("API server is not configured.")
 		cfg = DefaultConfig
 	}
@@ -291,6 +330,9 @@ func newCoreService(
 		gs:            gasstation.NewGasStation(chain, dao, cfg.GasStation),
 		readCache:     NewReadCache(),
 		getBlockTime:  getBlockTime,
+		// the actions are dropped from streaming if the subscribers are too slow
+		pendingActions:   make(chan *action.SealedEnvelope, 1024),
+		pendingActionLog: newPendingActionLog(_pendingActionLogSize),
 	}
 
 	for _, opt := range opts {
@@ -531,11 +573,15 @@ func (core *coreService) validateChainID(chainID uint32) error {
 func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Address, sc *action.Execution) (string, *iotextypes.Receipt, error) {
 	log.Logger("api").Debug("receive read smart contract request")
 	key := hash.Hash160b(append([]byte(sc.Contract()), sc.Data()...))
+	// the result of a call with overrides does not reflect the chain state, so it is not cached
+	_, hasOverride := evm.GetSimulationOverrideCtx(ctx)
 	// TODO: either moving readcache into the upper layer or change the storage format
-	if d, ok := core.readCache.Get(key); ok {
-		res := iotexapi.ReadContractResponse{}
-		if err := proto.Unmarshal(d, &res); err == nil {
-			return res.Data, res.Receipt, nil
+	if !hasOverride {
+		if d, ok := core.readCache.Get(key); ok {
+			res := iotexapi.ReadContractResponse{}
+			if err := proto.Unmarshal(d, &res); err == nil {
+				return res.Data, res.Receipt, nil
+			}
 		}
 	}
 	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
@@ -575,8 +621,10 @@ func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Ad
 		Data:    hex.EncodeToString(retval),
 		Receipt: receipt.ConvertToReceiptPb(),
 	}
-	if d, err := proto.Marshal(&res); err == nil {
-		core.readCache.Put(key, d)
+	if !hasOverride {
+		if d, err := proto.Marshal(&res); err == nil {
+			core.readCache.Put(key, d)
+		}
 	}
 	return res.Data, res.Receipt, nil
 }
@@ -893,11 +941,17 @@ func (core *coreService) Start(_ context.Context) error {
 			return errors.Wrap(err, "failed to start message batcher")
 		}
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	core.stopStreaming = cancel
+	go core.streamPendingActions(ctx)
 	return nil
 }
 
 // Stop stops the API server
 func (core *coreService) Stop(_ context.Context) error {
+	if core.stopStreaming != nil {
+		core.stopStreaming()
+	}
 	if core.messageBatcher != nil {
 		if err := core.messageBatcher.Stop(); err != nil {
 			return errors.Wrap(err, "failed to stop message batcher")
@@ -1096,6 +1150,35 @@ func (core *coreService) BlockHashByBlockHeight(blkHeight uint64) (hash.Hash256,
 	return core.dao.GetBlockHash(blkHeight)
 }
 
+// StateDiff returns the states changed by the block at the height
+func (core *coreService) StateDiff(height uint64) ([]*factory.StateChange, error) {
+	if core.stateDiffIndexer == nil {
+		return nil, status.Error(codes.Unavailable, "state diff indexer is not enabled")
+	}
+	changes, err := core.stateDiffIndexer.StateDiff(height)
+	if err != nil {
+		if errors.Cause(err) == blockindex.ErrStateDiffNotExist {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
+		return nil, err
+	}
+	return changes, nil
+}
+
+// StakingHistory returns the lifecycle events of the staking buckets owned by the address
+func (core *coreService) StakingHistory(owner address.Address, offset, limit uint64) ([]*blockindex.StakingEvent, uint64, error) {
+	if core.stakingHistory == nil {
+		return nil, 0, status.Error(codes.Unavailable, "staking history indexer is not enabled")
+	}
+	if limit == 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "limit must be greater than zero")
+	}
+	if limit > core.cfg.RangeQueryLimit {
+		return nil, 0, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	return core.stakingHistory.StakingHistory(owner, offset, limit)
+}
+
 // ActionByActionHash returns action by action hash
 func (core *coreService) ActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, *block.Block, uint32, error) {
 	if err := core.checkActionIndex(); err != nil {
@@ -1556,27 +1639,90 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, sc
 		return 0, status.Error(codes.Internal, err.Error())
 	}
 	if !enough {
-		low, high := estimatedGas, blockGasLimit
-		estimatedGas = high
-		for low <= high {
-			mid := (low + high) / 2
-			sc.SetGasLimit(mid)
+		// the gas consumed is net of the refund, which is at most half of the gas used, and a call
+		// only forwards 63/64 of the remaining gas, so an optimistic limit is tried before searching
+		low, high := estimatedGas+1, blockGasLimit
+		if optimistic := (2*estimatedGas + params.CallStipend) * 64 / 63; optimistic < high {
+			sc.SetGasLimit(optimistic)
 			enough, _, err = core.isGasLimitEnough(ctx, callerAddr, sc)
 			if err != nil && err != action.ErrInsufficientFunds {
 				return 0, status.Error(codes.Internal, err.Error())
 			}
 			if enough {
-				estimatedGas = mid
-				high = mid - 1
+				high = optimistic
 			} else {
-				low = mid + 1
+				low = optimistic + 1
 			}
 		}
+		if estimatedGas, err = core.searchGasLimit(ctx, callerAddr, sc, low, high); err != nil {
+			return 0, status.Error(codes.Internal, err.Error())
+		}
 	}
 
 	return estimatedGas, nil
 }
 
+// searchGasLimit binary searches the lowest gas limit in [low, high] with which the execution
+// succeeds, the execution is supposed to succeed with the gas limit high
+func (core *coreService) searchGasLimit(ctx context.Context, callerAddr address.Address, sc *action.Execution, low, high uint64) (uint64, error) {
+	estimatedGas := high
+	for low < high {
+		mid := low + (high-low)/2
+		sc.SetGasLimit(mid)
+		enough, _, err := core.isGasLimitEnough(ctx, callerAddr, sc)
+		if err != nil && err != action.ErrInsufficientFunds {
+			return 0, err
+		}
+		if enough {
+			estimatedGas = mid
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return estimatedGas, nil
+}
+
+// CreateAccessList simulates the execution with an access list tracer until the access list
+// is stable, it returns the access list and the receipt of the execution with the list applied
+func (core *coreService) CreateAccessList(ctx context.Context, callerAddr address.Address, exec *action.Execution) (types.AccessList, *action.Receipt, error) {
+	var (
+		from = common.BytesToAddress(callerAddr.Bytes())
+		to   common.Address
+	)
+	if exec.To() != nil {
+		to = *exec.To()
+	}
+	prevTracer := logger.NewAccessListTracer(exec.AccessList(), from, to, vm.PrecompiledAddressesBerlin)
+	for {
+		list := prevTracer.AccessList()
+		tracer := logger.NewAccessListTracer(list, from, to, vm.PrecompiledAddressesBerlin)
+		sc, err := action.NewExecutionWithAccessList(exec.Contract(), exec.Nonce(), exec.Amount(), exec.GasLimit(), exec.GasPrice(), exec.Data(), list)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, receipt, err := core.SimulateExecution(protocol.WithVMConfigCtx(ctx, vm.Config{
+			Tracer:    tracer,
+			NoBaseFee: true,
+		}), callerAddr, sc)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tracer.Equal(prevTracer) {
+			return list, receipt, nil
+		}
+		if exec.To() == nil && receipt.ContractAddress != "" {
+			// the created contract is warm as the recipient
+			contract, err := address.FromString(receipt.ContractAddress)
+			if err != nil {
+				return nil, nil, err
+			}
+			to = common.BytesToAddress(contract.Bytes())
+		}
+		prevTracer = tracer
+	}
+}
+
 func (core *coreService) isGasLimitEnough(
 	ctx context.Context,
 	caller address.Address,
@@ -1727,6 +1873,36 @@ func (core *coreService) ReceiveBlock(blk *block.Block) error {
 	return core.chainListener.ReceiveBlock(blk)
 }
 
+func (core *coreService) ReceivePendingAction(selp *action.SealedEnvelope) {
+	actHash, err := selp.Hash()
+	if err != nil {
+		return
+	}
+	core.pendingActionLog.add(actHash)
+	select {
+	case core.pendingActions <- selp:
+	default:
+		log.L().Debug("Drop the pending action from streaming", zap.String("actHash", hex.EncodeToString(actHash[:])))
+	}
+}
+
+func (core *coreService) PendingActionHashes(since uint64) ([]hash.Hash256, uint64) {
+	return core.pendingActionLog.since(since)
+}
+
+func (core *coreService) streamPendingActions(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case selp := <-core.pendingActions:
+			if err := core.chainListener.ReceiveAction(selp); err != nil {
+				log.L().Error("Failed to stream the pending action", zap.Error(err))
+			}
+		}
+	}
+}
+
 func (core *coreService) SimulateExecution(ctx context.Context, addr address.Address, exec *action.Execution) ([]byte, *action.Receipt, error) {
 	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
 	state, err := accountutil.AccountState(ctx, core.sf, addr)
@@ -1756,6 +1932,65 @@ func (core *coreService) SimulateExecution(ctx context.Context, addr address.Add
 	return core.simulateExecution(ctx, addr, exec, core.dao.GetBlockHash, core.getBlockTime)
 }
 
+// SimulateExecutions simulates the calls in order on one working set of the tip state
+func (core *coreService) SimulateExecutions(ctx context.Context, calls []*apitypes.SimulationCall, config *tracers.TraceConfig) ([]*apitypes.SimulationResult, error) {
+	var (
+		g             = core.bc.Genesis()
+		blockGasLimit = g.BlockGasLimitByHeight(core.bc.TipHeight())
+		err           error
+	)
+	ctx = genesis.WithGenesisContext(ctx, g)
+	if ctx, err = core.bc.Context(ctx); err != nil {
+		return nil, err
+	}
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGasWithSGD,
+		Sgd:            core.sgdIndexer,
+	})
+	simulator, err := core.sf.NewSimulator(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	results := make([]*apitypes.SimulationResult, 0, len(calls))
+	for i, call := range calls {
+		if call.Exec.GasLimit() == 0 || blockGasLimit < call.Exec.GasLimit() {
+			call.Exec.SetGasLimit(blockGasLimit)
+		}
+		// the simulation is read-only, use 0 to prevent insufficient gas
+		call.Exec.SetGasPrice(big.NewInt(0))
+		callCtx := ctx
+		if call.Override != nil {
+			callCtx = evm.WithSimulationOverrideCtx(callCtx, *call.Override)
+		}
+		var (
+			tracer vm.EVMLogger
+			cancel context.CancelFunc = func() {}
+		)
+		if config != nil {
+			if tracer, cancel, err = newTracer(ctx, new(tracers.Context), config); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			callCtx = protocol.WithVMConfigCtx(callCtx, vm.Config{
+				Tracer:    tracer,
+				NoBaseFee: true,
+			})
+		}
+		retval, receipt, err := simulator.Simulate(callCtx, call.Caller, call.Exec)
+		cancel()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrapf(err, "failed to simulate call %d", i).Error())
+		}
+		results = append(results, &apitypes.SimulationResult{
+			ReturnValue: retval,
+			Receipt:     receipt,
+			Tracer:      tracer,
+		})
+	}
+	return results, nil
+}
+
 // SyncingProgress returns the syncing status of node
 func (core *coreService) SyncingProgress() (uint64, uint64, uint64) {
 	startingHeight, currentHeight, targetHeight, _ := core.bs.SyncStatus()
@@ -1852,47 +2087,51 @@ func (core *coreService) Track(ctx context.Context, start time.Time, method stri
 }
 
 func (core *coreService) traceTx(ctx context.Context, txctx *tracers.Context, config *tracers.TraceConfig, simulateFn func(ctx context.Context) ([]byte, *action.Receipt, error)) ([]byte, *action.Receipt, any, error) {
-	var (
-		tracer vm.EVMLogger
-		err    error
-	)
+	tracer, cancel, err := newTracer(ctx, txctx, config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer cancel()
+	ctx = protocol.WithVMConfigCtx(ctx, vm.Config{
+		Tracer:    tracer,
+		NoBaseFee: true,
+	})
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{})
+	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
+	ctx = protocol.WithBlockchainCtx(protocol.WithFeatureCtx(ctx), protocol.BlockchainCtx{})
+	retval, receipt, err := simulateFn(ctx)
+	return retval, receipt, tracer, err
+}
+
+// newTracer creates the tracer of the trace config, the returned cancel func stops the timer of the tracer
+func newTracer(ctx context.Context, txctx *tracers.Context, config *tracers.TraceConfig) (vm.EVMLogger, context.CancelFunc, error) {
 	switch {
 	case config == nil:
-		tracer = logger.NewStructLogger(nil)
+		return logger.NewStructLogger(nil), func() {}, nil
 	case config.Tracer != nil:
 		// Define a meaningful timeout of a single transaction trace
 		timeout := defaultTraceTimeout
 		if config.Timeout != nil {
+			var err error
 			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-				return nil, nil, nil, err
+				return nil, nil, err
 			}
 		}
 		t, err := tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
 		if err != nil {
-			return nil, nil, nil, err
+			return nil, nil, err
 		}
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
 		go func() {
 			<-deadlineCtx.Done()
 			if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
 				t.Stop(errors.New("execution timeout"))
 			}
 		}()
-		tracer = t
-
+		return t, cancel, nil
 	default:
-		tracer = logger.NewStructLogger(config.Config)
+		return logger.NewStructLogger(config.Config), func() {}, nil
 	}
-	ctx = protocol.WithVMConfigCtx(ctx, vm.Config{
-		Tracer:    tracer,
-		NoBaseFee: true,
-	})
-	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{})
-	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
-	ctx = protocol.WithBlockchainCtx(protocol.WithFeatureCtx(ctx), protocol.BlockchainCtx{})
-	retval, receipt, err := simulateFn(ctx)
-	return retval, receipt, tracer, err
 }
 
 func (core *coreService) simulateExecution(ctx context.Context, addr address.Address, exec *action.Execution, getBlockHash evm.GetBlockHash, getBlockTime evm.GetBlockTime) ([]byte, *action.Receipt, error) {
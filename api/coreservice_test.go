@@ -14,6 +14,7 @@ import (
 	"time"
 
 	. "github.com/agiledragon/gomonkey/v2"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
@@ -30,6 +31,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/api/logfilter"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
@@ -594,6 +596,42 @@ func TestEstimateExecutionGasConsumption(t *testing.T) {
 		})
 	})
 
+	t.Run("SearchLowestGasLimit", func(t *testing.T) {
+		p := NewPatches()
+		defer p.Reset()
+
+		p = p.ApplyFuncReturn(genesis.WithGenesisContext, ctx)
+		p = p.ApplyFuncReturn(accountutil.AccountState, &state.Account{}, nil)
+		p = p.ApplyFuncReturn(protocol.WithFeatureCtx, ctx)
+		p = p.ApplyFuncReturn(protocol.WithBlockCtx, ctx)
+		p = p.ApplyFuncReturn(protocol.MustGetFeatureCtx, protocol.FeatureCtx{})
+		p = p.ApplyMethodReturn(&state.Account{}, "PendingNonce", uint64(0))
+		simulations := 0
+		p = p.ApplyPrivateMethod(
+			cs,
+			"isGasLimitEnough",
+			func(
+				_ *coreService,
+				ctx context.Context,
+				caller address.Address,
+				sc *action.Execution,
+			) (bool, *action.Receipt, error) {
+				// the gas consumed is net of the refund, and the execution needs more gas to succeed
+				simulations++
+				return sc.GasLimit() >= 30001, &action.Receipt{GasConsumed: 21000}, nil
+			},
+		)
+
+		bc.EXPECT().Genesis().Return(genesis.Genesis{Blockchain: genesis.Blockchain{BlockGasLimit: 50000000, TsunamiBlockGasLimit: 50000000}}).Times(2)
+		bc.EXPECT().TipHeight().Return(uint64(0)).Times(2)
+
+		estimatedGas, err := cs.EstimateExecutionGasConsumption(ctx, &action.Execution{}, &address.AddrV1{})
+		require.NoError(err)
+		require.Equal(uint64(30001), estimatedGas)
+		// the optimistic limit narrows the search
+		require.Less(simulations, 20)
+	})
+
 	t.Run("EstimateExecutionGasConsumptionSuccess", func(t *testing.T) {
 		svr, _, _, _, cleanCallback := setupTestCoreService()
 		defer cleanCallback()
@@ -689,6 +727,145 @@ func TestTraceCall(t *testing.T) {
 	require.Equal(0, len(traces.(*logger.StructLogger).StructLogs()))
 }
 
+func TestCreateAccessList(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, ap, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	ctx := context.Background()
+
+	// the runtime code reads the slot 0 and the balance of identityset.Address(30)
+	other := common.BytesToAddress(identityset.Address(30).Bytes())
+	runtime := append(append([]byte{0x60, 0x00, 0x54, 0x50, 0x73}, other.Bytes()...), 0x31, 0x50, 0x00)
+	initCode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+	deploy, err := action.SignedExecution(action.EmptyAddress, identityset.PrivateKey(29), 1, big.NewInt(0), testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPriceInt64), initCode)
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, deploy))
+	blk, err := bc.MintNewBlock(testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+	deployHash, err := deploy.Hash()
+	require.NoError(err)
+	receipt, err := svr.ReceiptByActionHash(deployHash)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), receipt.Status)
+	contract, err := address.FromString(receipt.ContractAddress)
+	require.NoError(err)
+
+	exec, err := action.NewExecution(contract.String(), 0, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	list, receipt, err := svr.CreateAccessList(ctx, identityset.Address(29), exec)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), receipt.Status)
+	require.Len(list, 2)
+	for _, tuple := range list {
+		switch tuple.Address {
+		case common.BytesToAddress(contract.Bytes()):
+			require.Equal([]common.Hash{{}}, tuple.StorageKeys)
+		case other:
+			require.Empty(tuple.StorageKeys)
+		default:
+			require.Fail("unexpected address in access list", tuple.Address.Hex())
+		}
+	}
+}
+
+func TestReadContractWithOverride(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	// the runtime code returns the slot 0, the balance of identityset.Address(30), the block number,
+	// the block time and the coinbase
+	other := common.BytesToAddress(identityset.Address(30).Bytes())
+	runtime := append([]byte{0x60, 0x00, 0x54, 0x60, 0x00, 0x52, 0x73}, other.Bytes()...)
+	runtime = append(runtime, 0x31, 0x60, 0x20, 0x52, 0x43, 0x60, 0x40, 0x52, 0x42, 0x60, 0x60, 0x52, 0x41, 0x60, 0x80, 0x52,
+		0x60, 0xa0, 0x60, 0x00, 0xf3)
+	contract := common.HexToAddress("0x000000000000000000000000000000000000abcd")
+	ioContract, err := address.FromBytes(contract.Bytes())
+	require.NoError(err)
+	read := func(ctx context.Context) string {
+		exec, err := action.NewExecution(ioContract.String(), 0, big.NewInt(0), 0, big.NewInt(0), nil)
+		require.NoError(err)
+		data, _, err := svr.ReadContract(ctx, identityset.Address(29), exec)
+		require.NoError(err)
+		return data
+	}
+	require.Empty(read(context.Background()))
+
+	var (
+		balance  = big.NewInt(1234)
+		number   = uint64(100)
+		ts       = uint64(1700000000)
+		coinbase = common.HexToAddress("0x00000000000000000000000000000000000000ff")
+		slot     = common.HexToHash("0x01")
+	)
+	ctx := evm.WithSimulationOverrideCtx(context.Background(), evm.SimulationOverride{
+		State: evm.StateOverride{
+			contract: {Code: runtime, StateDiff: map[common.Hash]common.Hash{{}: slot}},
+			other:    {Balance: balance},
+		},
+		Block: &evm.BlockOverride{Number: &number, Time: &ts, Coinbase: &coinbase},
+	})
+	expected := append(append(append(append(slot.Bytes(), common.BigToHash(balance).Bytes()...),
+		common.BigToHash(new(big.Int).SetUint64(number)).Bytes()...),
+		common.BigToHash(new(big.Int).SetUint64(ts)).Bytes()...),
+		common.BytesToHash(coinbase.Bytes()).Bytes()...)
+	require.Equal(hex.EncodeToString(expected), read(ctx))
+	// the result with overrides is not cached
+	require.Empty(read(context.Background()))
+
+	exec, err := action.NewExecution(ioContract.String(), 0, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	_, _, err = svr.ReadContract(evm.WithSimulationOverrideCtx(context.Background(), evm.SimulationOverride{
+		State: evm.StateOverride{other: {Balance: big.NewInt(-1)}},
+	}), identityset.Address(29), exec)
+	require.ErrorContains(err, "negative balance override")
+}
+
+func TestSimulateExecutions(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	// the runtime code increments the slot 0 and returns the new value
+	runtime := []byte{0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x80, 0x60, 0x00, 0x55, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+	contract := common.HexToAddress("0x000000000000000000000000000000000000abcd")
+	ioContract, err := address.FromBytes(contract.Bytes())
+	require.NoError(err)
+	newCall := func(override *evm.SimulationOverride) *apitypes.SimulationCall {
+		exec, err := action.NewExecution(ioContract.String(), 0, big.NewInt(0), 0, big.NewInt(0), nil)
+		require.NoError(err)
+		return &apitypes.SimulationCall{Caller: identityset.Address(29), Exec: exec, Override: override}
+	}
+	calls := []*apitypes.SimulationCall{
+		newCall(&evm.SimulationOverride{State: evm.StateOverride{contract: {Code: runtime}}}),
+		newCall(nil),
+		newCall(nil),
+	}
+	results, err := svr.SimulateExecutions(context.Background(), calls, nil)
+	require.NoError(err)
+	require.Len(results, 3)
+	for i, result := range results {
+		require.Equal(uint64(iotextypes.ReceiptStatus_Success), result.Receipt.Status)
+		require.Equal(common.BigToHash(big.NewInt(int64(i+1))).Bytes(), result.ReturnValue)
+		require.Nil(result.Tracer)
+	}
+	// the nonces of the calls of the same caller increase
+	require.Equal(calls[0].Exec.Nonce()+1, calls[1].Exec.Nonce())
+	require.Equal(calls[1].Exec.Nonce()+1, calls[2].Exec.Nonce())
+
+	// the state changes of the simulation are discarded, and the calls are traced with a trace config
+	calls = []*apitypes.SimulationCall{newCall(&evm.SimulationOverride{State: evm.StateOverride{contract: {Code: runtime}}})}
+	results, err = svr.SimulateExecutions(context.Background(), calls, &tracers.TraceConfig{})
+	require.NoError(err)
+	require.Len(results, 1)
+	require.Equal(common.BigToHash(big.NewInt(1)).Bytes(), results[0].ReturnValue)
+	tracer, ok := results[0].Tracer.(*logger.StructLogger)
+	require.True(ok)
+	require.NotEmpty(tracer.StructLogs())
+}
+
 func TestProofAndCompareReverseActions(t *testing.T) {
 	sliceN := func(n uint64) (value []uint64) {
 		value = make([]uint64, 0, n)
@@ -999,6 +1176,45 @@ func TestCheckActionIndex(t *testing.T) {
 	})
 }
 
+func TestReceivePendingAction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listener := mock_apitypes.NewMockListener(ctrl)
+	cs := &coreService{
+		chainListener:    listener,
+		pendingActions:   make(chan *action.SealedEnvelope, 1),
+		pendingActionLog: newPendingActionLog(_pendingActionLogSize),
+	}
+	listener.EXPECT().Start().Return(nil).Times(1)
+	listener.EXPECT().Stop().Return(nil).Times(1)
+	require.NoError(cs.Start(context.Background()))
+	defer func() {
+		require.NoError(cs.Stop(context.Background()))
+	}()
+
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	actHash, err := selp.Hash()
+	require.NoError(err)
+	received := make(chan *action.SealedEnvelope, 1)
+	listener.EXPECT().ReceiveAction(gomock.Any()).DoAndReturn(func(selp *action.SealedEnvelope) error {
+		received <- selp
+		return nil
+	}).Times(1)
+	cs.ReceivePendingAction(selp)
+	select {
+	case act := <-received:
+		require.Equal(selp, act)
+	case <-time.After(time.Second):
+		require.Fail("the pending action is not streamed")
+	}
+	hashes, next := cs.PendingActionHashes(0)
+	require.Equal([]hash.Hash256{actHash}, hashes)
+	require.EqualValues(1, next)
+}
+
 func TestReceiveBlock(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,798 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+const (
+	// _graphQLMaxDepth is the maximum depth of nested fields in a query
+	_graphQLMaxDepth = 10
+	// _graphQLMethod is the method name of graphql queries in rate limiting
+	_graphQLMethod = "graphql"
+)
+
+type (
+	// graphQLHandler serves the EIP-1767 graphql queries
+	graphQLHandler struct {
+		handler *relay.Handler
+		limiter *RateLimiter
+	}
+
+	graphQLResolver struct {
+		web3 *web3Handler
+	}
+
+	graphQLBlock struct {
+		r        *graphQLResolver
+		blk      *block.Block
+		receipts []*action.Receipt
+	}
+
+	graphQLTransaction struct {
+		r  *graphQLResolver
+		tx *getTransactionResult
+	}
+
+	graphQLAccount struct {
+		r    *graphQLResolver
+		addr address.Address
+	}
+
+	graphQLLog struct {
+		r         *graphQLResolver
+		log       *action.Log
+		blockHash hash.Hash256
+	}
+
+	graphQLCallResult struct {
+		data    hexutil.Bytes
+		gasUsed hexutil.Uint64
+		status  hexutil.Uint64
+	}
+
+	graphQLSyncState struct {
+		start, curr, highest uint64
+	}
+
+	graphQLCallData struct {
+		From     *common.Address
+		To       *common.Address
+		Gas      *hexutil.Uint64
+		GasPrice *hexutil.Big
+		Value    *hexutil.Big
+		Data     *hexutil.Bytes
+	}
+
+	graphQLFilterCriteria struct {
+		FromBlock *hexutil.Uint64
+		ToBlock   *hexutil.Uint64
+		Addresses *[]common.Address
+		Topics    *[][]common.Hash
+	}
+
+	graphQLBlockFilterCriteria struct {
+		Addresses *[]common.Address
+		Topics    *[][]common.Hash
+	}
+
+	graphQLBlockArgs struct {
+		Block *hexutil.Uint64
+	}
+)
+
+// NewGraphQLHandler creates a new graphql handler backed by the core service
+func NewGraphQLHandler(core CoreService, limiter *RateLimiter) (http.Handler, error) {
+	schema, err := graphql.ParseSchema(
+		_graphQLSchema,
+		&graphQLResolver{web3: &web3Handler{coreService: core}},
+		graphql.MaxDepth(_graphQLMaxDepth),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse graphql schema")
+	}
+	return &graphQLHandler{
+		handler: &relay.Handler{Schema: schema},
+		limiter: limiter,
+	}, nil
+}
+
+func (h *graphQLHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		w.Write([]byte("IoTeX GraphQL endpoint is ready."))
+		return
+	}
+	ctx := withHTTPClientInfo(req)
+	if err := h.limiter.Allow(ctx, _graphQLMethod); err != nil {
+		code := http.StatusTooManyRequests
+		if status.Code(err) == codes.Unauthenticated {
+			code = http.StatusUnauthorized
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": []map[string]string{{"message": status.Convert(err).Message()}},
+		})
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	h.handler.ServeHTTP(w, req.WithContext(ctx))
+}
+
+// Block returns the block by number or hash, or the tip block
+func (r *graphQLResolver) Block(args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*graphQLBlock, error) {
+	var (
+		blk *apitypes.BlockWithReceipts
+		err error
+	)
+	switch {
+	case args.Hash != nil:
+		blk, err = r.web3.coreService.BlockByHash(hex.EncodeToString(args.Hash[:]))
+	case args.Number != nil:
+		blk, err = r.web3.coreService.BlockByHeight(uint64(*args.Number))
+	default:
+		blk, err = r.web3.coreService.BlockByHeight(r.web3.coreService.TipHeight())
+	}
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.newBlock(blk.Block, blk.Receipts), nil
+}
+
+// Blocks returns the blocks in the range
+func (r *graphQLResolver) Blocks(args struct {
+	From hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*graphQLBlock, error) {
+	from, to := uint64(args.From), r.web3.coreService.TipHeight()
+	if args.To != nil && uint64(*args.To) < to {
+		to = uint64(*args.To)
+	}
+	if from > to {
+		return []*graphQLBlock{}, nil
+	}
+	blks, err := r.web3.coreService.BlockByHeightRange(from, to-from+1)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*graphQLBlock, 0, len(blks))
+	for _, blk := range blks {
+		ret = append(ret, r.newBlock(blk.Block, blk.Receipts))
+	}
+	return ret, nil
+}
+
+// Transaction returns the transaction by hash, in block or pending
+func (r *graphQLResolver) Transaction(args struct{ Hash common.Hash }) (*graphQLTransaction, error) {
+	tx, err := r.transaction(hash.BytesToHash256(args.Hash[:]))
+	if errors.Cause(err) == ErrNotFound {
+		return nil, nil
+	}
+	return tx, err
+}
+
+// Logs returns the logs matching the filter
+func (r *graphQLResolver) Logs(args struct{ Filter graphQLFilterCriteria }) ([]*graphQLLog, error) {
+	from, to := r.web3.coreService.TipHeight(), r.web3.coreService.TipHeight()
+	if args.Filter.FromBlock != nil {
+		from = uint64(*args.Filter.FromBlock)
+	}
+	if args.Filter.ToBlock != nil {
+		to = uint64(*args.Filter.ToBlock)
+	}
+	addrs, topics := graphQLFilterParams(args.Filter.Addresses, args.Filter.Topics)
+	logs, err := r.web3.getLogsWithFilter(from, to, addrs, topics)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*graphQLLog, 0, len(logs))
+	for _, l := range logs {
+		ret = append(ret, &graphQLLog{r: r, log: l.log, blockHash: l.blockHash})
+	}
+	return ret, nil
+}
+
+// GasPrice returns the suggested gas price
+func (r *graphQLResolver) GasPrice() (hexutil.Big, error) {
+	price, err := r.web3.coreService.SuggestGasPrice()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(price)), nil
+}
+
+// Syncing returns the sync state, nil if the node is synced
+func (r *graphQLResolver) Syncing() *graphQLSyncState {
+	start, curr, highest := r.web3.coreService.SyncingProgress()
+	if curr >= highest {
+		return nil
+	}
+	return &graphQLSyncState{start: start, curr: curr, highest: highest}
+}
+
+// ChainID returns the EVM network id
+func (r *graphQLResolver) ChainID() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(uint64(r.web3.coreService.EVMNetworkID())))
+}
+
+// SendRawTransaction sends the raw transaction
+func (r *graphQLResolver) SendRawTransaction(args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	actHash, err := r.web3.sendRawTx(hex.EncodeToString(args.Data))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(actHash), nil
+}
+
+func (r *graphQLResolver) newBlock(blk *block.Block, receipts []*action.Receipt) *graphQLBlock {
+	return &graphQLBlock{r: r, blk: blk, receipts: receipts}
+}
+
+func (r *graphQLResolver) blockByHeight(height uint64) (*graphQLBlock, error) {
+	blk, err := r.web3.coreService.BlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return r.newBlock(blk.Block, blk.Receipts), nil
+}
+
+func (r *graphQLResolver) transaction(actHash hash.Hash256) (*graphQLTransaction, error) {
+	selp, blk, _, err := r.web3.coreService.ActionByActionHash(actHash)
+	if err == nil {
+		receipt, err := r.web3.coreService.ReceiptByActionHash(actHash)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := r.web3.assembleConfirmedTransaction(blk.HashBlock(), selp, receipt)
+		if err != nil {
+			return nil, err
+		}
+		return &graphQLTransaction{r: r, tx: tx}, nil
+	}
+	if errors.Cause(err) != ErrNotFound {
+		return nil, err
+	}
+	selp, err = r.web3.coreService.PendingActionByActionHash(actHash)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := r.web3.assemblePendingTransaction(selp)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLTransaction{r: r, tx: tx}, nil
+}
+
+func (r *graphQLResolver) call(data graphQLCallData) (*graphQLCallResult, error) {
+	from, to, gasLimit, gasPrice, value, input, err := graphQLCallParams(data)
+	if err != nil {
+		return nil, err
+	}
+	ret, receipt, err := r.web3.callContract(context.Background(), from, to, gasLimit, gasPrice, value, input)
+	if err != nil {
+		return nil, err
+	}
+	retData, err := hex.DecodeString(ret)
+	if err != nil {
+		return nil, err
+	}
+	res := &graphQLCallResult{data: retData, status: 1}
+	if receipt != nil {
+		res.gasUsed = hexutil.Uint64(receipt.GasConsumed)
+		res.status = graphQLReceiptStatus(receipt.Status)
+	}
+	return res, nil
+}
+
+func (r *graphQLResolver) estimateGas(data graphQLCallData) (hexutil.Uint64, error) {
+	from, to, gasLimit, gasPrice, value, input, err := graphQLCallParams(data)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := r.web3.estimateCallGas(from, to, gasLimit, gasPrice, value, input)
+	return hexutil.Uint64(gas), err
+}
+
+// Number returns the block height
+func (b *graphQLBlock) Number() hexutil.Uint64 {
+	return hexutil.Uint64(b.blk.Height())
+}
+
+// Hash returns the block hash
+func (b *graphQLBlock) Hash() common.Hash {
+	if b.blk.Height() == 0 {
+		return common.Hash(block.GenesisHash())
+	}
+	return common.Hash(b.blk.HashBlock())
+}
+
+// Parent returns the previous block
+func (b *graphQLBlock) Parent() (*graphQLBlock, error) {
+	if b.blk.Height() == 0 {
+		return nil, nil
+	}
+	blk, err := b.r.blockByHeight(b.blk.Height() - 1)
+	if errors.Cause(err) == ErrNotFound {
+		return nil, nil
+	}
+	return blk, err
+}
+
+// TransactionsRoot returns the root of actions
+func (b *graphQLBlock) TransactionsRoot() common.Hash {
+	return common.Hash(b.blk.TxRoot())
+}
+
+// TransactionCount returns the number of actions
+func (b *graphQLBlock) TransactionCount() *hexutil.Uint64 {
+	count := hexutil.Uint64(len(b.blk.Actions))
+	return &count
+}
+
+// StateRoot returns the delta state digest
+func (b *graphQLBlock) StateRoot() common.Hash {
+	return common.Hash(b.blk.DeltaStateDigest())
+}
+
+// ReceiptsRoot returns the root of receipts
+func (b *graphQLBlock) ReceiptsRoot() common.Hash {
+	return common.Hash(b.blk.ReceiptRoot())
+}
+
+// Miner returns the block producer
+func (b *graphQLBlock) Miner(graphQLBlockArgs) (*graphQLAccount, error) {
+	producer := address.ZeroAddress
+	if b.blk.Height() > 0 {
+		producer = b.blk.ProducerAddress()
+	}
+	addr, err := address.FromString(producer)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLAccount{r: b.r, addr: addr}, nil
+}
+
+// ExtraData returns empty data
+func (b *graphQLBlock) ExtraData() hexutil.Bytes {
+	return hexutil.Bytes{}
+}
+
+// GasLimit returns the sum of gas limit of actions
+func (b *graphQLBlock) GasLimit() hexutil.Uint64 {
+	var gasLimit uint64
+	for _, selp := range b.blk.Actions {
+		gasLimit += selp.GasLimit()
+	}
+	return hexutil.Uint64(gasLimit)
+}
+
+// GasUsed returns the sum of gas consumed by actions
+func (b *graphQLBlock) GasUsed() hexutil.Uint64 {
+	var gasUsed uint64
+	for _, r := range b.receipts {
+		gasUsed += r.GasConsumed
+	}
+	return hexutil.Uint64(gasUsed)
+}
+
+// Timestamp returns the block timestamp in seconds
+func (b *graphQLBlock) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(b.blk.Timestamp().Unix())
+}
+
+// LogsBloom returns the bloom filter of logs
+func (b *graphQLBlock) LogsBloom() hexutil.Bytes {
+	if bf := b.blk.LogsBloomfilter(); bf != nil {
+		return bf.Bytes()
+	}
+	return make([]byte, 256)
+}
+
+// Transactions returns the ethereum compatible actions in the block
+func (b *graphQLBlock) Transactions() (*[]*graphQLTransaction, error) {
+	txs := make([]*graphQLTransaction, 0, len(b.blk.Actions))
+	for i := range b.blk.Actions {
+		tx, err := b.transactionAt(i)
+		if err != nil {
+			if errors.Cause(err) == errUnsupportedAction {
+				continue
+			}
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return &txs, nil
+}
+
+// TransactionAt returns the action at the index, nil if the index is out of bound or the action is not ethereum compatible
+func (b *graphQLBlock) TransactionAt(args struct{ Index hexutil.Uint64 }) (*graphQLTransaction, error) {
+	if uint64(args.Index) >= uint64(len(b.blk.Actions)) {
+		return nil, nil
+	}
+	tx, err := b.transactionAt(int(args.Index))
+	if errors.Cause(err) == errUnsupportedAction {
+		return nil, nil
+	}
+	return tx, err
+}
+
+// Logs returns the logs in the block matching the filter
+func (b *graphQLBlock) Logs(args struct{ Filter graphQLBlockFilterCriteria }) ([]*graphQLLog, error) {
+	addrs, topics := graphQLFilterParams(args.Filter.Addresses, args.Filter.Topics)
+	filter, err := newLogFilterFrom(addrs, topics)
+	if err != nil {
+		return nil, err
+	}
+	blkHash := hash.Hash256(b.Hash())
+	logs, err := b.r.web3.coreService.LogsInBlockByHash(filter, blkHash)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*graphQLLog, 0, len(logs))
+	for _, l := range logs {
+		ret = append(ret, &graphQLLog{r: b.r, log: l, blockHash: blkHash})
+	}
+	return ret, nil
+}
+
+// Account returns the account
+func (b *graphQLBlock) Account(args struct{ Address common.Address }) (*graphQLAccount, error) {
+	return newGraphQLAccount(b.r, args.Address)
+}
+
+// Call executes the call
+func (b *graphQLBlock) Call(args struct{ Data graphQLCallData }) (*graphQLCallResult, error) {
+	return b.r.call(args.Data)
+}
+
+// EstimateGas estimates the gas of the call
+func (b *graphQLBlock) EstimateGas(args struct{ Data graphQLCallData }) (hexutil.Uint64, error) {
+	return b.r.estimateGas(args.Data)
+}
+
+func (b *graphQLBlock) transactionAt(i int) (*graphQLTransaction, error) {
+	if i >= len(b.receipts) {
+		return nil, errors.Errorf("receipt of action %d is not found", i)
+	}
+	tx, err := b.r.web3.assembleConfirmedTransaction(b.blk.HashBlock(), b.blk.Actions[i], b.receipts[i])
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLTransaction{r: b.r, tx: tx}, nil
+}
+
+// Hash returns the action hash
+func (t *graphQLTransaction) Hash() common.Hash {
+	if t.tx.receipt != nil {
+		return common.Hash(t.tx.receipt.ActionHash)
+	}
+	return t.tx.ethTx.Hash()
+}
+
+// Nonce returns the nonce
+func (t *graphQLTransaction) Nonce() hexutil.Uint64 {
+	return hexutil.Uint64(t.tx.ethTx.Nonce())
+}
+
+// Index returns the index in block, nil if pending
+func (t *graphQLTransaction) Index() *hexutil.Uint64 {
+	if t.tx.receipt == nil {
+		return nil
+	}
+	index := hexutil.Uint64(t.tx.receipt.TxIndex)
+	return &index
+}
+
+// From returns the sender
+func (t *graphQLTransaction) From(graphQLBlockArgs) *graphQLAccount {
+	return &graphQLAccount{r: t.r, addr: t.tx.pubkey.Address()}
+}
+
+// To returns the recipient, nil if the transaction creates contract
+func (t *graphQLTransaction) To(graphQLBlockArgs) (*graphQLAccount, error) {
+	if t.tx.to == nil {
+		return nil, nil
+	}
+	return newGraphQLAccount(t.r, common.HexToAddress(*t.tx.to))
+}
+
+// Value returns the amount
+func (t *graphQLTransaction) Value() hexutil.Big {
+	return hexutil.Big(*t.tx.ethTx.Value())
+}
+
+// GasPrice returns the gas price
+func (t *graphQLTransaction) GasPrice() hexutil.Big {
+	return hexutil.Big(*t.tx.ethTx.GasPrice())
+}
+
+// Gas returns the gas limit
+func (t *graphQLTransaction) Gas() hexutil.Uint64 {
+	return hexutil.Uint64(t.tx.ethTx.Gas())
+}
+
+// InputData returns the data
+func (t *graphQLTransaction) InputData() hexutil.Bytes {
+	return t.tx.ethTx.Data()
+}
+
+// Block returns the block of the transaction, nil if pending
+func (t *graphQLTransaction) Block() (*graphQLBlock, error) {
+	if t.tx.receipt == nil {
+		return nil, nil
+	}
+	return t.r.blockByHeight(t.tx.receipt.BlockHeight)
+}
+
+// Status returns 1 for success or 0 for failure, nil if pending
+func (t *graphQLTransaction) Status() *hexutil.Uint64 {
+	if t.tx.receipt == nil {
+		return nil
+	}
+	s := graphQLReceiptStatus(t.tx.receipt.Status)
+	return &s
+}
+
+// GasUsed returns the gas consumed, nil if pending
+func (t *graphQLTransaction) GasUsed() *hexutil.Uint64 {
+	if t.tx.receipt == nil {
+		return nil
+	}
+	gas := hexutil.Uint64(t.tx.receipt.GasConsumed)
+	return &gas
+}
+
+// CumulativeGasUsed returns the gas consumed, nil if pending
+func (t *graphQLTransaction) CumulativeGasUsed() *hexutil.Uint64 {
+	return t.GasUsed()
+}
+
+// EffectiveGasPrice returns the gas price, nil if pending
+func (t *graphQLTransaction) EffectiveGasPrice() *hexutil.Big {
+	if t.tx.receipt == nil {
+		return nil
+	}
+	price := t.GasPrice()
+	return &price
+}
+
+// CreatedContract returns the contract created by the transaction
+func (t *graphQLTransaction) CreatedContract(graphQLBlockArgs) (*graphQLAccount, error) {
+	if t.tx.receipt == nil || t.tx.to != nil || t.tx.receipt.ContractAddress == "" {
+		return nil, nil
+	}
+	addr, err := address.FromString(t.tx.receipt.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLAccount{r: t.r, addr: addr}, nil
+}
+
+// Logs returns the logs emitted by the transaction, nil if pending
+func (t *graphQLTransaction) Logs() *[]*graphQLLog {
+	if t.tx.receipt == nil {
+		return nil
+	}
+	logs := make([]*graphQLLog, 0, len(t.tx.receipt.Logs()))
+	for _, l := range t.tx.receipt.Logs() {
+		logs = append(logs, &graphQLLog{r: t.r, log: l, blockHash: *t.tx.blockHash})
+	}
+	return &logs
+}
+
+// R returns the signature r
+func (t *graphQLTransaction) R() hexutil.Big {
+	_, r, _ := t.tx.ethTx.RawSignatureValues()
+	return hexutil.Big(*r)
+}
+
+// S returns the signature s
+func (t *graphQLTransaction) S() hexutil.Big {
+	_, _, s := t.tx.ethTx.RawSignatureValues()
+	return hexutil.Big(*s)
+}
+
+// V returns the signature v
+func (t *graphQLTransaction) V() hexutil.Big {
+	v, _, _ := t.tx.ethTx.RawSignatureValues()
+	return hexutil.Big(*v)
+}
+
+// Index returns the index of log in the block
+func (l *graphQLLog) Index() hexutil.Uint64 {
+	return hexutil.Uint64(l.log.Index)
+}
+
+// Account returns the contract emitting the log
+func (l *graphQLLog) Account(graphQLBlockArgs) (*graphQLAccount, error) {
+	addr, err := address.FromString(l.log.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLAccount{r: l.r, addr: addr}, nil
+}
+
+// Topics returns the topics
+func (l *graphQLLog) Topics() []common.Hash {
+	topics := make([]common.Hash, 0, len(l.log.Topics))
+	for _, t := range l.log.Topics {
+		topics = append(topics, common.Hash(t))
+	}
+	return topics
+}
+
+// Data returns the data
+func (l *graphQLLog) Data() hexutil.Bytes {
+	return l.log.Data
+}
+
+// Transaction returns the transaction emitting the log
+func (l *graphQLLog) Transaction() (*graphQLTransaction, error) {
+	return l.r.transaction(l.log.ActionHash)
+}
+
+// Address returns the address
+func (a *graphQLAccount) Address() common.Address {
+	return common.BytesToAddress(a.addr.Bytes())
+}
+
+// Balance returns the balance
+func (a *graphQLAccount) Balance() (hexutil.Big, error) {
+	meta, _, err := a.r.web3.coreService.Account(a.addr)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	balance, ok := new(big.Int).SetString(meta.Balance, 10)
+	if !ok {
+		return hexutil.Big{}, errors.Wrapf(errUnkownType, "balance: %s", meta.Balance)
+	}
+	return hexutil.Big(*balance), nil
+}
+
+// TransactionCount returns the pending nonce
+func (a *graphQLAccount) TransactionCount() (hexutil.Uint64, error) {
+	nonce, err := a.r.web3.coreService.PendingNonce(a.addr)
+	return hexutil.Uint64(nonce), err
+}
+
+// Code returns the contract byte code
+func (a *graphQLAccount) Code() (hexutil.Bytes, error) {
+	meta, _, err := a.r.web3.coreService.Account(a.addr)
+	if err != nil {
+		return nil, err
+	}
+	return meta.ContractByteCode, nil
+}
+
+// Storage returns the value of contract storage at the slot
+func (a *graphQLAccount) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	val, err := a.r.web3.coreService.ReadContractStorage(ctx, a.addr, args.Slot[:])
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(val), nil
+}
+
+// Data returns the return data
+func (c *graphQLCallResult) Data() hexutil.Bytes { return c.data }
+
+// GasUsed returns the gas consumed
+func (c *graphQLCallResult) GasUsed() hexutil.Uint64 { return c.gasUsed }
+
+// Status returns 1 for success or 0 for failure
+func (c *graphQLCallResult) Status() hexutil.Uint64 { return c.status }
+
+// StartingBlock returns the height sync started from
+func (s *graphQLSyncState) StartingBlock() hexutil.Uint64 { return hexutil.Uint64(s.start) }
+
+// CurrentBlock returns the current height
+func (s *graphQLSyncState) CurrentBlock() hexutil.Uint64 { return hexutil.Uint64(s.curr) }
+
+// HighestBlock returns the highest known height
+func (s *graphQLSyncState) HighestBlock() hexutil.Uint64 { return hexutil.Uint64(s.highest) }
+
+func newGraphQLAccount(r *graphQLResolver, ethAddr common.Address) (*graphQLAccount, error) {
+	addr, err := address.FromBytes(ethAddr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLAccount{r: r, addr: addr}, nil
+}
+
+func graphQLReceiptStatus(s uint64) hexutil.Uint64 {
+	if s == uint64(iotextypes.ReceiptStatus_Success) {
+		return 1
+	}
+	return 0
+}
+
+func graphQLFilterParams(addresses *[]common.Address, topics *[][]common.Hash) ([]string, [][]string) {
+	var (
+		addrs   []string
+		topicss [][]string
+	)
+	if addresses != nil {
+		for _, addr := range *addresses {
+			addrs = append(addrs, addr.Hex())
+		}
+	}
+	if topics != nil {
+		for _, tps := range *topics {
+			strs := make([]string, 0, len(tps))
+			for _, tp := range tps {
+				strs = append(strs, hex.EncodeToString(tp[:]))
+			}
+			topicss = append(topicss, strs)
+		}
+	}
+	return addrs, topicss
+}
+
+func graphQLCallParams(data graphQLCallData) (address.Address, string, uint64, *big.Int, *big.Int, []byte, error) {
+	var (
+		from     address.Address
+		to       string
+		gasLimit uint64
+		gasPrice = big.NewInt(0)
+		value    = big.NewInt(0)
+		input    []byte
+		err      error
+	)
+	if data.From != nil {
+		from, err = address.FromBytes(data.From.Bytes())
+	} else {
+		from, err = address.FromString(address.ZeroAddress)
+	}
+	if err != nil {
+		return nil, "", 0, nil, nil, nil, err
+	}
+	if data.To != nil {
+		addr, err := address.FromBytes(data.To.Bytes())
+		if err != nil {
+			return nil, "", 0, nil, nil, nil, err
+		}
+		to = addr.String()
+	}
+	if data.Gas != nil {
+		gasLimit = uint64(*data.Gas)
+	}
+	if data.GasPrice != nil {
+		gasPrice = data.GasPrice.ToInt()
+	}
+	if data.Value != nil {
+		value = data.Value.ToInt()
+	}
+	if data.Data != nil {
+		input = *data.Data
+	}
+	return from, to, gasLimit, gasPrice, value, input, nil
+}
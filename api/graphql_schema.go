@@ -0,0 +1,154 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+// _graphQLSchema is the subset of EIP-1767 schema supported by the node, the accounts, calls and
+// gas estimations are always on the state of the tip block
+const _graphQLSchema = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes
+    # BigInt is a large integer, output values are 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer, output values are 0x-prefixed hexadecimal.
+    scalar Long
+
+    schema {
+        query: Query
+        mutation: Mutation
+    }
+
+    # Account is an account at the tip block.
+    type Account {
+        address: Address!
+        balance: BigInt!
+        # TransactionCount is the pending nonce of the account.
+        transactionCount: Long!
+        code: Bytes!
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is an event log.
+    type Log {
+        # Index is the index of this log in the block.
+        index: Long!
+        account(block: Long): Account!
+        topics: [Bytes32!]!
+        data: Bytes!
+        transaction: Transaction!
+    }
+
+    # Transaction is an ethereum compatible transaction.
+    type Transaction {
+        hash: Bytes32!
+        nonce: Long!
+        # Index is null if the transaction has not yet been mined.
+        index: Long
+        from(block: Long): Account!
+        # To is null for contract-creating transactions.
+        to(block: Long): Account
+        value: BigInt!
+        gasPrice: BigInt!
+        gas: Long!
+        inputData: Bytes!
+        # Block is null if the transaction has not yet been mined.
+        block: Block
+        # Status is 1 if the transaction succeeded, or 0 if it failed, null if not yet mined.
+        status: Long
+        gasUsed: Long
+        cumulativeGasUsed: Long
+        effectiveGasPrice: BigInt
+        createdContract(block: Long): Account
+        logs: [Log!]
+        r: BigInt!
+        s: BigInt!
+        v: BigInt!
+    }
+
+    # BlockFilterCriteria is the log filter applied to a single block.
+    input BlockFilterCriteria {
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is a block of the chain.
+    type Block {
+        number: Long!
+        hash: Bytes32!
+        parent: Block
+        transactionsRoot: Bytes32!
+        transactionCount: Long
+        stateRoot: Bytes32!
+        receiptsRoot: Bytes32!
+        miner(block: Long): Account!
+        extraData: Bytes!
+        gasLimit: Long!
+        gasUsed: Long!
+        timestamp: Long!
+        logsBloom: Bytes!
+        # Transactions skips the actions which are not ethereum compatible.
+        transactions: [Transaction!]
+        transactionAt(index: Long!): Transaction
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        account(address: Address!): Account!
+        call(data: CallData!): CallResult
+        estimateGas(data: CallData!): Long!
+    }
+
+    # CallData represents the data associated with a local contract call.
+    input CallData {
+        from: Address
+        to: Address
+        gas: Long
+        gasPrice: BigInt
+        value: BigInt
+        data: Bytes
+    }
+
+    # CallResult is the result of a local call operation.
+    type CallResult {
+        data: Bytes!
+        gasUsed: Long!
+        # Status is 1 for success or 0 for failure.
+        status: Long!
+    }
+
+    # FilterCriteria is the log filter of a block range, the blocks default to the tip block.
+    input FilterCriteria {
+        fromBlock: Long
+        toBlock: Long
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    # SyncState contains the current synchronisation state of the node.
+    type SyncState {
+        startingBlock: Long!
+        currentBlock: Long!
+        highestBlock: Long!
+    }
+
+    type Query {
+        # Block fetches a block by number or by hash, the tip block is returned if neither is supplied.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns the blocks between two numbers inclusively, to defaults to the tip block.
+        blocks(from: Long!, to: Long): [Block!]!
+        transaction(hash: Bytes32!): Transaction
+        logs(filter: FilterCriteria!): [Log!]!
+        gasPrice: BigInt!
+        # Syncing is null if the node is synced.
+        syncing: SyncState
+        chainID: BigInt!
+    }
+
+    type Mutation {
+        # SendRawTransaction sends an RLP-encoded transaction to the network.
+        sendRawTransaction(data: Bytes!): Bytes32!
+    }
+`
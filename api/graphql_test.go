@@ -0,0 +1,244 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/action"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+)
+
+func graphQLQuery(r *require.Assertions, h http.Handler, query string) gjson.Result {
+	body, err := json.Marshal(map[string]string{"query": query})
+	r.NoError(err)
+	req := httptest.NewRequest(http.MethodPost, "http://url.com/graphql", strings.NewReader(string(body)))
+	req.RemoteAddr = "1.1.1.1:1000"
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	res := gjson.Parse(resp.Body.String())
+	r.False(res.Get("errors").Exists(), resp.Body.String())
+	return res.Get("data")
+}
+
+func TestGraphQLBlock(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	h, err := NewGraphQLHandler(core, nil)
+	r.NoError(err)
+
+	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), []byte{}, 100000, big.NewInt(0))
+	r.NoError(err)
+	tsfHash, err := tsf.Hash()
+	r.NoError(err)
+	receipts := []*action.Receipt{
+		{Status: uint64(iotextypes.ReceiptStatus_Success), BlockHeight: 1, ActionHash: tsfHash, GasConsumed: 10000},
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetVersion(111).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Unix(1700000000, 0)).
+		SetReceipts(receipts).
+		AddActions(tsf).
+		SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	blkHash := blk.HashBlock()
+	core.EXPECT().BlockByHeight(uint64(1)).Return(&apitypes.BlockWithReceipts{Block: &blk, Receipts: receipts}, nil).Times(1)
+	core.EXPECT().EVMNetworkID().Return(uint32(0)).AnyTimes()
+
+	data := graphQLQuery(r, h, `{ block(number: 1) {
+		number hash gasLimit gasUsed timestamp transactionCount
+		miner { address }
+		transactions { hash index value gas status gasUsed from { address } to { address } }
+	} }`)
+	r.Equal("0x1", data.Get("block.number").String())
+	r.Equal("0x"+hex.EncodeToString(blkHash[:]), data.Get("block.hash").String())
+	r.Equal("0x186a0", data.Get("block.gasLimit").String())
+	r.Equal("0x2710", data.Get("block.gasUsed").String())
+	r.Equal(fmt.Sprintf("0x%x", 1700000000), data.Get("block.timestamp").String())
+	r.Equal("0x1", data.Get("block.transactionCount").String())
+	r.True(strings.EqualFold(identityset.Address(0).Hex(), data.Get("block.miner.address").String()))
+	txs := data.Get("block.transactions").Array()
+	r.Len(txs, 1)
+	r.Equal("0x"+hex.EncodeToString(tsfHash[:]), txs[0].Get("hash").String())
+	r.Equal("0x0", txs[0].Get("index").String())
+	r.Equal("0xa", txs[0].Get("value").String())
+	r.Equal("0x1", txs[0].Get("status").String())
+	r.True(strings.EqualFold(identityset.Address(27).Hex(), txs[0].Get("from.address").String()))
+	r.True(strings.EqualFold(identityset.Address(28).Hex(), txs[0].Get("to.address").String()))
+
+	// block not found
+	core.EXPECT().BlockByHeight(uint64(2)).Return(nil, ErrNotFound).Times(1)
+	data = graphQLQuery(r, h, `{ block(number: 2) { number } }`)
+	r.Equal(gjson.Null, data.Get("block").Type)
+}
+
+func TestGraphQLTransactionAndLogs(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	h, err := NewGraphQLHandler(core, nil)
+	r.NoError(err)
+
+	exec, err := action.SignedExecution(identityset.Address(29).String(), identityset.PrivateKey(27), 1, big.NewInt(0), 100000, big.NewInt(1), []byte{1, 2})
+	r.NoError(err)
+	execHash, err := exec.Hash()
+	r.NoError(err)
+	topic := hash.Hash256b([]byte("topic"))
+	receipt := &action.Receipt{
+		Status:      uint64(iotextypes.ReceiptStatus_ErrExecutionReverted),
+		BlockHeight: 1,
+		ActionHash:  execHash,
+		GasConsumed: 200,
+	}
+	receipt.AddLogs(&action.Log{
+		Address:     identityset.Address(29).String(),
+		Topics:      []hash.Hash256{topic},
+		Data:        []byte{3},
+		BlockHeight: 1,
+		ActionHash:  execHash,
+	})
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		AddActions(exec).
+		SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	core.EXPECT().ActionByActionHash(execHash).Return(exec, &blk, uint32(0), nil).Times(2)
+	core.EXPECT().ReceiptByActionHash(execHash).Return(receipt, nil).Times(2)
+	core.EXPECT().EVMNetworkID().Return(uint32(0)).AnyTimes()
+
+	data := graphQLQuery(r, h, fmt.Sprintf(`{ transaction(hash: "0x%x") {
+		hash inputData status gasUsed
+		logs { index topics data account { address } transaction { hash } }
+	} }`, execHash[:]))
+	r.Equal("0x0102", data.Get("transaction.inputData").String())
+	r.Equal("0x0", data.Get("transaction.status").String())
+	r.Equal("0xc8", data.Get("transaction.gasUsed").String())
+	logs := data.Get("transaction.logs").Array()
+	r.Len(logs, 1)
+	r.Equal("0x"+hex.EncodeToString(topic[:]), logs[0].Get("topics.0").String())
+	r.Equal("0x03", logs[0].Get("data").String())
+	r.True(strings.EqualFold(identityset.Address(29).Hex(), logs[0].Get("account.address").String()))
+	r.Equal("0x"+hex.EncodeToString(execHash[:]), logs[0].Get("transaction.hash").String())
+
+	core.EXPECT().TipHeight().Return(uint64(10)).Times(2)
+	core.EXPECT().LogsInRange(gomock.Any(), uint64(5), uint64(10), uint64(0)).Return(receipt.Logs(), []hash.Hash256{blk.HashBlock()}, nil).Times(1)
+	data = graphQLQuery(r, h, `{ logs(filter: {fromBlock: 5}) { data } }`)
+	r.Len(data.Get("logs").Array(), 1)
+
+	// pending transaction
+	core.EXPECT().ActionByActionHash(gomock.Any()).Return(nil, nil, uint32(0), ErrNotFound).Times(1)
+	core.EXPECT().PendingActionByActionHash(gomock.Any()).Return(exec, nil).Times(1)
+	data = graphQLQuery(r, h, fmt.Sprintf(`{ transaction(hash: "0x%x") { index block { number } status logs { data } } }`, execHash[:]))
+	r.Equal(gjson.Null, data.Get("transaction.index").Type)
+	r.Equal(gjson.Null, data.Get("transaction.block").Type)
+	r.Equal(gjson.Null, data.Get("transaction.status").Type)
+	r.Equal(gjson.Null, data.Get("transaction.logs").Type)
+}
+
+func TestGraphQLAccountAndCall(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	h, err := NewGraphQLHandler(core, nil)
+	r.NoError(err)
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(3).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	contract := identityset.Address(29)
+	core.EXPECT().TipHeight().Return(uint64(3)).Times(1)
+	core.EXPECT().BlockByHeight(uint64(3)).Return(&apitypes.BlockWithReceipts{Block: &blk}, nil).Times(1)
+	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{
+		Address:          contract.String(),
+		Balance:          "100",
+		IsContract:       true,
+		ContractByteCode: []byte{0x60, 0x80},
+	}, nil, nil).Times(3)
+	core.EXPECT().PendingNonce(gomock.Any()).Return(uint64(5), nil).Times(1)
+	core.EXPECT().ReadContract(gomock.Any(), gomock.Any(), gomock.Any()).Return("0a0b", &iotextypes.Receipt{
+		Status:      uint64(iotextypes.ReceiptStatus_Success),
+		GasConsumed: 3000,
+	}, nil).Times(1)
+	core.EXPECT().EstimateExecutionGasConsumption(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(30000), nil).Times(1)
+	core.EXPECT().ChainID().Return(uint32(1)).Times(1)
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).Times(1)
+	core.EXPECT().SuggestGasPrice().Return(uint64(1000000000000), nil).Times(1)
+	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(3), uint64(3)).Times(1)
+
+	data := graphQLQuery(r, h, fmt.Sprintf(`{
+		block {
+			account(address: "%[1]s") { balance transactionCount code }
+			call(data: {to: "%[1]s", data: "0x01"}) { data gasUsed status }
+			estimateGas(data: {to: "%[1]s", data: "0x01"})
+		}
+		chainID
+		gasPrice
+		syncing { currentBlock }
+	}`, contract.Hex()))
+	r.Equal("0x64", data.Get("block.account.balance").String())
+	r.Equal("0x5", data.Get("block.account.transactionCount").String())
+	r.Equal("0x6080", data.Get("block.account.code").String())
+	r.Equal("0x0a0b", data.Get("block.call.data").String())
+	r.Equal("0xbb8", data.Get("block.call.gasUsed").String())
+	r.Equal("0x1", data.Get("block.call.status").String())
+	r.Equal("0x7530", data.Get("block.estimateGas").String())
+	r.Equal("0x1251", data.Get("chainID").String())
+	r.Equal("0xe8d4a51000", data.Get("gasPrice").String())
+	r.Equal(gjson.Null, data.Get("syncing").Type)
+}
+
+func TestGraphQLRateLimit(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).Times(1)
+	rl, err := NewRateLimiter(RateLimitConfig{
+		Enabled:       true,
+		IPBurst:       10,
+		MaxTrackedIPs: 10,
+		MethodCosts:   map[string]int{_graphQLMethod: 10},
+	})
+	r.NoError(err)
+	h, err := NewGraphQLHandler(core, rl)
+	r.NoError(err)
+
+	r.Equal("0x1251", graphQLQuery(r, h, `{ chainID }`).Get("chainID").String())
+	req := httptest.NewRequest(http.MethodPost, "http://url.com/graphql", strings.NewReader(`{"query":"{ chainID }"}`))
+	req.RemoteAddr = "1.1.1.1:1000"
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	r.Equal(http.StatusTooManyRequests, resp.Code)
+	r.Contains(resp.Body.String(), "rate limit exceeded")
+}
@@ -81,22 +81,31 @@ func RecoveryInterceptor() grpc_recovery.Option {
 }
 
 // NewGRPCServer creates a new grpc server
-func NewGRPCServer(core CoreService, grpcPort int) *GRPCServer {
+func NewGRPCServer(core CoreService, grpcPort int, limiter *RateLimiter, monitor *RequestMonitor) *GRPCServer {
 	if grpcPort == 0 {
 		return nil
 	}
 
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_prometheus.StreamServerInterceptor,
+		otelgrpc.StreamServerInterceptor(),
+		grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_prometheus.UnaryServerInterceptor,
+		otelgrpc.UnaryServerInterceptor(),
+		grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
+	}
+	if monitor != nil {
+		unaryInterceptors = append(unaryInterceptors, monitor.UnaryInterceptor())
+	}
+	if limiter != nil {
+		streamInterceptors = append(streamInterceptors, limiter.StreamInterceptor())
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryInterceptor())
+	}
 	gSvr := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_prometheus.StreamServerInterceptor,
-			otelgrpc.StreamServerInterceptor(),
-			grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
-		)),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_prometheus.UnaryServerInterceptor,
-			otelgrpc.UnaryServerInterceptor(),
-			grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
-		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 		grpc.KeepaliveEnforcementPolicy(kaep),
 		grpc.KeepaliveParams(kasp),
 	)
@@ -0,0 +1,166 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// _ipcSocketMode only allows the owner of the node process to connect to the socket
+	_ipcSocketMode = 0600
+	// _ipcDirMode is the mode of the directory of the socket if it does not exist
+	_ipcDirMode = 0700
+)
+
+type (
+	// IPCServer serves web3 JSON-RPC, including subscriptions, on a unix domain socket, the access to which is
+	// controlled by the filesystem permissions of the socket
+	IPCServer struct {
+		path     string
+		handler  Web3Handler
+		listener net.Listener
+		mutex    sync.Mutex
+		conns    map[net.Conn]struct{}
+		wg       sync.WaitGroup
+	}
+
+	ipcRequestKey struct{}
+)
+
+// NewIPCServer creates a new ipc server, it returns nil if the path is empty
+func NewIPCServer(path string, handler Web3Handler) *IPCServer {
+	if path == "" {
+		return nil
+	}
+	return &IPCServer{
+		path:    path,
+		handler: handler,
+		conns:   make(map[net.Conn]struct{}),
+	}
+}
+
+// Start starts the ipc server
+func (s *IPCServer) Start(_ context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), _ipcDirMode); err != nil {
+		return errors.Wrapf(err, "failed to create the directory of ipc socket %s", s.path)
+	}
+	// remove the socket left by an unclean shutdown
+	if info, err := os.Lstat(s.path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return errors.Errorf("ipc path %s exists and is not a socket", s.path)
+		}
+		if err := os.Remove(s.path); err != nil {
+			return errors.Wrapf(err, "failed to remove stale ipc socket %s", s.path)
+		}
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on ipc socket %s", s.path)
+	}
+	if err := os.Chmod(s.path, _ipcSocketMode); err != nil {
+		listener.Close()
+		return errors.Wrapf(err, "failed to set the mode of ipc socket %s", s.path)
+	}
+	s.listener = listener
+	go s.serve()
+	return nil
+}
+
+// Stop closes the socket and the open connections
+func (s *IPCServer) Stop(_ context.Context) error {
+	if s.listener == nil {
+		return nil
+	}
+	// closing the listener also removes the socket file
+	err := s.listener.Close()
+	s.mutex.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mutex.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *IPCServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			log.Logger("api").Warn("failed to accept ipc connection", zap.Error(err))
+			continue
+		}
+		s.mutex.Lock()
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mutex.Unlock()
+		go s.handleConnection(conn)
+	}
+}
+
+func (s *IPCServer) handleConnection(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+		s.wg.Done()
+	}()
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ipcRequestKey{}, true))
+	defer cancel()
+
+	// the responses of subscriptions are written from other goroutines
+	var mu sync.Mutex
+	writer := apitypes.NewResponseWriter(func(resp interface{}) (int, error) {
+		raw, err := json.Marshal(resp)
+		if err != nil {
+			return 0, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+			log.Logger("api").Warn("failed to set write deadline timeout.", zap.Error(err))
+		}
+		return conn.Write(append(raw, '\n'))
+	})
+	// the requests are a stream of json values without delimiters
+	decoder := json.NewDecoder(conn)
+	for {
+		var msg json.RawMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				log.Logger("api").Debug("failed to read ipc request", zap.Error(err))
+			}
+			return
+		}
+		if err := s.handler.HandlePOSTReq(ctx, bytes.NewReader(msg), writer); err != nil {
+			log.Logger("api").Warn("fail to respond request.", zap.Error(err))
+			return
+		}
+	}
+}
+
+func isIPCRequest(ctx context.Context) bool {
+	ipc, _ := ctx.Value(ipcRequestKey{}).(bool)
+	return ipc
+}
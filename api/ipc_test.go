@@ -0,0 +1,134 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
+)
+
+func TestIPCServer(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).AnyTimes()
+	var responder apitypes.Responder
+	listener := mock_apitypes.NewMockListener(ctrl)
+	listener.EXPECT().AddResponder(gomock.Any()).DoAndReturn(func(r apitypes.Responder) (string, error) {
+		responder = r
+		return "0x1", nil
+	}).Times(1)
+	core.EXPECT().ChainListener().Return(listener).Times(1)
+
+	handler := NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil, nil, DefaultConfig.IPCOnlyNamespaces, nil)
+	r.Nil(NewIPCServer("", handler))
+	path := filepath.Join(t.TempDir(), "ipc", "iotex.ipc")
+	// a stale socket is removed on start
+	r.NoError(os.MkdirAll(filepath.Dir(path), 0700))
+	stale, err := net.Listen("unix", path)
+	r.NoError(err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	r.NoError(stale.Close())
+	svr := NewIPCServer(path, handler)
+	ctx := context.Background()
+	r.NoError(svr.Start(ctx))
+	info, err := os.Stat(path)
+	r.NoError(err)
+	r.Equal(os.FileMode(_ipcSocketMode), info.Mode().Perm())
+
+	conn, err := net.Dial("unix", path)
+	r.NoError(err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	readResp := func() string {
+		r.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		line, err := reader.ReadString('\n')
+		r.NoError(err)
+		return line
+	}
+	// the requests are not delimited
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}{"jsonrpc":"2.0","method":"debug_foo","params":[],"id":2}`))
+	r.NoError(err)
+	resp := readResp()
+	r.Equal(uint64(1), gjson.Get(resp, "id").Uint())
+	r.Equal("0x1251", gjson.Get(resp, "result").String())
+	// the ipc only namespaces are served over ipc
+	resp = readResp()
+	r.Equal(uint64(2), gjson.Get(resp, "id").Uint())
+	r.Contains(gjson.Get(resp, "error.message").String(), "method not found")
+
+	// subscription
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","method":"eth_subscribe","params":["newHeads"],"id":3}`))
+	r.NoError(err)
+	r.Equal("0x1", gjson.Get(readResp(), "result").String())
+	r.NotNil(responder)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	r.NoError(responder.Respond("0x1", &blk))
+	resp = readResp()
+	r.Equal("eth_subscription", gjson.Get(resp, "method").String())
+	r.Equal("0x1", gjson.Get(resp, "params.result.number").String())
+
+	r.NoError(svr.Stop(ctx))
+	_, err = os.Stat(path)
+	r.True(os.IsNotExist(err))
+	// the open connection is closed
+	r.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	_, err = reader.ReadString('\n')
+	r.Error(err)
+}
+
+func TestIPCOnlyNamespaces(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil, nil, DefaultConfig.IPCOnlyNamespaces, nil))
+	for _, c := range []struct {
+		method   string
+		expected string
+	}{
+		{"debug_getStateDiff", "only available over IPC"},
+		{"admin_nodeInfo", "only available over IPC"},
+		{"web3_foo", "method not found"},
+	} {
+		req := httptest.NewRequest("POST", "http://url.com", strings.NewReader(`{"jsonrpc":"2.0","method":"`+c.method+`","params":[],"id":1}`))
+		resp := httptest.NewRecorder()
+		svr.ServeHTTP(resp, req)
+		r.Contains(resp.Body.String(), c.expected, c.method)
+	}
+	// the namespaces are not restricted without the unix domain socket
+	cfg := DefaultConfig
+	r.Empty(ipcOnlyNamespaces(cfg))
+	cfg.IPCPath = "iotex.ipc"
+	r.Equal(DefaultConfig.IPCOnlyNamespaces, ipcOnlyNamespaces(cfg))
+}
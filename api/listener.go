@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/fastrand"
@@ -84,6 +85,23 @@ func (cl *chainListener) ReceiveBlock(blk *block.Block) error {
 	return nil
 }
 
+// ReceiveAction handles the action accepted into actpool
+func (cl *chainListener) ReceiveAction(selp *action.SealedEnvelope) error {
+	// pass the action to every action responder
+	cl.streamMap.Range(func(key, value interface{}) error {
+		r, ok := value.(apitypes.ActionResponder)
+		if !ok {
+			return nil
+		}
+		err := r.RespondAction(key.(string), selp)
+		if err != nil {
+			log.L().Error("responder failed to process action", zap.Error(err))
+		}
+		return err
+	})
+	return nil
+}
+
 // AddResponder adds a new responder
 func (cl *chainListener) AddResponder(responder apitypes.Responder) (string, error) {
 	cl.mu.Lock()
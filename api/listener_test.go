@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
 	"github.com/stretchr/testify/require"
@@ -59,6 +60,27 @@ func TestChainListener(t *testing.T) {
 	})
 }
 
+func TestChainListener_ReceiveAction(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	responder := mock_apitypes.NewMockResponder(ctrl)
+	actResponder := mock_apitypes.NewMockActionResponder(ctrl)
+	listener := NewChainListener(2)
+	_, err := listener.AddResponder(responder)
+	r.NoError(err)
+	id, err := listener.AddResponder(actResponder)
+	r.NoError(err)
+
+	// only the action responder receives the action
+	selp := &action.SealedEnvelope{}
+	actResponder.EXPECT().RespondAction(id, selp).Return(nil).Times(1)
+	r.NoError(listener.ReceiveAction(selp))
+	responder.EXPECT().Respond(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	actResponder.EXPECT().Respond(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	r.NoError(listener.ReceiveBlock(&block.Block{}))
+}
+
 func TestRandID(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,257 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	_web3Protocol = "web3"
+	_grpcProtocol = "grpc"
+	// _unknownMethod is the metric label of the methods not found, to bound the cardinality
+	_unknownMethod = "unknown"
+	_ipcCaller     = "ipc"
+)
+
+var (
+	_apiLatencyMtc = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "iotex_api_latency_seconds",
+		Help:    "api request latency by method",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"protocol", "method"})
+	_apiResponseSizeMtc = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "iotex_api_response_size_bytes",
+		Help:    "api response size by method",
+		Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+	}, []string{"protocol", "method"})
+	_apiSlowQueryMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_slow_queries",
+		Help: "api requests slower than the slow query threshold",
+	}, []string{"protocol", "method"})
+)
+
+func init() {
+	prometheus.MustRegister(_apiLatencyMtc)
+	prometheus.MustRegister(_apiResponseSizeMtc)
+	prometheus.MustRegister(_apiSlowQueryMtc)
+}
+
+type (
+	// RequestMonitor records the latency and response size of api requests by method, and logs the requests
+	// slower than the slow query threshold, keeping the slowest recent ones
+	RequestMonitor struct {
+		cfg     SlowQueryConfig
+		mutex   sync.Mutex
+		queries []*SlowQuery
+	}
+
+	// SlowQuery is a request slower than the slow query threshold
+	SlowQuery struct {
+		Protocol string
+		Method   string
+		Params   string
+		Caller   string
+		Duration time.Duration
+		Time     time.Time
+	}
+)
+
+// NewRequestMonitor creates a new request monitor
+func NewRequestMonitor(cfg SlowQueryConfig) *RequestMonitor {
+	return &RequestMonitor{
+		cfg: cfg,
+	}
+}
+
+// Observe records a request, the params are only evaluated if the request is slow
+func (m *RequestMonitor) Observe(ctx context.Context, protocol, method string, params func() string, elapsed time.Duration, size int) {
+	_apiLatencyMtc.WithLabelValues(protocol, method).Observe(elapsed.Seconds())
+	_apiResponseSizeMtc.WithLabelValues(protocol, method).Observe(float64(size))
+	if m == nil || m.cfg.Threshold <= 0 || elapsed < m.cfg.Threshold {
+		return
+	}
+	_apiSlowQueryMtc.WithLabelValues(protocol, method).Inc()
+	q := &SlowQuery{
+		Protocol: protocol,
+		Method:   method,
+		Params:   m.truncate(params()),
+		Caller:   requestCaller(ctx),
+		Duration: elapsed,
+		Time:     time.Now(),
+	}
+	log.Logger("api").Warn("slow query",
+		zap.String("protocol", q.Protocol),
+		zap.String("method", q.Method),
+		zap.String("params", q.Params),
+		zap.String("caller", q.Caller),
+		zap.Duration("duration", q.Duration))
+	if m.cfg.Size <= 0 {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.queries = m.recent(q.Time)
+	// the queries are sorted from the slowest
+	i := sort.Search(len(m.queries), func(i int) bool { return m.queries[i].Duration < q.Duration })
+	if i >= m.cfg.Size {
+		return
+	}
+	m.queries = append(m.queries, nil)
+	copy(m.queries[i+1:], m.queries[i:])
+	m.queries[i] = q
+	if len(m.queries) > m.cfg.Size {
+		m.queries = m.queries[:m.cfg.Size]
+	}
+}
+
+// SlowQueries returns the n slowest requests in the recent window, from the slowest
+func (m *RequestMonitor) SlowQueries(n int) []*SlowQuery {
+	if m == nil {
+		return nil
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.queries = m.recent(time.Now())
+	if n > len(m.queries) {
+		n = len(m.queries)
+	}
+	ret := make([]*SlowQuery, n)
+	copy(ret, m.queries)
+	return ret
+}
+
+// UnaryInterceptor returns a grpc unary interceptor which monitors the requests
+func (m *RequestMonitor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		var size int
+		if msg, ok := resp.(proto.Message); ok && err == nil {
+			size = proto.Size(msg)
+		}
+		m.Observe(ctx, _grpcProtocol, path.Base(info.FullMethod), func() string {
+			return redactGRPCParams(path.Base(info.FullMethod), req)
+		}, time.Since(start), size)
+		return resp, err
+	}
+}
+
+func (m *RequestMonitor) recent(now time.Time) []*SlowQuery {
+	if m.cfg.Window <= 0 {
+		return m.queries
+	}
+	recent := m.queries[:0]
+	for _, q := range m.queries {
+		if now.Sub(q.Time) <= m.cfg.Window {
+			recent = append(recent, q)
+		}
+	}
+	// clear the dropped tail for gc
+	for i := len(recent); i < len(m.queries); i++ {
+		m.queries[i] = nil
+	}
+	return recent
+}
+
+func (m *RequestMonitor) truncate(params string) string {
+	if m.cfg.MaxParamsLength > 0 && len(params) > m.cfg.MaxParamsLength {
+		return params[:m.cfg.MaxParamsLength] + "...(truncated)"
+	}
+	return params
+}
+
+// redactWeb3Params returns the params of a web3 request to log, a raw transaction is logged by
+// its hash and the data of a call by its selector and size
+func redactWeb3Params(method string, params gjson.Result) string {
+	switch method {
+	case "eth_sendRawTransaction":
+		raw, err := hexToBytes(params.Get("0").String())
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf(`[{"txHash":"%s","size":%d}]`, crypto.Keccak256Hash(raw).Hex(), len(raw))
+	case "eth_call", "eth_estimateGas", "debug_traceCall":
+		call := make(map[string]string)
+		for k, v := range params.Get("0").Map() {
+			if k == "data" || k == "input" {
+				call[k] = redactCallData(v.String())
+			} else {
+				call[k] = v.String()
+			}
+		}
+		data, _ := json.Marshal(call)
+		ret := []string{string(data)}
+		for i, p := range params.Array() {
+			if i > 0 {
+				ret = append(ret, p.Get("@ugly").Raw)
+			}
+		}
+		return "[" + strings.Join(ret, ",") + "]"
+	default:
+		return params.Get("@ugly").Raw
+	}
+}
+
+// redactGRPCParams returns the params of a grpc request to log, the requests carrying signed actions
+// or call data are logged by their size
+func redactGRPCParams(method string, req interface{}) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	switch method {
+	case "SendAction", "ReadContract", "EstimateGasForAction", "EstimateActionGasConsumption":
+		return fmt.Sprintf(`{"size":%d}`, proto.Size(msg))
+	default:
+		data, _ := protojson.Marshal(msg)
+		return string(data)
+	}
+}
+
+func redactCallData(data string) string {
+	data = util.Remove0xPrefix(data)
+	if len(data) <= 8 {
+		return "0x" + data
+	}
+	return fmt.Sprintf("0x%s...(%d bytes)", data[:8], len(data)/2)
+}
+
+// requestCaller returns the address of the client of the request, the api key is never returned
+func requestCaller(ctx context.Context) string {
+	if isIPCRequest(ctx) {
+		return _ipcCaller
+	}
+	if ci, ok := ctx.Value(clientInfoKey{}).(*clientInfo); ok {
+		if ci.forwardedFor != "" {
+			return ci.forwardedFor
+		}
+		return ci.remoteAddr
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
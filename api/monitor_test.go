@@ -0,0 +1,135 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+)
+
+func TestRequestMonitor(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	params := func(p string) func() string { return func() string { return p } }
+
+	var nilMonitor *RequestMonitor
+	nilMonitor.Observe(ctx, _web3Protocol, "eth_call", params(""), time.Hour, 0)
+	r.Empty(nilMonitor.SlowQueries(1))
+
+	m := NewRequestMonitor(SlowQueryConfig{
+		Threshold:       time.Second,
+		Size:            2,
+		Window:          time.Hour,
+		MaxParamsLength: 8,
+	})
+	m.Observe(ctx, _web3Protocol, "eth_getLogs", func() string {
+		require.Fail(t, "params of a fast request are evaluated")
+		return ""
+	}, time.Millisecond, 10)
+	r.Empty(m.SlowQueries(10))
+
+	httpCtx := context.WithValue(ctx, clientInfoKey{}, &clientInfo{remoteAddr: "1.2.3.4:5678", apiKey: "secret"})
+	m.Observe(httpCtx, _web3Protocol, "eth_getLogs", params(`[{"fromBlock":"0x1"}]`), 2*time.Second, 10)
+	m.Observe(context.WithValue(ctx, ipcRequestKey{}, true), _web3Protocol, "eth_call", params(`[]`), 3*time.Second, 10)
+	queries := m.SlowQueries(10)
+	r.Len(queries, 2)
+	// from the slowest
+	r.Equal("eth_call", queries[0].Method)
+	r.Equal(_ipcCaller, queries[0].Caller)
+	r.Equal("eth_getLogs", queries[1].Method)
+	r.Equal(2*time.Second, queries[1].Duration)
+	r.Equal("1.2.3.4:5678", queries[1].Caller)
+	r.Equal(`[{"fromB...(truncated)`, queries[1].Params)
+	r.Len(m.SlowQueries(1), 1)
+
+	// the fastest is evicted beyond the size
+	m.Observe(ctx, _grpcProtocol, "GetLogs", params(""), 4*time.Second, 10)
+	queries = m.SlowQueries(10)
+	r.Len(queries, 2)
+	r.Equal("GetLogs", queries[0].Method)
+	r.Equal("eth_call", queries[1].Method)
+	m.Observe(ctx, _grpcProtocol, "GetLogs", params(""), time.Second, 10)
+	r.Equal("eth_call", m.SlowQueries(10)[1].Method)
+
+	// the queries out of the window are dropped
+	m.cfg.Window = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	r.Empty(m.SlowQueries(10))
+}
+
+func TestRequestMonitorInterceptor(t *testing.T) {
+	r := require.New(t)
+	m := NewRequestMonitor(SlowQueryConfig{Threshold: time.Nanosecond, Size: 10})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 5678}})
+	resp, err := m.UnaryInterceptor()(ctx, &iotexapi.GetAccountRequest{Address: "io1abc"},
+		&grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetAccount"},
+		func(context.Context, interface{}) (interface{}, error) {
+			time.Sleep(time.Millisecond)
+			return &iotexapi.GetAccountResponse{}, nil
+		})
+	r.NoError(err)
+	r.NotNil(resp)
+	queries := m.SlowQueries(10)
+	r.Len(queries, 1)
+	r.Equal(_grpcProtocol, queries[0].Protocol)
+	r.Equal("GetAccount", queries[0].Method)
+	r.Equal("1.2.3.4:5678", queries[0].Caller)
+	r.Contains(queries[0].Params, "io1abc")
+}
+
+func TestWeb3SlowQueries(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	m := NewRequestMonitor(SlowQueryConfig{Threshold: time.Nanosecond, Size: 10})
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil, nil, nil, m))
+	request := func(body string) string {
+		req := httptest.NewRequest("POST", "http://url.com", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		svr.ServeHTTP(resp, req)
+		return resp.Body.String()
+	}
+	request(`{"jsonrpc":"2.0","method":"web3_foo","params":[1,  2],"id":1}`)
+	res := request(`{"jsonrpc":"2.0","method":"debug_slowQueries","params":[5],"id":2}`)
+	r.Equal(_unknownMethod, gjson.Get(res, "result.0.method").String())
+	r.Equal("[1,2]", gjson.Get(res, "result.0.params").String())
+	r.Equal(_web3Protocol, gjson.Get(res, "result.0.protocol").String())
+	r.Contains(request(`{"jsonrpc":"2.0","method":"debug_slowQueries","params":[0],"id":3}`), "wrong type of params")
+}
+
+func TestRedactParams(t *testing.T) {
+	r := require.New(t)
+	for _, c := range []struct {
+		method, params, expected string
+	}{
+		{"eth_getLogs", `[{"fromBlock": "0x1"}]`, `[{"fromBlock":"0x1"}]`},
+		{"eth_sendRawTransaction", `["0x0102"]`, `[{"txHash":"0x22ae6da6b482f9b1b19b0b897c3fd43884180a1c5ee361e1107a1bc635649dda","size":2}]`},
+		{"eth_call", `[{"to":"0x12","data":"0xa9059cbb0000000000000000000000000000000000000000000000000000000000000001"},"latest"]`,
+			`[{"data":"0xa9059cbb...(36 bytes)","to":"0x12"},"latest"]`},
+		{"eth_estimateGas", `[{"input":"0x01"}]`, `[{"input":"0x01"}]`},
+		{"eth_call", `[]`, `[{}]`},
+	} {
+		r.Equal(c.expected, redactWeb3Params(c.method, gjson.Parse(c.params)), c.method)
+	}
+	r.Equal(`{"size":6}`, redactGRPCParams("SendAction", &iotexapi.SendActionRequest{Action: &iotextypes.Action{Signature: []byte{1, 2}}}))
+	r.Contains(redactGRPCParams("GetAccount", &iotexapi.GetAccountRequest{Address: "io1abc"}), "io1abc")
+}
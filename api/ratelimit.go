@@ -0,0 +1,250 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache/lru"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// _apiKeyHeader is the http header to pass the api key
+	_apiKeyHeader = "X-API-Key"
+	// _apiKeyMetadata is the grpc metadata to pass the api key
+	_apiKeyMetadata = "x-api-key"
+	// _forwardedForHeader is the http header or grpc metadata of the proxied client address
+	_forwardedForHeader = "X-Forwarded-For"
+	// _anonymousClient is the metric label of the clients without api key
+	_anonymousClient = "anonymous"
+)
+
+var (
+	_apiRateLimitMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_rate_limit",
+		Help: "api rate limit by client",
+	}, []string{"client", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(_apiRateLimitMtc)
+}
+
+type (
+	// RateLimiter limits the requests of each client with token buckets, a client is identified
+	// by its api key, or by its IP if it does not provide one
+	RateLimiter struct {
+		cfg   RateLimitConfig
+		keys  map[string]*apiKeyLimiter
+		mutex sync.Mutex
+		ips   *lru.Cache
+	}
+
+	apiKeyLimiter struct {
+		name        string
+		limiter     *rate.Limiter
+		quota       uint64
+		used        uint64
+		periodStart time.Time
+	}
+
+	clientInfo struct {
+		remoteAddr   string
+		forwardedFor string
+		apiKey       string
+	}
+
+	clientInfoKey struct{}
+)
+
+// NewRateLimiter creates a new rate limiter, it returns nil if the rate limiting is disabled
+func NewRateLimiter(cfg RateLimitConfig) (*RateLimiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.DefaultCost <= 0 {
+		cfg.DefaultCost = 1
+	}
+	maxCost := cfg.DefaultCost
+	for method, cost := range cfg.MethodCosts {
+		if cost <= 0 {
+			return nil, errors.Errorf("invalid cost %d of method %s", cost, method)
+		}
+		if cost > maxCost {
+			maxCost = cost
+		}
+	}
+	if cfg.IPBurst < maxCost {
+		return nil, errors.Errorf("ip burst %d is less than the max method cost %d", cfg.IPBurst, maxCost)
+	}
+	if cfg.MaxTrackedIPs <= 0 {
+		return nil, errors.Errorf("invalid max tracked ips %d", cfg.MaxTrackedIPs)
+	}
+	keys := make(map[string]*apiKeyLimiter, len(cfg.APIKeys))
+	for _, k := range cfg.APIKeys {
+		if k.Key == "" || k.Name == "" {
+			return nil, errors.New("api key and its name cannot be empty")
+		}
+		if _, ok := keys[k.Key]; ok {
+			return nil, errors.Errorf("duplicate api key of %s", k.Name)
+		}
+		if k.Burst < maxCost {
+			return nil, errors.Errorf("burst %d of api key %s is less than the max method cost %d", k.Burst, k.Name, maxCost)
+		}
+		if k.Quota > 0 && cfg.QuotaPeriod <= 0 {
+			return nil, errors.Errorf("invalid quota period %s", cfg.QuotaPeriod)
+		}
+		keys[k.Key] = &apiKeyLimiter{
+			name:    k.Name,
+			limiter: rate.NewLimiter(rate.Limit(k.Rate), k.Burst),
+			quota:   k.Quota,
+		}
+	}
+	return &RateLimiter{
+		cfg:  cfg,
+		keys: keys,
+		ips:  lru.New(cfg.MaxTrackedIPs),
+	}, nil
+}
+
+// Allow consumes the cost of the method from the bucket of the client in the context,
+// it returns a ResourceExhausted error if the rate or quota of the client is exceeded,
+// or an Unauthenticated error if the api key is unknown
+func (rl *RateLimiter) Allow(ctx context.Context, method string) error {
+	if rl == nil {
+		return nil
+	}
+	ci, ok := ctx.Value(clientInfoKey{}).(*clientInfo)
+	if !ok {
+		// not a request from the network
+		return nil
+	}
+	cost := rl.cost(method)
+	if ci.apiKey != "" {
+		k, ok := rl.keys[ci.apiKey]
+		if !ok {
+			_apiRateLimitMtc.WithLabelValues(_anonymousClient, "unauthenticated").Inc()
+			return status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		return rl.allowKey(k, method, cost)
+	}
+	ip := rl.clientIP(ci)
+	rl.mutex.Lock()
+	limiter, ok := rl.ips.Get(ip)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(rl.cfg.IPRate), rl.cfg.IPBurst)
+		rl.ips.Add(ip, limiter)
+	}
+	rl.mutex.Unlock()
+	if !limiter.(*rate.Limiter).AllowN(time.Now(), cost) {
+		_apiRateLimitMtc.WithLabelValues(_anonymousClient, "limited").Inc()
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s, cost %d", method, cost)
+	}
+	_apiRateLimitMtc.WithLabelValues(_anonymousClient, "allowed").Inc()
+	return nil
+}
+
+func (rl *RateLimiter) allowKey(k *apiKeyLimiter, method string, cost int) error {
+	now := time.Now()
+	if k.quota > 0 {
+		// check the quota first, so a call rejected by the quota does not take the tokens of the rate
+		rl.mutex.Lock()
+		defer rl.mutex.Unlock()
+		if now.Sub(k.periodStart) >= rl.cfg.QuotaPeriod {
+			k.periodStart, k.used = now, 0
+		}
+		if k.used+uint64(cost) > k.quota {
+			_apiRateLimitMtc.WithLabelValues(k.name, "quota_exhausted").Inc()
+			return status.Errorf(codes.ResourceExhausted, "quota exhausted, resets at %s", k.periodStart.Add(rl.cfg.QuotaPeriod).UTC().Format(time.RFC3339))
+		}
+	}
+	if !k.limiter.AllowN(now, cost) {
+		_apiRateLimitMtc.WithLabelValues(k.name, "limited").Inc()
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s, cost %d", method, cost)
+	}
+	if k.quota > 0 {
+		k.used += uint64(cost)
+	}
+	_apiRateLimitMtc.WithLabelValues(k.name, "allowed").Inc()
+	return nil
+}
+
+func (rl *RateLimiter) cost(method string) int {
+	if cost, ok := rl.cfg.MethodCosts[method]; ok {
+		return cost
+	}
+	return rl.cfg.DefaultCost
+}
+
+func (rl *RateLimiter) clientIP(ci *clientInfo) string {
+	if rl.cfg.TrustForwardedFor && ci.forwardedFor != "" {
+		return strings.TrimSpace(strings.Split(ci.forwardedFor, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(ci.remoteAddr)
+	if err != nil {
+		return ci.remoteAddr
+	}
+	return host
+}
+
+// UnaryInterceptor returns a grpc unary interceptor which limits the requests of clients
+func (rl *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withGRPCClientInfo(ctx)
+		if err := rl.Allow(ctx, path.Base(info.FullMethod)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a grpc stream interceptor which limits the streams opened by clients
+func (rl *RateLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rl.Allow(withGRPCClientInfo(ss.Context()), path.Base(info.FullMethod)); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func withHTTPClientInfo(req *http.Request) context.Context {
+	return context.WithValue(req.Context(), clientInfoKey{}, &clientInfo{
+		remoteAddr:   req.RemoteAddr,
+		forwardedFor: req.Header.Get(_forwardedForHeader),
+		apiKey:       req.Header.Get(_apiKeyHeader),
+	})
+}
+
+func withGRPCClientInfo(ctx context.Context) context.Context {
+	ci := &clientInfo{}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ci.remoteAddr = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(_apiKeyMetadata); len(v) > 0 {
+			ci.apiKey = v[0]
+		}
+		if v := md.Get(_forwardedForHeader); len(v) > 0 {
+			ci.forwardedFor = v[0]
+		}
+	}
+	return context.WithValue(ctx, clientInfoKey{}, ci)
+}
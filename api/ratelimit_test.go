@@ -0,0 +1,180 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	r := require.New(t)
+
+	rl, err := NewRateLimiter(RateLimitConfig{})
+	r.NoError(err)
+	r.Nil(rl)
+	r.NoError(rl.Allow(context.Background(), "eth_getLogs"))
+
+	cfg := DefaultConfig.RateLimit
+	cfg.Enabled = true
+	_, err = NewRateLimiter(cfg)
+	r.NoError(err)
+
+	cfg.IPBurst = 5
+	_, err = NewRateLimiter(cfg)
+	r.ErrorContains(err, "less than the max method cost")
+
+	cfg = DefaultConfig.RateLimit
+	cfg.Enabled = true
+	cfg.APIKeys = []APIKeyConfig{
+		{Name: "a", Key: "key", Rate: 1, Burst: 100},
+		{Name: "b", Key: "key", Rate: 1, Burst: 100},
+	}
+	_, err = NewRateLimiter(cfg)
+	r.ErrorContains(err, "duplicate api key")
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	r := require.New(t)
+
+	cfg := RateLimitConfig{
+		Enabled:       true,
+		IPRate:        0.001,
+		IPBurst:       10,
+		MaxTrackedIPs: 10,
+		DefaultCost:   1,
+		MethodCosts:   map[string]int{"eth_getLogs": 10},
+		APIKeys: []APIKeyConfig{
+			{Name: "scraper", Key: "secret", Rate: 1000, Burst: 100, Quota: 15},
+			{Name: "bot", Key: "bot-secret", Rate: 0.001, Burst: 10, Quota: 1},
+		},
+		QuotaPeriod: time.Hour,
+	}
+	rl, err := NewRateLimiter(cfg)
+	r.NoError(err)
+	clientCtx := func(ci *clientInfo) context.Context {
+		return context.WithValue(context.Background(), clientInfoKey{}, ci)
+	}
+
+	t.Run("per ip", func(t *testing.T) {
+		ctx := clientCtx(&clientInfo{remoteAddr: "1.1.1.1:1000"})
+		r.NoError(rl.Allow(ctx, "eth_blockNumber"))
+		err := rl.Allow(ctx, "eth_getLogs")
+		r.Equal(codes.ResourceExhausted, status.Code(err))
+		// the bucket is shared by connections from the same ip
+		r.Equal(codes.ResourceExhausted, status.Code(rl.Allow(clientCtx(&clientInfo{remoteAddr: "1.1.1.1:2000"}), "eth_getLogs")))
+		r.NoError(rl.Allow(clientCtx(&clientInfo{remoteAddr: "2.2.2.2:1000"}), "eth_getLogs"))
+		// the forwarded address is not trusted by default
+		r.Equal(codes.ResourceExhausted, status.Code(rl.Allow(clientCtx(&clientInfo{remoteAddr: "2.2.2.2:1000", forwardedFor: "3.3.3.3"}), "eth_blockNumber")))
+		// not a request from the network
+		r.NoError(rl.Allow(context.Background(), "eth_getLogs"))
+	})
+	t.Run("api key", func(t *testing.T) {
+		r.Equal(codes.Unauthenticated, status.Code(rl.Allow(clientCtx(&clientInfo{apiKey: "unknown"}), "eth_blockNumber")))
+		ctx := clientCtx(&clientInfo{remoteAddr: "1.1.1.1:1000", apiKey: "secret"})
+		r.NoError(rl.Allow(ctx, "eth_getLogs"))
+		for i := 0; i < 5; i++ {
+			r.NoError(rl.Allow(ctx, "eth_blockNumber"))
+		}
+		err := rl.Allow(ctx, "eth_blockNumber")
+		r.Equal(codes.ResourceExhausted, status.Code(err))
+		r.Contains(err.Error(), "quota exhausted")
+		// the quota is reset after the period
+		rl.keys["secret"].periodStart = time.Now().Add(-time.Hour)
+		r.NoError(rl.Allow(ctx, "eth_blockNumber"))
+		// the calls rejected by the quota do not take the tokens of the rate
+		ctx = clientCtx(&clientInfo{remoteAddr: "1.1.1.1:1000", apiKey: "bot-secret"})
+		r.NoError(rl.Allow(ctx, "eth_blockNumber"))
+		for i := 0; i < 5; i++ {
+			r.Contains(rl.Allow(ctx, "eth_blockNumber").Error(), "quota exhausted")
+		}
+		rl.keys["bot-secret"].periodStart = time.Now().Add(-time.Hour)
+		r.InDelta(9, rl.keys["bot-secret"].limiter.Tokens(), 0.1)
+		r.NoError(rl.Allow(ctx, "eth_blockNumber"))
+	})
+	t.Run("trust forwarded for", func(t *testing.T) {
+		cfg := cfg
+		cfg.TrustForwardedFor = true
+		rl, err := NewRateLimiter(cfg)
+		r.NoError(err)
+		r.NoError(rl.Allow(clientCtx(&clientInfo{remoteAddr: "10.0.0.1:1000", forwardedFor: "4.4.4.4, 10.0.0.2"}), "eth_getLogs"))
+		r.NoError(rl.Allow(clientCtx(&clientInfo{remoteAddr: "10.0.0.1:1000", forwardedFor: "5.5.5.5"}), "eth_getLogs"))
+		r.Equal(codes.ResourceExhausted, status.Code(rl.Allow(clientCtx(&clientInfo{remoteAddr: "10.0.0.3:1000", forwardedFor: "4.4.4.4"}), "eth_blockNumber")))
+	})
+}
+
+func TestRateLimiter_Web3(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	core.EXPECT().TipHeight().Return(uint64(1)).Times(1)
+
+	rl, err := NewRateLimiter(RateLimitConfig{
+		Enabled:       true,
+		IPBurst:       10,
+		MaxTrackedIPs: 10,
+		MethodCosts:   map[string]int{"eth_getLogs": 10},
+	})
+	r.NoError(err)
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, rl, nil, nil, nil))
+	request := func(body string) string {
+		req := httptest.NewRequest("POST", "http://url.com", strings.NewReader(body))
+		req.RemoteAddr = "1.1.1.1:1000"
+		resp := httptest.NewRecorder()
+		svr.ServeHTTP(resp, req)
+		return resp.Body.String()
+	}
+	r.Contains(request(`{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`), `"result":"0x1"`)
+	r.Equal(
+		`{"jsonrpc":"2.0","id":2,"error":{"code":8,"message":"rate limit exceeded for eth_getLogs, cost 10"}}`,
+		request(`{"jsonrpc":"2.0","method":"eth_getLogs","params":[{}],"id":2}`),
+	)
+}
+
+func TestRateLimiter_UnaryInterceptor(t *testing.T) {
+	r := require.New(t)
+
+	rl, err := NewRateLimiter(RateLimitConfig{
+		Enabled:       true,
+		IPBurst:       10,
+		MaxTrackedIPs: 10,
+		MethodCosts:   map[string]int{"GetLogs": 10},
+		APIKeys:       []APIKeyConfig{{Name: "a", Key: "secret", Rate: 1, Burst: 10}},
+	})
+	r.NoError(err)
+	interceptor := rl.UnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 1000}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetLogs"}
+
+	res, err := interceptor(ctx, nil, info, handler)
+	r.NoError(err)
+	r.Equal("ok", res)
+	_, err = interceptor(ctx, nil, info, handler)
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+	// the api key has its own bucket
+	keyCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(_apiKeyMetadata, "secret"))
+	_, err = interceptor(keyCtx, nil, info, handler)
+	r.NoError(err)
+	_, err = interceptor(metadata.NewIncomingContext(ctx, metadata.Pairs(_apiKeyMetadata, "bad")), nil, info, handler)
+	r.Equal(codes.Unauthenticated, status.Code(err))
+}
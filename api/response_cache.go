@@ -0,0 +1,105 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const _responseCacheKeyPrefix = "resp:"
+
+var (
+	_responseCacheMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_response_cache",
+		Help: "api response cache hits and misses by method",
+	}, []string{"method", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(_responseCacheMtc)
+}
+
+// ResponseCache caches the responses of the queries which are immutable once the queried
+// blocks are final, an in-memory lru is backed by an optional redis shared among nodes
+type ResponseCache struct {
+	cfg   ResponseCacheConfig
+	local cache.LRUCache
+	redis *redis.Client
+}
+
+// NewResponseCache creates a new response cache, it returns nil if the cache is disabled
+func NewResponseCache(cfg ResponseCacheConfig) *ResponseCache {
+	if !cfg.Enabled || cfg.Size <= 0 {
+		return nil
+	}
+	rc := &ResponseCache{
+		cfg:   cfg,
+		local: cache.NewThreadSafeLruCache(cfg.Size),
+	}
+	if cfg.RedisURL != "" {
+		client := redis.NewClient(&redis.Options{
+			Addr: cfg.RedisURL,
+		})
+		if err := client.Ping(context.Background()).Err(); err != nil {
+			log.L().Warn("failed to connect redis, only local response cache is used", zap.Error(err))
+		} else {
+			rc.redis = client
+		}
+	}
+	return rc
+}
+
+// ResponseCacheKey returns the cache key of the request of method with raw params
+func ResponseCacheKey(method string, params []byte) string {
+	h := hash.Hash160b(append([]byte(method), params...))
+	return _responseCacheKeyPrefix + hex.EncodeToString(h[:])
+}
+
+// Get returns the cached response of the key
+func (rc *ResponseCache) Get(method, key string) ([]byte, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	if v, ok := rc.local.Get(key); ok {
+		_responseCacheMtc.WithLabelValues(method, "hit").Inc()
+		return v.([]byte), true
+	}
+	if rc.redis != nil {
+		data, err := rc.redis.Get(context.Background(), key).Bytes()
+		if err == nil {
+			rc.local.Add(key, data)
+			_responseCacheMtc.WithLabelValues(method, "remote_hit").Inc()
+			return data, true
+		}
+		if err != redis.Nil {
+			log.L().Debug("failed to read response cache from redis", zap.Error(err))
+		}
+	}
+	_responseCacheMtc.WithLabelValues(method, "miss").Inc()
+	return nil, false
+}
+
+// Put stores the response of the key
+func (rc *ResponseCache) Put(key string, data []byte) {
+	if rc == nil {
+		return
+	}
+	rc.local.Add(key, data)
+	if rc.redis != nil {
+		if err := rc.redis.Set(context.Background(), key, data, rc.cfg.Expiration).Err(); err != nil {
+			log.L().Debug("failed to write response cache to redis", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/action"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+)
+
+func TestResponseCache(t *testing.T) {
+	r := require.New(t)
+
+	var rc *ResponseCache
+	r.Nil(NewResponseCache(DefaultConfig.ResponseCache))
+	_, ok := rc.Get("eth_getBlockByNumber", "key")
+	r.False(ok)
+	rc.Put("key", []byte{1})
+
+	cfg := DefaultConfig.ResponseCache
+	cfg.Enabled = true
+	cfg.Size = 2
+	rc = NewResponseCache(cfg)
+	r.NotNil(rc)
+	k1 := ResponseCacheKey("eth_getBlockByNumber", []byte(`["0x1",false]`))
+	k2 := ResponseCacheKey("eth_getBlockByNumber", []byte(`["0x1",true]`))
+	k3 := ResponseCacheKey("eth_getTransactionReceipt", []byte(`["0x1",false]`))
+	r.NotEqual(k1, k2)
+	r.NotEqual(k1, k3)
+	rc.Put(k1, []byte{1})
+	rc.Put(k2, []byte{2})
+	data, ok := rc.Get("eth_getBlockByNumber", k1)
+	r.True(ok)
+	r.Equal([]byte{1}, data)
+	// the least recently used is evicted
+	rc.Put(k3, []byte{3})
+	_, ok = rc.Get("eth_getBlockByNumber", k2)
+	r.False(ok)
+	_, ok = rc.Get("eth_getBlockByNumber", k1)
+	r.True(ok)
+}
+
+func TestWeb3ResponseCache(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	core.EXPECT().TipHeight().Return(uint64(2)).AnyTimes()
+	blkHash := hash.Hash256b([]byte{1})
+	core.EXPECT().BlockHashByBlockHeight(uint64(1)).DoAndReturn(func(uint64) (hash.Hash256, error) {
+		return blkHash, nil
+	}).AnyTimes()
+
+	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	r.NoError(err)
+	tsfhash, err := tsf.Hash()
+	r.NoError(err)
+	receipts := []*action.Receipt{{BlockHeight: 1, ActionHash: tsfhash}}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		SetReceipts(receipts).
+		AddActions(tsf).
+		SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	// the block of an explicit number is only read once
+	core.EXPECT().BlockByHeight(uint64(1)).Return(&apitypes.BlockWithReceipts{
+		Block:    &blk,
+		Receipts: receipts,
+	}, nil).Times(2)
+	// the latest block and a future block are not cached
+	core.EXPECT().BlockByHeight(uint64(2)).Return(nil, ErrNotFound).Times(2)
+	core.EXPECT().BlockByHeight(uint64(3)).Return(nil, ErrNotFound).Times(2)
+
+	cfg := DefaultConfig.ResponseCache
+	cfg.Enabled = true
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil, NewResponseCache(cfg), nil, nil))
+	request := func(body string) string {
+		req := httptest.NewRequest("POST", "http://url.com", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		svr.ServeHTTP(resp, req)
+		return resp.Body.String()
+	}
+	res := request(`{"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0x1", false],"id":1}`)
+	r.Equal(uint64(1), gjson.Get(res, "id").Uint())
+	r.Equal("0x1", gjson.Get(res, "result.number").String())
+	// the id of the request is kept on a cache hit
+	cached := request(`{"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0x1",false],"id":2}`)
+	r.Equal(uint64(2), gjson.Get(cached, "id").Uint())
+	r.Equal(gjson.Get(res, "result").Raw, gjson.Get(cached, "result").Raw)
+	// the block is read again once it is reverted and replaced
+	blkHash = hash.Hash256b([]byte{2})
+	request(`{"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0x1",false],"id":2}`)
+	for i := 0; i < 2; i++ {
+		r.Contains(request(`{"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["latest", false],"id":3}`), `"result":null`)
+		r.Contains(request(`{"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0x3", false],"id":4}`), `"result":null`)
+	}
+}
+
+func TestResponseCacheKey(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	core.EXPECT().BlockHashByBlockHeight(gomock.Any()).DoAndReturn(func(height uint64) (hash.Hash256, error) {
+		return hash.Hash256b(byteutil.Uint64ToBytes(height)), nil
+	}).AnyTimes()
+
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
+	in := gjson.Parse(`{"params":["0x1", false]}`)
+	_, ok := web3svr.responseCacheKey("eth_getBlockByNumber", &in)
+	r.False(ok)
+
+	cfg := DefaultConfig.ResponseCache
+	cfg.Enabled = true
+	web3svr.respCache = NewResponseCache(cfg)
+	for _, c := range []struct {
+		method, params string
+		cacheable      bool
+	}{
+		{"eth_getBlockByNumber", `["0x1", false]`, true},
+		{"eth_getBlockByNumber", `["0xa", true]`, true},
+		{"eth_getBlockByNumber", `["0xb", true]`, false},
+		{"eth_getBlockByNumber", `["latest", true]`, false},
+		{"eth_getTransactionReceipt", `["0x1234"]`, true},
+		{"eth_getBlockReceipts", `["0xa"]`, true},
+		{"eth_getBlockReceipts", `[{"blockNumber":"0xb"}]`, false},
+		{"eth_getBlockReceipts", `["0x0000000000000000000000000000000000000000000000000000000000000001"]`, true},
+		{"eth_getLogs", `[{"fromBlock":"0x1","toBlock":"0xa"}]`, true},
+		{"eth_getLogs", `[{"fromBlock":"0x1","toBlock":"0xb"}]`, false},
+		{"eth_getLogs", `[{"fromBlock":"0x1","toBlock":"latest"}]`, false},
+		{"eth_getLogs", `[{"fromBlock":"0x1"}]`, false},
+		{"eth_getLogs", `[{"blockHash":"0x12"}]`, false},
+		{"eth_blockNumber", `[]`, false},
+	} {
+		in := gjson.Parse(`{"params":` + c.params + `}`)
+		_, ok := web3svr.responseCacheKey(c.method, &in)
+		r.Equal(c.cacheable, ok, "%s %s", c.method, c.params)
+	}
+	// the key ignores the formatting of the params
+	in1, in2 := gjson.Parse(`{"params":["0x1", false]}`), gjson.Parse(`{"params":[ "0x1",false ]}`)
+	k1, _ := web3svr.responseCacheKey("eth_getBlockByNumber", &in1)
+	k2, _ := web3svr.responseCacheKey("eth_getBlockByNumber", &in2)
+	r.Equal(k1, k2)
+	// a cached receipt is only valid if its block is still on the chain
+	blkHash := hash.Hash256b(byteutil.Uint64ToBytes(1))
+	r.True(web3svr.isCachedResponseValid("eth_getTransactionReceipt",
+		[]byte(`{"blockNumber":"0x1","blockHash":"0x`+hex.EncodeToString(blkHash[:])+`"}`)))
+	r.False(web3svr.isCachedResponseValid("eth_getTransactionReceipt",
+		[]byte(`{"blockNumber":"0x2","blockHash":"0x`+hex.EncodeToString(blkHash[:])+`"}`)))
+	r.True(web3svr.isCachedResponseValid("eth_getBlockByNumber", []byte(`{}`)))
+}
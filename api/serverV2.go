@@ -13,6 +13,7 @@ import (
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/time/rate"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/actpool"
@@ -31,6 +32,8 @@ type ServerV2 struct {
 	grpcServer   *GRPCServer
 	httpSvr      *HTTPServer
 	websocketSvr *HTTPServer
+	graphQLSvr   *HTTPServer
+	ipcSvr       *IPCServer
 	tracer       *tracesdk.TracerProvider
 }
 
@@ -52,7 +55,12 @@ func NewServerV2(
 	if err != nil {
 		return nil, err
 	}
-	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit)
+	limiter, err := NewRateLimiter(cfg.RateLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create rate limiter")
+	}
+	monitor := NewRequestMonitor(cfg.SlowQuery)
+	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit, limiter, NewResponseCache(cfg.ResponseCache), ipcOnlyNamespaces(cfg), monitor)
 
 	tp, err := tracer.NewProvider(
 		tracer.WithServiceName(cfg.Tracer.ServiceName),
@@ -66,18 +74,35 @@ func NewServerV2(
 
 	wrappedWeb3Handler := otelhttp.NewHandler(newHTTPHandler(web3Handler), "web3.jsonrpc")
 
-	limiter := rate.NewLimiter(rate.Limit(cfg.WebsocketRateLimit), 1)
-	wrappedWebsocketHandler := otelhttp.NewHandler(NewWebsocketHandler(web3Handler, limiter), "web3.websocket")
+	graphQLHandler, err := NewGraphQLHandler(coreAPI, limiter)
+	if err != nil {
+		return nil, err
+	}
+	wrappedGraphQLHandler := otelhttp.NewHandler(graphQLHandler, "web3.graphql")
+
+	wsLimiter := rate.NewLimiter(rate.Limit(cfg.WebsocketRateLimit), 1)
+	wrappedWebsocketHandler := otelhttp.NewHandler(NewWebsocketHandler(web3Handler, wsLimiter), "web3.websocket")
 
 	return &ServerV2{
 		core:         coreAPI,
-		grpcServer:   NewGRPCServer(coreAPI, cfg.GRPCPort),
+		grpcServer:   NewGRPCServer(coreAPI, cfg.GRPCPort, limiter, monitor),
 		httpSvr:      NewHTTPServer("", cfg.HTTPPort, wrappedWeb3Handler),
 		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, wrappedWebsocketHandler),
+		graphQLSvr:   NewHTTPServer("graphql", cfg.GraphQLPort, wrappedGraphQLHandler),
+		ipcSvr:       NewIPCServer(cfg.IPCPath, web3Handler),
 		tracer:       tp,
 	}, nil
 }
 
+// ipcOnlyNamespaces returns the namespaces restricted to the unix domain socket, none are
+// restricted if the socket is disabled, otherwise they could not be served at all
+func ipcOnlyNamespaces(cfg Config) []string {
+	if cfg.IPCPath == "" {
+		return nil
+	}
+	return cfg.IPCOnlyNamespaces
+}
+
 // Start starts the CoreService and the GRPC server
 func (svr *ServerV2) Start(ctx context.Context) error {
 	if err := svr.core.Start(ctx); err != nil {
@@ -98,6 +123,16 @@ func (svr *ServerV2) Start(ctx context.Context) error {
 			return err
 		}
 	}
+	if svr.graphQLSvr != nil {
+		if err := svr.graphQLSvr.Start(ctx); err != nil {
+			return err
+		}
+	}
+	if svr.ipcSvr != nil {
+		if err := svr.ipcSvr.Start(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -108,6 +143,16 @@ func (svr *ServerV2) Stop(ctx context.Context) error {
 			return errors.Wrap(err, "failed to shutdown api tracer")
 		}
 	}
+	if svr.ipcSvr != nil {
+		if err := svr.ipcSvr.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	if svr.graphQLSvr != nil {
+		if err := svr.graphQLSvr.Stop(ctx); err != nil {
+			return err
+		}
+	}
 	if svr.websocketSvr != nil {
 		if err := svr.websocketSvr.Stop(ctx); err != nil {
 			return err
@@ -134,6 +179,11 @@ func (svr *ServerV2) ReceiveBlock(blk *block.Block) error {
 	return svr.core.ReceiveBlock(blk)
 }
 
+// OnAdded receives the action accepted into actpool
+func (svr *ServerV2) OnAdded(selp *action.SealedEnvelope) {
+	svr.core.ReceivePendingAction(selp)
+}
+
 // CoreService returns the coreservice of the api
 func (svr *ServerV2) CoreService() CoreService {
 	return svr.core
@@ -23,10 +23,10 @@ func TestServerV2(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3Handler := NewWeb3Handler(core, "", _defaultBatchRequestLimit)
+	web3Handler := NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil, nil, nil, nil)
 	svr := &ServerV2{
 		core:         core,
-		grpcServer:   NewGRPCServer(core, testutil.RandomPort()),
+		grpcServer:   NewGRPCServer(core, testutil.RandomPort(), nil, nil),
 		httpSvr:      NewHTTPServer("", testutil.RandomPort(), newHTTPHandler(web3Handler)),
 		websocketSvr: NewHTTPServer("", testutil.RandomPort(), NewWebsocketHandler(web3Handler, nil)),
 	}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/iotexproject/iotex-address/address"
+
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 )
 
@@ -23,11 +26,18 @@ type (
 		Exit()
 	}
 
+	// ActionResponder responds to new block and new action accepted into actpool
+	ActionResponder interface {
+		Responder
+		RespondAction(string, *action.SealedEnvelope) error
+	}
+
 	// Listener pass new block to all responders
 	Listener interface {
 		Start() error
 		Stop() error
 		ReceiveBlock(*block.Block) error
+		ReceiveAction(*action.SealedEnvelope) error
 		AddResponder(Responder) (string, error)
 		RemoveResponder(string) (bool, error)
 	}
@@ -37,6 +47,21 @@ type (
 		Block    *block.Block
 		Receipts []*action.Receipt
 	}
+
+	// SimulationCall is a call of a bundle simulation, the override is applied before the call if not nil
+	SimulationCall struct {
+		Caller   address.Address
+		Exec     *action.Execution
+		Override *evm.SimulationOverride
+	}
+
+	// SimulationResult is the result of a call of a bundle simulation
+	SimulationResult struct {
+		ReturnValue []byte
+		Receipt     *action.Receipt
+		// Tracer is the tracer of the call, nil if tracing is not enabled
+		Tracer any
+	}
 )
 
 // responseWriter for server
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -28,6 +29,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	pollabi "github.com/iotexproject/iotex-core/action/protocol/poll/ethabi"
 	rewardingabi "github.com/iotexproject/iotex-core/action/protocol/rewarding/ethabi"
 	stakingabi "github.com/iotexproject/iotex-core/action/protocol/staking/ethabi"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
@@ -41,6 +45,12 @@ const (
 	_metamaskBalanceContractAddr = "io1k8uw2hrlvnfq8s2qpwwc24ws2ru54heenx8chr"
 	// _defaultBatchRequestLimit is the default maximum number of items in a batch.
 	_defaultBatchRequestLimit = 100 // Maximum number of items in a batch.
+	// _simulateCallsLimit is the maximum number of calls in a simulation
+	_simulateCallsLimit = 256
+	// _defaultSlowQueries is the default number of requests returned by debug_slowQueries
+	_defaultSlowQueries = 10
+	// _defaultStakingHistoryLimit is the default number of events returned by iotex_getStakingHistory
+	_defaultStakingHistoryLimit = 100
 )
 
 type (
@@ -53,6 +63,10 @@ type (
 		coreService       CoreService
 		cache             apiCache
 		batchRequestLimit int
+		limiter           *RateLimiter
+		respCache         *ResponseCache
+		ipcOnlyNamespaces []string
+		monitor           *RequestMonitor
 	}
 )
 
@@ -82,6 +96,7 @@ var (
 	errInvalidBlock      = errors.New("invalid block")
 	errUnsupportedAction = errors.New("the type of action is not supported")
 	errMsgBatchTooLarge  = errors.New("batch too large")
+	errMethodNotFound    = errors.New("web3 method not found")
 
 	_pendingBlockNumber  = "pending"
 	_latestBlockNumber   = "latest"
@@ -93,11 +108,15 @@ func init() {
 }
 
 // NewWeb3Handler creates a handle to process web3 requests
-func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int) Web3Handler {
+func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int, limiter *RateLimiter, respCache *ResponseCache, ipcOnlyNamespaces []string, monitor *RequestMonitor) Web3Handler {
 	return &web3Handler{
 		coreService:       core,
 		cache:             newAPICache(15*time.Minute, cacheURL),
 		batchRequestLimit: batchRequestLimit,
+		limiter:           limiter,
+		respCache:         respCache,
+		ipcOnlyNamespaces: ipcOnlyNamespaces,
+		monitor:           monitor,
 	}
 }
 
@@ -143,12 +162,89 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		method    = web3Req.Get("method").Value()
 		size      int
 	)
-	defer func(start time.Time) { svr.coreService.Track(ctx, start, method.(string), int64(size), err == nil) }(time.Now())
+	defer func(start time.Time) {
+		svr.coreService.Track(ctx, start, method.(string), int64(size), err == nil)
+		label := method.(string)
+		if errors.Cause(err) == errMethodNotFound {
+			label = _unknownMethod
+		}
+		svr.monitor.Observe(ctx, _web3Protocol, label, func() string {
+			return redactWeb3Params(method.(string), web3Req.Get("params"))
+		}, time.Since(start), size)
+	}(time.Now())
 
 	log.T(ctx).Debug("handleWeb3Req", zap.String("method", method.(string)), zap.String("requestParams", fmt.Sprintf("%+v", web3Req)))
 	_web3ServerMtc.WithLabelValues(method.(string)).Inc()
 	_web3ServerMtc.WithLabelValues("requests_total").Inc()
-	switch method {
+	if err = svr.checkNamespace(ctx, method.(string)); err == nil {
+		err = svr.limiter.Allow(ctx, method.(string))
+	}
+	if err != nil {
+		id, _ := web3RequestID(web3Req)
+		size, err1 = writer.Write(&web3Response{
+			id:  id,
+			err: err,
+		})
+		return err1
+	}
+	cacheKey, cacheable := svr.responseCacheKey(method.(string), web3Req)
+	var cached []byte
+	if cacheable {
+		cached, _ = svr.respCache.Get(method.(string), cacheKey)
+		if cached != nil && !svr.isCachedResponseValid(method.(string), cached) {
+			cached = nil
+		}
+	}
+	if cached != nil {
+		res = json.RawMessage(cached)
+	} else {
+		res, err = svr.handleWeb3Method(ctx, web3Req, writer)
+		if cacheable && err == nil && res != nil {
+			if data, err := json.Marshal(res); err == nil {
+				svr.respCache.Put(cacheKey, data)
+			}
+		}
+	}
+	if err != nil {
+		log.Logger("api").Debug("web3server",
+			zap.String("requestParams", fmt.Sprintf("%+v", web3Req)),
+			zap.Error(err))
+	} else {
+		log.Logger("api").Debug("web3Debug", zap.String("response", fmt.Sprintf("%+v", res)))
+	}
+	id, idErr := web3RequestID(web3Req)
+	if idErr != nil {
+		res, err = nil, idErr
+	}
+	size, err1 = writer.Write(&web3Response{
+		id:     id,
+		result: res,
+		err:    err,
+	})
+	return err1
+}
+
+// checkNamespace returns a PermissionDenied error if the method is in a namespace only served over IPC
+// and the request is not from IPC
+func (svr *web3Handler) checkNamespace(ctx context.Context, method string) error {
+	if isIPCRequest(ctx) {
+		return nil
+	}
+	namespace, _, _ := strings.Cut(method, "_")
+	for _, ns := range svr.ipcOnlyNamespaces {
+		if namespace == ns {
+			return status.Errorf(codes.PermissionDenied, "method %s is only available over IPC", method)
+		}
+	}
+	return nil
+}
+
+func (svr *web3Handler) handleWeb3Method(ctx context.Context, web3Req *gjson.Result, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+	var (
+		res interface{}
+		err error
+	)
+	switch web3Req.Get("method").Value() {
 	case "eth_accounts":
 		res, err = svr.ethAccounts()
 	case "eth_gasPrice":
@@ -195,6 +291,10 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.getBlockByNumber(web3Req)
 	case "eth_estimateGas":
 		res, err = svr.estimateGas(web3Req)
+	case "eth_createAccessList":
+		res, err = svr.createAccessList(web3Req)
+	case "eth_simulateV1":
+		res, err = svr.simulateV1(web3Req)
 	case "eth_sendRawTransaction":
 		res, err = svr.sendRawTransaction(web3Req)
 	case "eth_getTransactionByHash":
@@ -207,6 +307,8 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.getBlockTransactionCountByNumber(web3Req)
 	case "eth_getTransactionReceipt":
 		res, err = svr.getTransactionReceipt(web3Req)
+	case "eth_getBlockReceipts":
+		res, err = svr.getBlockReceipts(web3Req)
 	case "eth_getStorageAt":
 		res, err = svr.getStorageAt(web3Req)
 	case "eth_getFilterLogs":
@@ -223,10 +325,18 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		}
 	case "eth_newBlockFilter":
 		res, err = svr.newBlockFilter()
+	case "eth_newPendingTransactionFilter":
+		res, err = svr.newPendingTransactionFilter()
 	case "eth_subscribe":
 		res, err = svr.subscribe(web3Req, writer)
 	case "eth_unsubscribe":
 		res, err = svr.unsubscribe(web3Req)
+	case "debug_getStateDiff":
+		res, err = svr.getStateDiff(web3Req)
+	case "debug_slowQueries":
+		res, err = svr.slowQueries(web3Req)
+	case "iotex_getStakingHistory":
+		res, err = svr.getStakingHistory(web3Req)
 	//TODO: enable debug api after archive mode is supported
 	// case "debug_traceTransaction":
 	// 	res, err = svr.traceTransaction(ctx, web3Req)
@@ -237,32 +347,21 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		"eth_getUncleByBlockNumberAndIndex", "eth_pendingTransactions":
 		res, err = svr.unimplemented()
 	default:
-		res, err = nil, errors.Wrapf(errors.New("web3 method not found"), "method: %s\n", web3Req.Get("method"))
+		res, err = nil, errors.Wrapf(errMethodNotFound, "method: %s\n", web3Req.Get("method"))
 	}
-	if err != nil {
-		log.Logger("api").Debug("web3server",
-			zap.String("requestParams", fmt.Sprintf("%+v", web3Req)),
-			zap.Error(err))
-	} else {
-		log.Logger("api").Debug("web3Debug", zap.String("response", fmt.Sprintf("%+v", res)))
-	}
-	var id any
+	return res, err
+}
+
+func web3RequestID(web3Req *gjson.Result) (any, error) {
 	reqID := web3Req.Get("id")
 	switch reqID.Type {
 	case gjson.String:
-		id = reqID.String()
+		return reqID.String(), nil
 	case gjson.Number:
-		id = reqID.Int()
+		return reqID.Int(), nil
 	default:
-		id = 0
-		res, err = nil, errors.New("invalid id type")
+		return 0, errors.New("invalid id type")
 	}
-	size, err1 = writer.Write(&web3Response{
-		id:     id,
-		result: res,
-		err:    err,
-	})
-	return err1
 }
 
 func parseWeb3Reqs(reader io.Reader) (gjson.Result, error) {
@@ -368,45 +467,65 @@ func (svr *web3Handler) call(in *gjson.Result) (interface{}, error) {
 	if to == _metamaskBalanceContractAddr {
 		return nil, nil
 	}
+	so, hasOverride, err := parseSimulationOverride(in)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	if hasOverride {
+		ctx = evm.WithSimulationOverrideCtx(ctx, so)
+	}
+	ret, receipt, err := svr.callContract(ctx, callerAddr, to, gasLimit, gasPrice, value, data)
+	if err != nil {
+		return nil, err
+	}
+	if receipt != nil && len(receipt.GetExecutionRevertMsg()) > 0 {
+		return "0x" + ret, status.Error(codes.InvalidArgument, "execution reverted: "+receipt.GetExecutionRevertMsg())
+	}
+	return "0x" + ret, nil
+}
+
+// callContract executes the call on the tip state, the receipt is nil for reading the states of staking, rewarding or poll protocol,
+// which ignores the simulation overrides in the context
+func (svr *web3Handler) callContract(ctx context.Context, callerAddr address.Address, to string, gasLimit uint64, gasPrice, value *big.Int, data []byte) (string, *iotextypes.Receipt, error) {
 	if to == address.StakingProtocolAddr {
 		sctx, err := stakingabi.BuildReadStateRequest(data)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		states, err := svr.coreService.ReadState("staking", "", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		ret, err := sctx.EncodeToEth(states)
-		if err != nil {
-			return nil, err
-		}
-		return "0x" + ret, nil
+		return ret, nil, err
 	}
 	if to == address.RewardingProtocol {
 		sctx, err := rewardingabi.BuildReadStateRequest(data)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		states, err := svr.coreService.ReadState("rewarding", "", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		ret, err := sctx.EncodeToEth(states)
+		return ret, nil, err
+	}
+	if to == poll.ProtocolAddr().String() {
+		sctx, err := pollabi.BuildReadStateRequest(data)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
-		return "0x" + ret, nil
+		states, err := svr.coreService.ReadState("poll", "", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
+		if err != nil {
+			return "", nil, err
+		}
+		ret, err := sctx.EncodeToEth(states)
+		return ret, nil, err
 	}
 	exec, _ := action.NewExecution(to, 0, value, gasLimit, gasPrice, data)
-	ret, receipt, err := svr.coreService.ReadContract(context.Background(), callerAddr, exec)
-	if err != nil {
-		return nil, err
-	}
-	if receipt != nil && len(receipt.GetExecutionRevertMsg()) > 0 {
-		return "0x" + ret, status.Error(codes.InvalidArgument, "execution reverted: "+receipt.GetExecutionRevertMsg())
-	}
-	return "0x" + ret, nil
+	return svr.coreService.ReadContract(ctx, callerAddr, exec)
 }
 
 func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
@@ -414,7 +533,14 @@ func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	estimatedGas, err := svr.estimateCallGas(from, to, gasLimit, gasPrice, value, data)
+	if err != nil {
+		return nil, err
+	}
+	return uint64ToHex(estimatedGas), nil
+}
 
+func (svr *web3Handler) estimateCallGas(from address.Address, to string, gasLimit uint64, gasPrice, value *big.Int, data []byte) (uint64, error) {
 	var (
 		tx     *types.Transaction
 		toAddr *common.Address
@@ -422,7 +548,7 @@ func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
 	if len(to) != 0 {
 		addr, err := addrutil.IoAddrToEvmAddr(to)
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
 		toAddr = &addr
 	}
@@ -436,7 +562,7 @@ func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
 	})
 	elp, err := svr.ethTxToEnvelope(tx)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
 	var estimatedGas uint64
@@ -449,12 +575,158 @@ func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
 		estimatedGas, err = svr.coreService.EstimateGasForNonExecution(act)
 	}
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 	if estimatedGas < 21000 {
 		estimatedGas = 21000
 	}
-	return uint64ToHex(estimatedGas), nil
+	return estimatedGas, nil
+}
+
+func (svr *web3Handler) createAccessList(in *gjson.Result) (interface{}, error) {
+	from, to, gasLimit, gasPrice, value, data, err := parseCallObject(in)
+	if err != nil {
+		return nil, err
+	}
+	var list types.AccessList
+	if listStr := in.Get("params.0.accessList"); listStr.Exists() {
+		if err := json.Unmarshal([]byte(listStr.Raw), &list); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "accessList: %s", listStr.Raw)
+		}
+	}
+	exec, err := action.NewExecutionWithAccessList(to, 0, value, gasLimit, gasPrice, data, list)
+	if err != nil {
+		return nil, err
+	}
+	list, receipt, err := svr.coreService.CreateAccessList(context.Background(), from, exec)
+	if err != nil {
+		return nil, err
+	}
+	ret := &createAccessListResult{
+		AccessList: list,
+		GasUsed:    uint64ToHex(receipt.GasConsumed),
+	}
+	if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		if ret.Error = receipt.ExecutionRevertMsg(); ret.Error == "" {
+			ret.Error = fmt.Sprintf("execution failed: status = %d", receipt.Status)
+		}
+	}
+	return ret, nil
+}
+
+// simulateV1 simulates the calls of the block state calls in order on one working set of the tip state, the
+// state overrides of a block are applied before its first call, and the block overrides apply to all its calls
+func (svr *web3Handler) simulateV1(in *gjson.Result) (interface{}, error) {
+	blockStateCalls := in.Get("params.0.blockStateCalls")
+	if !blockStateCalls.IsArray() {
+		return nil, errInvalidFormat
+	}
+	var (
+		calls      []*apitypes.SimulationCall
+		blocks     = make([]*simulateBlockResult, 0, len(blockStateCalls.Array()))
+		callCounts = make([]int, 0, len(blockStateCalls.Array()))
+		// the state overrides of a block without calls are applied before the next call
+		pending evm.StateOverride
+		tip     = svr.coreService.TipHeight()
+		err     error
+	)
+	for i, blockStateCall := range blockStateCalls.Array() {
+		var so evm.SimulationOverride
+		if stateOverrides := blockStateCall.Get("stateOverrides"); stateOverrides.Exists() {
+			if so.State, err = parseStateOverride(stateOverrides); err != nil {
+				return nil, errors.Wrapf(err, "block %d", i)
+			}
+		}
+		if blockOverrides := blockStateCall.Get("blockOverrides"); blockOverrides.Exists() {
+			if so.Block, err = parseBlockOverride(blockOverrides); err != nil {
+				return nil, errors.Wrapf(err, "block %d", i)
+			}
+		}
+		for k, v := range so.State {
+			if pending == nil {
+				pending = make(evm.StateOverride)
+			}
+			pending[k] = v
+		}
+		blockCalls := blockStateCall.Get("calls").Array()
+		for j, call := range blockCalls {
+			from, to, gasLimit, _, value, data, err := parseCallArgs(call)
+			if err != nil {
+				return nil, errors.Wrapf(err, "block %d call %d", i, j)
+			}
+			exec, err := action.NewExecution(to, 0, value, gasLimit, big.NewInt(0), data)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, &apitypes.SimulationCall{
+				Caller:   from,
+				Exec:     exec,
+				Override: &evm.SimulationOverride{State: pending, Block: so.Block},
+			})
+			pending = nil
+		}
+		number := tip + 1
+		if so.Block != nil && so.Block.Number != nil {
+			number = *so.Block.Number
+		}
+		blocks = append(blocks, &simulateBlockResult{Number: uint64ToHex(number)})
+		callCounts = append(callCounts, len(blockCalls))
+	}
+	if len(calls) > _simulateCallsLimit {
+		return nil, errors.Wrapf(errInvalidFormat, "%d calls exceed the limit %d", len(calls), _simulateCallsLimit)
+	}
+	var traceConfig *tracers.TraceConfig
+	if options := in.Get("params.0.traceConfig"); options.Exists() {
+		traceConfig = parseTraceConfig(options)
+	}
+	results, err := svr.coreService.SimulateExecutions(context.Background(), calls, traceConfig)
+	if err != nil {
+		return nil, err
+	}
+	for i, blk := range blocks {
+		var gasUsed uint64
+		blk.Calls = make([]*simulateCallResult, 0, callCounts[i])
+		for _, result := range results[:callCounts[i]] {
+			callResult, err := newSimulateCallResult(result)
+			if err != nil {
+				return nil, err
+			}
+			gasUsed += result.Receipt.GasConsumed
+			blk.Calls = append(blk.Calls, callResult)
+		}
+		blk.GasUsed = uint64ToHex(gasUsed)
+		results = results[callCounts[i]:]
+	}
+	return blocks, nil
+}
+
+func newSimulateCallResult(result *apitypes.SimulationResult) (*simulateCallResult, error) {
+	receipt := result.Receipt
+	logs := make([]*getLogsResult, 0, len(receipt.Logs()))
+	for _, l := range receipt.Logs() {
+		logs = append(logs, &getLogsResult{hash.ZeroHash256, l})
+	}
+	ret := &simulateCallResult{
+		ReturnData: byteToHex(result.ReturnValue),
+		Logs:       logs,
+		GasUsed:    uint64ToHex(receipt.GasConsumed),
+		Status:     uint64ToHex(receipt.Status),
+	}
+	if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		if revert := receipt.ExecutionRevertMsg(); revert != "" {
+			ret.Error = &simulateCallError{Code: 3, Message: "execution reverted: " + revert}
+		} else {
+			ret.Error = &simulateCallError{Code: -32015, Message: fmt.Sprintf("execution failed: status = %d", receipt.Status)}
+		}
+	}
+	if result.Tracer != nil {
+		trace, err := traceResult(result.Tracer, result.ReturnValue, receipt)
+		if err != nil {
+			return nil, err
+		}
+		ret.Trace = trace
+	}
+	return ret, nil
 }
 
 func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error) {
@@ -462,27 +734,34 @@ func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error
 	if !dataStr.Exists() {
 		return nil, errInvalidFormat
 	}
-	// parse raw data string from json request
+	actionHash, err := svr.sendRawTx(dataStr.String())
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + actionHash, nil
+}
+
+// sendRawTx sends the hex encoded raw ethereum transaction, and returns the action hash
+func (svr *web3Handler) sendRawTx(rawString string) (string, error) {
 	var (
-		cs        = svr.coreService
-		rawString = dataStr.String()
-		tx        *types.Transaction
-		encoding  iotextypes.Encoding
-		sig       []byte
-		pubkey    crypto.PublicKey
-		err       error
-		req       *iotextypes.Action
+		cs       = svr.coreService
+		tx       *types.Transaction
+		encoding iotextypes.Encoding
+		sig      []byte
+		pubkey   crypto.PublicKey
+		err      error
+		req      *iotextypes.Action
 	)
 	tx, err = action.DecodeEtherTx(rawString)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	if tx.Protected() && tx.ChainId().Uint64() != uint64(cs.EVMNetworkID()) {
-		return nil, errors.Wrapf(errInvalidEvmChainID, "expect chainID = %d, got %d", cs.EVMNetworkID(), tx.ChainId().Uint64())
+		return "", errors.Wrapf(errInvalidEvmChainID, "expect chainID = %d, got %d", cs.EVMNetworkID(), tx.ChainId().Uint64())
 	}
 	encoding, sig, pubkey, err = action.ExtractTypeSigPubkey(tx)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	if g := cs.Genesis(); g.IsToBeEnabled(cs.TipHeight()) {
 		if strings.HasPrefix(rawString, "0x") || strings.HasPrefix(rawString, "0X") {
@@ -509,7 +788,11 @@ func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error
 	} else {
 		elp, err := svr.ethTxToEnvelope(tx)
 		if err != nil {
-			return nil, err
+			return "", err
+		}
+		if action.IsContainerOnly(elp.Action()) {
+			// the action is only carried by the tx container
+			return "", errUnsupportedAction
 		}
 		req = &iotextypes.Action{
 			Core:         elp.Proto(),
@@ -518,11 +801,7 @@ func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error
 			Encoding:     encoding,
 		}
 	}
-	actionHash, err := cs.SendAction(context.Background(), req)
-	if err != nil {
-		return nil, err
-	}
-	return "0x" + actionHash, nil
+	return cs.SendAction(context.Background(), req)
 }
 
 func (svr *web3Handler) getCode(in *gjson.Result) (interface{}, error) {
@@ -699,6 +978,21 @@ func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, er
 
 }
 
+func (svr *web3Handler) getBlockReceipts(in *gjson.Result) (interface{}, error) {
+	blkParam := in.Get("params.0")
+	if !blkParam.Exists() {
+		return nil, errInvalidFormat
+	}
+	blk, err := svr.blockByNumberOrHash(blkParam)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return svr.getReceiptsOfBlock(blk.Block, blk.Receipts)
+}
+
 func (svr *web3Handler) getBlockTransactionCountByNumber(in *gjson.Result) (interface{}, error) {
 	blkNum := in.Get("params.0")
 	if !blkNum.Exists() {
@@ -836,6 +1130,22 @@ func (svr *web3Handler) newBlockFilter() (interface{}, error) {
 	return "0x" + filterID, nil
 }
 
+func (svr *web3Handler) newPendingTransactionFilter() (interface{}, error) {
+	_, next := svr.coreService.PendingActionHashes(math.MaxUint64)
+	filterObj := filterObject{
+		FilterType: "pendingTransaction",
+		LogHeight:  next,
+	}
+	objInByte, _ := json.Marshal(filterObj)
+	keyHash := hash.Hash256b(objInByte)
+	filterID := hex.EncodeToString(keyHash[:])
+	err := svr.cache.Set(filterID, objInByte)
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + filterID, nil
+}
+
 func (svr *web3Handler) uninstallFilter(in *gjson.Result) (interface{}, error) {
 	id := in.Get("params.0")
 	if !id.Exists() {
@@ -891,6 +1201,14 @@ func (svr *web3Handler) getFilterChanges(in *gjson.Result) (interface{}, error)
 			hashArr = append(hashArr, "0x"+hex.EncodeToString(blkHash[:]))
 		}
 		ret, newLogHeight = hashArr, filterObj.LogHeight+queryCount
+	case "pendingTransaction":
+		// LogHeight is the sequence number of the next pending action
+		actHashes, next := svr.coreService.PendingActionHashes(filterObj.LogHeight)
+		hashArr := make([]string, 0, len(actHashes))
+		for _, actHash := range actHashes {
+			hashArr = append(hashArr, "0x"+hex.EncodeToString(actHash[:]))
+		}
+		ret, newLogHeight = hashArr, next
 	default:
 		return nil, errors.Wrapf(errUnkownType, "filterType: %s", filterObj.FilterType)
 	}
@@ -938,6 +1256,8 @@ func (svr *web3Handler) subscribe(in *gjson.Result, writer apitypes.Web3Response
 			return nil, err
 		}
 		return svr.streamLogs(filter, writer)
+	case "newPendingTransactions":
+		return svr.streamPendingTransactions(in.Get("params.1").Bool(), writer)
 	default:
 		return nil, errInvalidFormat
 	}
@@ -965,6 +1285,21 @@ func (svr *web3Handler) streamLogs(filterObj *filterObject, writer apitypes.Web3
 	return streamID, nil
 }
 
+func (svr *web3Handler) streamPendingTransactions(fullTx bool, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+	var assemble func(*action.SealedEnvelope) (interface{}, error)
+	if fullTx {
+		assemble = func(selp *action.SealedEnvelope) (interface{}, error) {
+			return svr.assemblePendingTransaction(selp)
+		}
+	}
+	chainListener := svr.coreService.ChainListener()
+	streamID, err := chainListener.AddResponder(NewWeb3PendingActionListener(writer.Write, assemble))
+	if err != nil {
+		return nil, err
+	}
+	return streamID, nil
+}
+
 func (svr *web3Handler) unsubscribe(in *gjson.Result) (interface{}, error) {
 	id := in.Get("params.0")
 	if !id.Exists() {
@@ -974,6 +1309,41 @@ func (svr *web3Handler) unsubscribe(in *gjson.Result) (interface{}, error) {
 	return chainListener.RemoveResponder(id.String())
 }
 
+func (svr *web3Handler) getStateDiff(in *gjson.Result) (interface{}, error) {
+	blkNum := in.Get("params.0")
+	if !blkNum.Exists() {
+		return nil, errInvalidFormat
+	}
+	num, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
+	}
+	changes, err := svr.coreService.StateDiff(num)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	optionalHex := func(b []byte) *string {
+		if b == nil {
+			return nil
+		}
+		s := byteToHex(b)
+		return &s
+	}
+	ret := make([]*stateDiffResult, 0, len(changes))
+	for _, change := range changes {
+		ret = append(ret, &stateDiffResult{
+			Namespace: change.Namespace,
+			Key:       byteToHex(change.Key),
+			OldValue:  optionalHex(change.OldValue),
+			NewValue:  optionalHex(change.NewValue),
+		})
+	}
+	return ret, nil
+}
+
 func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result) (interface{}, error) {
 	actHash, options := in.Get("params.0"), in.Get("params.1")
 	if !actHash.Exists() {
@@ -1007,20 +1377,7 @@ func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result)
 	if err != nil {
 		return nil, err
 	}
-	switch tracer := tracer.(type) {
-	case *logger.StructLogger:
-		return &debugTraceTransactionResult{
-			Failed:      receipt.Status != uint64(iotextypes.ReceiptStatus_Success),
-			Revert:      receipt.ExecutionRevertMsg(),
-			ReturnValue: byteToHex(retval),
-			StructLogs:  fromLoggerStructLogs(tracer.StructLogs()),
-			Gas:         receipt.GasConsumed,
-		}, nil
-	case tracers.Tracer:
-		return tracer.GetResult()
-	default:
-		return nil, fmt.Errorf("unknown tracer type: %T", tracer)
-	}
+	return traceResult(tracer, retval, receipt)
 }
 
 func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interface{}, error) {
@@ -1080,6 +1437,105 @@ func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interf
 	if err != nil {
 		return nil, err
 	}
+	return traceResult(tracer, retval, receipt)
+}
+
+// slowQueries returns the slowest recent requests, params.0 is the number of requests, 10 by default
+func (svr *web3Handler) slowQueries(in *gjson.Result) (interface{}, error) {
+	n := _defaultSlowQueries
+	if num := in.Get("params.0"); num.Exists() {
+		if num.Type != gjson.Number || num.Int() <= 0 {
+			return nil, errors.Wrapf(errUnkownType, "number: %s", num.Raw)
+		}
+		n = int(num.Int())
+	}
+	queries := svr.monitor.SlowQueries(n)
+	ret := make([]*slowQueryResult, 0, len(queries))
+	for _, q := range queries {
+		ret = append(ret, &slowQueryResult{
+			Protocol: q.Protocol,
+			Method:   q.Method,
+			Params:   q.Params,
+			Caller:   q.Caller,
+			Duration: q.Duration.String(),
+			Time:     q.Time.UTC().Format(time.RFC3339Nano),
+		})
+	}
+	return ret, nil
+}
+
+func (svr *web3Handler) getStakingHistory(in *gjson.Result) (interface{}, error) {
+	addr := in.Get("params.0")
+	if !addr.Exists() {
+		return nil, errInvalidFormat
+	}
+	owner, err := ethAddrToIoAddr(addr.String())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		offset = uint64(0)
+		limit  = uint64(_defaultStakingHistoryLimit)
+	)
+	if num := in.Get("params.1"); num.Exists() {
+		if offset, err = hexStringToNumber(num.String()); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "offset: %s", num.Raw)
+		}
+	}
+	if num := in.Get("params.2"); num.Exists() {
+		if limit, err = hexStringToNumber(num.String()); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "limit: %s", num.Raw)
+		}
+	}
+	events, total, err := svr.coreService.StakingHistory(owner, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	optionalAddr := func(addr address.Address) *string {
+		if addr == nil {
+			return nil
+		}
+		s := common.BytesToAddress(addr.Bytes()).Hex()
+		return &s
+	}
+	ret := &stakingHistoryResult{
+		Total:  uint64ToHex(total),
+		Events: make([]*stakingEventResult, 0, len(events)),
+	}
+	for _, e := range events {
+		event := &stakingEventResult{
+			Type:            e.Type.String(),
+			BucketIndex:     uint64ToHex(e.BucketIndex),
+			BlockNumber:     uint64ToHex(e.Height),
+			TransactionHash: "0x" + hex.EncodeToString(e.ActionHash[:]),
+			Candidate:       optionalAddr(e.Candidate),
+			Counterparty:    optionalAddr(e.Counterparty),
+			Related:         make([]string, 0, len(e.Related)),
+		}
+		if e.Contract != "" {
+			contract, err := ioAddrToEthAddr(e.Contract)
+			if err != nil {
+				return nil, err
+			}
+			event.Contract = &contract
+		}
+		if e.Amount != nil {
+			amount, err := intStrToHex(e.Amount.String())
+			if err != nil {
+				return nil, err
+			}
+			event.Amount = &amount
+		}
+		for _, index := range e.Related {
+			event.Related = append(event.Related, uint64ToHex(index))
+		}
+		ret.Events = append(ret.Events, event)
+	}
+	return ret, nil
+}
+
+// traceResult returns the result of the tracer of an execution
+func traceResult(tracer any, retval []byte, receipt *action.Receipt) (interface{}, error) {
 	switch tracer := tracer.(type) {
 	case *logger.StructLogger:
 		return &debugTraceTransactionResult{
@@ -72,12 +72,71 @@ type (
 		log       *action.Log
 	}
 
+	createAccessListResult struct {
+		AccessList types.AccessList `json:"accessList"`
+		GasUsed    string           `json:"gasUsed"`
+		Error      string           `json:"error,omitempty"`
+	}
+
+	simulateBlockResult struct {
+		Number  string                `json:"number"`
+		GasUsed string                `json:"gasUsed"`
+		Calls   []*simulateCallResult `json:"calls"`
+	}
+
+	simulateCallResult struct {
+		ReturnData string             `json:"returnData"`
+		Logs       []*getLogsResult   `json:"logs"`
+		GasUsed    string             `json:"gasUsed"`
+		Status     string             `json:"status"`
+		Error      *simulateCallError `json:"error,omitempty"`
+		Trace      interface{}        `json:"trace,omitempty"`
+	}
+
+	simulateCallError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
 	getSyncingResult struct {
 		StartingBlock string `json:"startingBlock"`
 		CurrentBlock  string `json:"currentBlock"`
 		HighestBlock  string `json:"highestBlock"`
 	}
 
+	stateDiffResult struct {
+		Namespace string  `json:"namespace"`
+		Key       string  `json:"key"`
+		OldValue  *string `json:"oldValue"`
+		NewValue  *string `json:"newValue"`
+	}
+
+	stakingHistoryResult struct {
+		Total  string                `json:"total"`
+		Events []*stakingEventResult `json:"events"`
+	}
+
+	stakingEventResult struct {
+		Type            string   `json:"type"`
+		Contract        *string  `json:"contract"`
+		BucketIndex     string   `json:"bucketIndex"`
+		BlockNumber     string   `json:"blockNumber"`
+		TransactionHash string   `json:"transactionHash"`
+		Candidate       *string  `json:"candidate"`
+		Amount          *string  `json:"amount"`
+		Counterparty    *string  `json:"counterparty"`
+		Related         []string `json:"related"`
+	}
+
+	slowQueryResult struct {
+		Protocol string `json:"protocol"`
+		Method   string `json:"method"`
+		Params   string `json:"params"`
+		Caller   string `json:"caller"`
+		Duration string `json:"duration"`
+		Time     string `json:"time"`
+	}
+
 	debugTraceTransactionResult struct {
 		Failed      bool                 `json:"failed"`
 		Revert      string               `json:"revert"`
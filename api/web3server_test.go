@@ -3,8 +3,10 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"math/rand"
 	"net/http"
@@ -14,6 +16,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -23,6 +28,7 @@ import (
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
@@ -30,6 +36,8 @@ import (
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
 	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
@@ -103,7 +111,7 @@ func TestHandlePost(t *testing.T) {
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
 	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
-	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil, nil, nil, nil))
 	getServerResp := func(svr *hTTPHandler, req *http.Request) *httptest.ResponseRecorder {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
@@ -171,7 +179,7 @@ func TestGasPrice(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().SuggestGasPrice().Return(uint64(1), nil)
 	ret, err := web3svr.gasPrice()
 	require.NoError(err)
@@ -187,7 +195,7 @@ func TestGetChainID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
 	ret, err := web3svr.getChainID()
 	require.NoError(err)
@@ -199,7 +207,7 @@ func TestGetBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().TipHeight().Return(uint64(1))
 	ret, err := web3svr.getBlockNumber()
 	require.NoError(err)
@@ -211,7 +219,7 @@ func TestGetBlockByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -262,7 +270,7 @@ func TestGetBalance(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	balance := "111111111111111111"
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{Balance: balance}, nil, nil)
 
@@ -279,7 +287,7 @@ func TestGetTransactionCount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().PendingNonce(gomock.Any()).Return(uint64(2), nil)
 
 	inNil := gjson.Parse(`{"params":[]}`)
@@ -297,7 +305,7 @@ func TestCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	t.Run("to is StakingProtocol addr", func(t *testing.T) {
 		meta := &iotextypes.AccountMeta{
@@ -341,6 +349,22 @@ func TestCall(t *testing.T) {
 		require.Equal("0x0000000000000000000000000000000000000000000000000000000000002710", ret.(string))
 	})
 
+	t.Run("to is PollProtocol addr", func(t *testing.T) {
+		core.EXPECT().ReadState("poll", "", []byte("CandidateHistoryByEpoch"), [][]byte{[]byte("1"), []byte("delegate"), []byte("2")}).Return(&iotexapi.ReadStateResponse{}, nil)
+		in := gjson.Parse(`{"params":[{
+			"from":     "",
+			"to":       "0x166b743c2c1a57c93c2e2bc3e169d28bbb9f6da3",
+			"gas":      "0x4e20",
+			"gasPrice": "0xe8d4a51000",
+			"value":    "0x1",
+			"data":     "efb21f15000000000000000000000000000000000000000000000000000000000000006000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000864656c6567617465000000000000000000000000000000000000000000000000"
+		   },
+		   1]}`)
+		ret, err := web3svr.call(&in)
+		require.NoError(err)
+		require.Equal("0x00000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000000", ret.(string))
+	})
+
 	t.Run("to is contract addr", func(t *testing.T) {
 		core.EXPECT().ReadContract(gomock.Any(), gomock.Any(), gomock.Any()).Return("111111", nil, nil)
 		in := gjson.Parse(`{"params":[{
@@ -388,7 +412,7 @@ func TestEstimateGas(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().ChainID().Return(uint32(1)).Times(2)
 
 	t.Run("estimate execution", func(t *testing.T) {
@@ -428,12 +452,152 @@ func TestEstimateGas(t *testing.T) {
 	})
 }
 
+func TestWeb3CreateAccessList(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
+
+	contract := common.HexToAddress("0x7c13866F9253DEf79e20034eDD011e1d69E67fe5")
+	list := types.AccessList{{Address: contract, StorageKeys: []common.Hash{{}}}}
+	in := gjson.Parse(`{"params":[{
+		"from":       "",
+		"to":         "0x7c13866F9253DEf79e20034eDD011e1d69E67fe5",
+		"data":       "0x6d4ce63c",
+		"accessList": [{"address":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","storageKeys":[]}]
+	   },
+	   "latest"]}`)
+
+	t.Run("success", func(t *testing.T) {
+		core.EXPECT().CreateAccessList(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ address.Address, exec *action.Execution) (types.AccessList, *action.Receipt, error) {
+				// the access list in request is the seed
+				require.Equal(types.AccessList{{Address: contract, StorageKeys: []common.Hash{}}}, exec.AccessList())
+				return list, &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success), GasConsumed: 25000}, nil
+			})
+		ret, err := web3svr.createAccessList(&in)
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		require.JSONEq(`{
+			"accessList":[{"address":"0x7c13866f9253def79e20034edd011e1d69e67fe5","storageKeys":["0x0000000000000000000000000000000000000000000000000000000000000000"]}],
+			"gasUsed":"0x61a8"
+		}`, string(data))
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		core.EXPECT().CreateAccessList(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			types.AccessList{}, &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_ErrExecutionReverted), GasConsumed: 22000}, nil)
+		ret, err := web3svr.createAccessList(&in)
+		require.NoError(err)
+		require.Equal("execution failed: status = 106", ret.(*createAccessListResult).Error)
+	})
+
+	t.Run("invalid access list", func(t *testing.T) {
+		in := gjson.Parse(`{"params":[{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","accessList":"0x1"}]}`)
+		_, err := web3svr.createAccessList(&in)
+		require.ErrorIs(err, errUnkownType)
+	})
+}
+
+func TestSimulateV1(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
+	core.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+
+	contract := common.HexToAddress("0x7c13866F9253DEf79e20034eDD011e1d69E67fe5")
+	in := gjson.Parse(`{"params":[{"blockStateCalls":[
+		{"stateOverrides":{"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5":{"code":"0x6000"}}},
+		{"blockOverrides":{"number":"0x20"},"calls":[
+			{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","data":"0x01"},
+			{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","data":"0x02"}
+		]},
+		{"calls":[{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","data":"0x03"}]}
+	]}, "latest"]}`)
+
+	t.Run("success", func(t *testing.T) {
+		core.EXPECT().SimulateExecutions(gomock.Any(), gomock.Any(), gomock.Nil()).DoAndReturn(
+			func(_ context.Context, calls []*apitypes.SimulationCall, _ *tracers.TraceConfig) ([]*apitypes.SimulationResult, error) {
+				require.Len(calls, 3)
+				// the state overrides of the block without calls are applied before the next call
+				require.Equal([]byte{0x60, 0x00}, calls[0].Override.State[contract].Code)
+				require.Equal(uint64(0x20), *calls[0].Override.Block.Number)
+				require.Nil(calls[1].Override.State)
+				require.Equal(uint64(0x20), *calls[1].Override.Block.Number)
+				require.Nil(calls[2].Override.State)
+				require.Nil(calls[2].Override.Block)
+				results := make([]*apitypes.SimulationResult, 0, len(calls))
+				for i, call := range calls {
+					require.Equal([]byte{byte(i + 1)}, call.Exec.Data())
+					results = append(results, &apitypes.SimulationResult{
+						ReturnValue: []byte{byte(i)},
+						Receipt:     &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success), GasConsumed: 21000},
+					})
+				}
+				results[2].Receipt.Status = uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)
+				return results, nil
+			})
+		ret, err := web3svr.simulateV1(&in)
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		require.JSONEq(`[
+			{"number":"0xb","gasUsed":"0x0","calls":[]},
+			{"number":"0x20","gasUsed":"0xa410","calls":[
+				{"returnData":"0x00","logs":[],"gasUsed":"0x5208","status":"0x1"},
+				{"returnData":"0x01","logs":[],"gasUsed":"0x5208","status":"0x1"}
+			]},
+			{"number":"0xb","gasUsed":"0x5208","calls":[
+				{"returnData":"0x02","logs":[],"gasUsed":"0x5208","status":"0x6a","error":{"code":-32015,"message":"execution failed: status = 106"}}
+			]}
+		]`, string(data))
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		in := gjson.Parse(`{"params":[{"blockStateCalls":[{"calls":[{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"}]}],
+			"traceConfig":{"disableStack":true}}]}`)
+		core.EXPECT().SimulateExecutions(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, calls []*apitypes.SimulationCall, cfg *tracers.TraceConfig) ([]*apitypes.SimulationResult, error) {
+				require.True(cfg.Config.DisableStack)
+				return []*apitypes.SimulationResult{{
+					Receipt: &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)},
+					Tracer:  logger.NewStructLogger(cfg.Config),
+				}}, nil
+			})
+		ret, err := web3svr.simulateV1(&in)
+		require.NoError(err)
+		trace, ok := ret.([]*simulateBlockResult)[0].Calls[0].Trace.(*debugTraceTransactionResult)
+		require.True(ok)
+		require.False(trace.Failed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, params := range []string{
+			`[{}]`,
+			`[{"blockStateCalls":[{"stateOverrides":{"0x12":{}}}]}]`,
+			`[{"blockStateCalls":[{"calls":[{"to":"0x12"}]}]}]`,
+		} {
+			in := gjson.Parse(`{"params":` + params + `}`)
+			_, err := web3svr.simulateV1(&in)
+			require.Error(err, params)
+		}
+		calls := strings.Repeat(`{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"},`, _simulateCallsLimit)
+		in := gjson.Parse(`{"params":[{"blockStateCalls":[{"calls":[` + calls + `{}]}]}]}`)
+		_, err := web3svr.simulateV1(&in)
+		require.ErrorIs(err, errInvalidFormat)
+	})
+}
+
 func TestSendRawTransaction(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().Genesis().Return(genesis.Default)
 	core.EXPECT().TipHeight().Return(uint64(0))
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
@@ -460,7 +624,7 @@ func TestGetCode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	code := "608060405234801561001057600080fd5b50610150806100206contractbytecode"
 	data, _ := hex.DecodeString(code)
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{ContractByteCode: data}, nil, nil)
@@ -484,7 +648,7 @@ func TestGetNodeInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().ServerMeta().Return("111", "", "", "222", "")
 	ret, err := web3svr.getNodeInfo()
 	require.NoError(err)
@@ -496,7 +660,7 @@ func TestGetNetworkID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().EVMNetworkID().Return(uint32(123))
 	ret, err := web3svr.getNetworkID()
 	require.NoError(err)
@@ -508,7 +672,7 @@ func TestIsSyncing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(2), uint64(3))
 	ret, err := web3svr.isSyncing()
 	require.NoError(err)
@@ -524,7 +688,7 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -560,7 +724,7 @@ func TestGetBlockByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -612,7 +776,7 @@ func TestGetTransactionByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -682,7 +846,7 @@ func TestGetLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	logs := []*action.Log{
 		{
@@ -731,7 +895,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -774,12 +938,94 @@ func TestGetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestGetBlockReceipts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
+
+	tsf1, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(identityset.Address(29).String(), identityset.PrivateKey(27), uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	h1, err := tsf1.Hash()
+	require.NoError(err)
+	h2, err := tsf2.Hash()
+	require.NoError(err)
+	receipts := []*action.Receipt{
+		{Status: 1, BlockHeight: 1, ActionHash: h1, GasConsumed: 10000, TxIndex: 0},
+		{Status: 1, BlockHeight: 1, ActionHash: h2, GasConsumed: 10000, TxIndex: 1},
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetVersion(111).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		SetReceipts(receipts).
+		AddActions(tsf1, tsf2).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	blkWithReceipts := &apitypes.BlockWithReceipts{Block: &blk, Receipts: receipts}
+
+	t.Run("nil params", func(t *testing.T) {
+		inNil := gjson.Parse(`{"params":[]}`)
+		_, err := web3svr.getBlockReceipts(&inNil)
+		require.EqualError(err, errInvalidFormat.Error())
+	})
+
+	t.Run("by number", func(t *testing.T) {
+		core.EXPECT().BlockByHeight(uint64(1)).Return(blkWithReceipts, nil)
+		in := gjson.Parse(`{"params":["0x1"]}`)
+		ret, err := web3svr.getBlockReceipts(&in)
+		require.NoError(err)
+		rlt, ok := ret.([]*getReceiptResult)
+		require.True(ok)
+		require.Len(rlt, 2)
+		for i := range rlt {
+			require.Equal(receipts[i], rlt[i].receipt)
+			require.Equal(blkHash, rlt[i].blockHash)
+			require.Equal(identityset.Address(27).String(), rlt[i].from.String())
+		}
+	})
+
+	t.Run("by hash", func(t *testing.T) {
+		core.EXPECT().BlockByHash(hex.EncodeToString(blkHash[:])).Return(blkWithReceipts, nil).Times(2)
+		for _, param := range []string{
+			fmt.Sprintf(`"0x%x"`, blkHash[:]),
+			fmt.Sprintf(`{"blockHash":"0x%x"}`, blkHash[:]),
+		} {
+			in := gjson.Parse(`{"params":[` + param + `]}`)
+			ret, err := web3svr.getBlockReceipts(&in)
+			require.NoError(err)
+			require.Len(ret, 2)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		core.EXPECT().TipHeight().Return(uint64(1))
+		core.EXPECT().BlockByHeight(uint64(1)).Return(nil, ErrNotFound)
+		in := gjson.Parse(`{"params":["latest"]}`)
+		ret, err := web3svr.getBlockReceipts(&in)
+		require.NoError(err)
+		require.Nil(ret)
+	})
+
+	t.Run("mismatched receipts", func(t *testing.T) {
+		core.EXPECT().BlockByHeight(uint64(1)).Return(&apitypes.BlockWithReceipts{Block: &blk, Receipts: receipts[:1]}, nil)
+		in := gjson.Parse(`{"params":[{"blockNumber":"0x1"}]}`)
+		_, err := web3svr.getBlockReceipts(&in)
+		require.ErrorIs(err, errInvalidBlock)
+	})
+}
+
 func TestGetBlockTransactionCountByNumber(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -814,7 +1060,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -861,7 +1107,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -907,7 +1153,7 @@ func TestGetStorageAt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 	val := []byte("test")
 	core.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
 
@@ -922,7 +1168,7 @@ func TestNewfilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	ret, err := web3svr.newFilter(&filterObject{
 		FromBlock: "1",
@@ -939,7 +1185,7 @@ func TestNewBlockFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().TipHeight().Return(uint64(123))
 
 	ret, err := web3svr.newBlockFilter()
@@ -947,12 +1193,41 @@ func TestNewBlockFilter(t *testing.T) {
 	require.Equal("0x4c6ace15a9c5b9d3c89e786b7b6dfaf1bdc5807b8d7da0292db94d473f349101", ret.(string))
 }
 
+func TestNewPendingTransactionFilter(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil, nil, nil, nil}
+	core.EXPECT().PendingActionHashes(uint64(math.MaxUint64)).Return(nil, uint64(5))
+
+	ret, err := web3svr.newPendingTransactionFilter()
+	require.NoError(err)
+	filterObj, err := loadFilterFromCache(web3svr.cache, util.Remove0xPrefix(ret.(string)))
+	require.NoError(err)
+	require.Equal("pendingTransaction", filterObj.FilterType)
+	require.EqualValues(5, filterObj.LogHeight)
+
+	// poll the pending transactions since the filter is created
+	actHash := hash.Hash256b([]byte("_action1"))
+	core.EXPECT().TipHeight().Return(uint64(0)).Times(2)
+	core.EXPECT().PendingActionHashes(uint64(5)).Return([]hash.Hash256{actHash}, uint64(6))
+	core.EXPECT().PendingActionHashes(uint64(6)).Return(nil, uint64(6))
+	in := gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, ret.(string)))
+	changes, err := web3svr.getFilterChanges(&in)
+	require.NoError(err)
+	require.Equal([]string{"0x" + hex.EncodeToString(actHash[:])}, changes)
+	changes, err = web3svr.getFilterChanges(&in)
+	require.NoError(err)
+	require.Empty(changes)
+}
+
 func TestUninstallFilter(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	require.NoError(web3svr.cache.Set("123456789abc", []byte("test")))
 
@@ -975,7 +1250,7 @@ func TestGetFilterChanges(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil, nil, nil, nil}
 	core.EXPECT().TipHeight().Return(uint64(0)).Times(3)
 
 	t.Run("log filterType", func(t *testing.T) {
@@ -1060,7 +1335,7 @@ func TestGetFilterLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	logs := []*action.Log{
 		{
@@ -1116,11 +1391,11 @@ func TestSubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
-	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(3)
-	core.EXPECT().ChainListener().Return(listener).Times(3)
+	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(5)
+	core.EXPECT().ChainListener().Return(listener).Times(5)
 	writer := mock_apitypes.NewMockWeb3ResponseWriter(ctrl)
 
 	t.Run("newHeads subscription", func(t *testing.T) {
@@ -1144,6 +1419,17 @@ func TestSubscribe(t *testing.T) {
 		require.Equal("streamid_1", ret.(string))
 	})
 
+	t.Run("newPendingTransactions subscription", func(t *testing.T) {
+		in := gjson.Parse(`{"params":["newPendingTransactions"]}`)
+		ret, err := web3svr.subscribe(&in, writer)
+		require.NoError(err)
+		require.Equal("streamid_1", ret.(string))
+		in = gjson.Parse(`{"params":["newPendingTransactions",true]}`)
+		ret, err = web3svr.subscribe(&in, writer)
+		require.NoError(err)
+		require.Equal("streamid_1", ret.(string))
+	})
+
 	t.Run("nil params", func(t *testing.T) {
 		inNil := gjson.Parse(`{"params":[]}`)
 		_, err := web3svr.subscribe(&inNil, writer)
@@ -1162,7 +1448,7 @@ func TestUnsubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	listener.EXPECT().RemoveResponder(gomock.Any()).Return(true, nil)
@@ -1202,7 +1488,7 @@ func TestDebugTraceTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1236,12 +1522,98 @@ func TestDebugTraceTransaction(t *testing.T) {
 	})
 }
 
+func TestDebugGetStateDiff(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
+
+	t.Run("nil params", func(t *testing.T) {
+		inNil := gjson.Parse(`{"params":[]}`)
+		_, err := web3svr.getStateDiff(&inNil)
+		require.EqualError(err, errInvalidFormat.Error())
+	})
+
+	t.Run("state diff", func(t *testing.T) {
+		core.EXPECT().StateDiff(uint64(1)).Return([]*factory.StateChange{
+			{Namespace: "Account", Key: []byte{1}, NewValue: []byte{2}},
+			{Namespace: "Contract", Key: []byte{3}, OldValue: []byte{4}},
+		}, nil)
+		in := gjson.Parse(`{"params":["0x1"]}`)
+		ret, err := web3svr.getStateDiff(&in)
+		require.NoError(err)
+		rlt, ok := ret.([]*stateDiffResult)
+		require.True(ok)
+		require.Len(rlt, 2)
+		require.Equal("Account", rlt[0].Namespace)
+		require.Equal("0x01", rlt[0].Key)
+		require.Nil(rlt[0].OldValue)
+		require.Equal("0x02", *rlt[0].NewValue)
+		require.Equal("0x04", *rlt[1].OldValue)
+		require.Nil(rlt[1].NewValue)
+	})
+
+	t.Run("not indexed", func(t *testing.T) {
+		core.EXPECT().StateDiff(uint64(2)).Return(nil, errors.Wrap(ErrNotFound, "not indexed"))
+		in := gjson.Parse(`{"params":["0x2"]}`)
+		ret, err := web3svr.getStateDiff(&in)
+		require.NoError(err)
+		require.Nil(ret)
+	})
+}
+
+func TestGetStakingHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
+
+	t.Run("nil params", func(t *testing.T) {
+		inNil := gjson.Parse(`{"params":[]}`)
+		_, err := web3svr.getStakingHistory(&inNil)
+		require.EqualError(err, errInvalidFormat.Error())
+	})
+
+	t.Run("staking history", func(t *testing.T) {
+		owner, contract, cand := identityset.Address(1), identityset.Address(30), identityset.Address(2)
+		core.EXPECT().StakingHistory(owner, uint64(2), uint64(_defaultStakingHistoryLimit)).Return([]*blockindex.StakingEvent{
+			{Type: blockindex.StakingEventCreate, BucketIndex: 3, Height: 10, Candidate: cand, Amount: big.NewInt(100)},
+			{Type: blockindex.StakingEventMerge, Contract: contract.String(), BucketIndex: 4, Height: 11, Related: []uint64{5}},
+		}, uint64(4), nil)
+		in := gjson.Parse(fmt.Sprintf(`{"params":["%s", "0x2"]}`, owner.Hex()))
+		ret, err := web3svr.getStakingHistory(&in)
+		require.NoError(err)
+		rlt, ok := ret.(*stakingHistoryResult)
+		require.True(ok)
+		require.Equal("0x4", rlt.Total)
+		require.Len(rlt.Events, 2)
+		require.Equal("create", rlt.Events[0].Type)
+		require.Nil(rlt.Events[0].Contract)
+		require.Equal("0x3", rlt.Events[0].BucketIndex)
+		require.Equal("0xa", rlt.Events[0].BlockNumber)
+		require.Equal(cand.Hex(), strings.ToLower(*rlt.Events[0].Candidate))
+		require.Equal("0x64", *rlt.Events[0].Amount)
+		require.Equal("merge", rlt.Events[1].Type)
+		require.Equal(contract.Hex(), strings.ToLower(*rlt.Events[1].Contract))
+		require.Nil(rlt.Events[1].Amount)
+		require.Equal([]string{"0x5"}, rlt.Events[1].Related)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		in := gjson.Parse(fmt.Sprintf(`{"params":["%s", "0x0", "xyz"]}`, identityset.Address(1).Hex()))
+		_, err := web3svr.getStakingHistory(&in)
+		require.ErrorIs(err, errUnkownType)
+	})
+}
+
 func TestDebugTraceCall(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1274,7 +1646,7 @@ func TestResponseIDMatchTypeWithRequest(t *testing.T) {
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
 	core.EXPECT().TipHeight().Return(uint64(1)).AnyTimes()
 	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
-	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil, nil, nil, nil))
 	getServerResp := func(svr *hTTPHandler, req *http.Request) *httptest.ResponseRecorder {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
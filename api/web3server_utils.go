@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/go-redis/redis/v8"
 	"github.com/iotexproject/go-pkgs/cache/ttl"
@@ -25,6 +27,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	logfilter "github.com/iotexproject/iotex-core/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -96,6 +99,39 @@ func (svr *web3Handler) getBlockWithTransactions(blk *block.Block, receipts []*a
 	}, nil
 }
 
+func (svr *web3Handler) getReceiptsOfBlock(blk *block.Block, receipts []*action.Receipt) ([]*getReceiptResult, error) {
+	if blk == nil || len(receipts) != len(blk.Actions) {
+		return nil, errInvalidBlock
+	}
+	var (
+		blkHash      = blk.HashBlock()
+		logsBloomStr string
+		ret          = make([]*getReceiptResult, 0, len(receipts))
+	)
+	if logsBloom := blk.LogsBloomfilter(); logsBloom != nil {
+		logsBloomStr = hex.EncodeToString(logsBloom.Bytes())
+	}
+	for i, selp := range blk.Actions {
+		to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipts[i])
+		if err != nil {
+			// the same actions are skipped as in the transactions of the block
+			if errors.Cause(err) == errUnsupportedAction {
+				continue
+			}
+			return nil, err
+		}
+		ret = append(ret, &getReceiptResult{
+			blockHash:       blkHash,
+			from:            selp.SenderAddress(),
+			to:              to,
+			contractAddress: contractAddr,
+			logsBloom:       logsBloomStr,
+			receipt:         receipts[i],
+		})
+	}
+	return ret, nil
+}
+
 func (svr *web3Handler) assembleConfirmedTransaction(blkHash hash.Hash256, selp *action.SealedEnvelope, receipt *action.Receipt) (*getTransactionResult, error) {
 	// sanity check
 	if receipt == nil {
@@ -154,6 +190,108 @@ func (svr *web3Handler) parseBlockRange(fromStr string, toStr string) (from uint
 	return
 }
 
+// blockByNumberOrHash returns the block of a block number, tag or hash, which is either a
+// string or an object with the field blockNumber or blockHash
+func (svr *web3Handler) blockByNumberOrHash(in gjson.Result) (*apitypes.BlockWithReceipts, error) {
+	in = unwrapBlockNumberOrHash(in)
+	if in.Type != gjson.String {
+		return nil, errInvalidFormat
+	}
+	if isBlockHash(in.String()) {
+		return svr.coreService.BlockByHash(util.Remove0xPrefix(in.String()))
+	}
+	num, err := svr.parseBlockNumber(in.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "block: %s", in.String())
+	}
+	return svr.coreService.BlockByHeight(num)
+}
+
+func unwrapBlockNumberOrHash(in gjson.Result) gjson.Result {
+	if !in.IsObject() {
+		return in
+	}
+	if blkHash := in.Get("blockHash"); blkHash.Exists() {
+		return blkHash
+	}
+	return in.Get("blockNumber")
+}
+
+func isBlockHash(str string) bool {
+	return len(util.Remove0xPrefix(str)) == 2*len(hash.ZeroHash256)
+}
+
+// responseCacheKey returns the cache key of the request if its response is immutable, i.e. it
+// queries a block by an explicit number, a receipt, or the logs of a closed range of final blocks.
+// The key of a query by number contains the hash of the block at that number, so a response is
+// never served again once its block is reverted
+func (svr *web3Handler) responseCacheKey(method string, in *gjson.Result) (string, bool) {
+	if svr.respCache == nil {
+		return "", false
+	}
+	finalHeight := func(num gjson.Result) (uint64, bool) {
+		if !strings.HasPrefix(num.String(), "0x") {
+			return 0, false
+		}
+		height, err := hexStringToNumber(num.String())
+		return height, err == nil && height <= svr.coreService.TipHeight()
+	}
+	finalHash := func(num gjson.Result) (string, bool) {
+		height, ok := finalHeight(num)
+		if !ok {
+			return "", false
+		}
+		h, err := svr.coreService.BlockHashByBlockHeight(height)
+		if err != nil {
+			return "", false
+		}
+		return hex.EncodeToString(h[:]), true
+	}
+	var (
+		blkHash string
+		ok      = true
+	)
+	switch method {
+	case "eth_getBlockByNumber":
+		blkHash, ok = finalHash(in.Get("params.0"))
+	case "eth_getTransactionReceipt":
+		// a receipt is only returned once the action is in a final block, its block is checked
+		// on a cache hit by isCachedResponseValid
+	case "eth_getBlockReceipts":
+		if blk := unwrapBlockNumberOrHash(in.Get("params.0")); !isBlockHash(blk.String()) {
+			blkHash, ok = finalHash(blk)
+		}
+	case "eth_getLogs":
+		if in.Get("params.0.blockHash").Exists() {
+			return "", false
+		}
+		if _, ok = finalHeight(in.Get("params.0.fromBlock")); ok {
+			// the hash of the last block commits to all blocks of the range
+			blkHash, ok = finalHash(in.Get("params.0.toBlock"))
+		}
+	default:
+		return "", false
+	}
+	if !ok {
+		return "", false
+	}
+	return ResponseCacheKey(method, []byte(in.Get("params|@ugly").Raw+blkHash)), true
+}
+
+// isCachedResponseValid checks that a cached receipt still belongs to the block on the chain
+func (svr *web3Handler) isCachedResponseValid(method string, data []byte) bool {
+	if method != "eth_getTransactionReceipt" {
+		return true
+	}
+	res := gjson.ParseBytes(data)
+	height, err := hexStringToNumber(res.Get("blockNumber").String())
+	if err != nil {
+		return false
+	}
+	h, err := svr.coreService.BlockHashByBlockHeight(height)
+	return err == nil && "0x"+hex.EncodeToString(h[:]) == res.Get("blockHash").String()
+}
+
 func (svr *web3Handler) ethTxToEnvelope(tx *types.Transaction) (action.Envelope, error) {
 	to := ""
 	if tx.To() != nil {
@@ -167,6 +305,9 @@ func (svr *web3Handler) ethTxToEnvelope(tx *types.Transaction) (action.Envelope,
 	if to == address.RewardingProtocol {
 		return elpBuilder.BuildRewardingAction(tx)
 	}
+	if to == action.GovernanceProtocolAddr().String() {
+		return elpBuilder.BuildGovernanceAction(tx)
+	}
 	isContract, err := svr.checkContractAddr(to)
 	if err != nil {
 		return nil, err
@@ -269,6 +410,11 @@ func parseLogRequest(in gjson.Result) (*filterObject, error) {
 }
 
 func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.Int, *big.Int, []byte, error) {
+	return parseCallArgs(in.Get("params.0"))
+}
+
+// parseCallArgs parses the fields of a call object
+func parseCallArgs(call gjson.Result) (address.Address, string, uint64, *big.Int, *big.Int, []byte, error) {
 	var (
 		from     address.Address
 		to       string
@@ -278,7 +424,7 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 		data     []byte
 		err      error
 	)
-	fromStr := in.Get("params.0.from").String()
+	fromStr := call.Get("from").String()
 	if fromStr == "" {
 		fromStr = "0x0000000000000000000000000000000000000000"
 	}
@@ -286,7 +432,7 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 		return nil, "", 0, nil, nil, nil, err
 	}
 
-	toStr := in.Get("params.0.to").String()
+	toStr := call.Get("to").String()
 	if toStr != "" {
 		ioAddr, err := ethAddrToIoAddr(toStr)
 		if err != nil {
@@ -295,14 +441,14 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 		to = ioAddr.String()
 	}
 
-	gasStr := in.Get("params.0.gas").String()
+	gasStr := call.Get("gas").String()
 	if gasStr != "" {
 		if gasLimit, err = hexStringToNumber(gasStr); err != nil {
 			return nil, "", 0, nil, nil, nil, err
 		}
 	}
 
-	gasPriceStr := in.Get("params.0.gasPrice").String()
+	gasPriceStr := call.Get("gasPrice").String()
 	if gasPriceStr != "" {
 		var ok bool
 		if gasPrice, ok = new(big.Int).SetString(util.Remove0xPrefix(gasPriceStr), 16); !ok {
@@ -310,7 +456,7 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 		}
 	}
 
-	valStr := in.Get("params.0.value").String()
+	valStr := call.Get("value").String()
 	if valStr != "" {
 		var ok bool
 		if value, ok = new(big.Int).SetString(util.Remove0xPrefix(valStr), 16); !ok {
@@ -318,15 +464,165 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 		}
 	}
 
-	input := in.Get("params.0.input")
+	input := call.Get("input")
 	if input.Exists() {
 		data = common.FromHex(input.String())
 	} else {
-		data = common.FromHex(in.Get("params.0.data").String())
+		data = common.FromHex(call.Get("data").String())
 	}
 	return from, to, gasLimit, gasPrice, value, data, nil
 }
 
+// parseSimulationOverride parses the state override set in params.2 and the block overrides in params.3
+// of eth_call, the returned bool is false if neither is given
+func parseSimulationOverride(in *gjson.Result) (evm.SimulationOverride, bool, error) {
+	var (
+		so       evm.SimulationOverride
+		err      error
+		hasState = in.Get("params.2").IsObject()
+		hasBlock = in.Get("params.3").IsObject()
+	)
+	if !hasState && !hasBlock {
+		return so, false, nil
+	}
+	if hasState {
+		if so.State, err = parseStateOverride(in.Get("params.2")); err != nil {
+			return so, false, err
+		}
+	}
+	if hasBlock {
+		if so.Block, err = parseBlockOverride(in.Get("params.3")); err != nil {
+			return so, false, err
+		}
+	}
+	return so, true, nil
+}
+
+// parseStateOverride parses a state override set keyed by the account addresses
+func parseStateOverride(in gjson.Result) (evm.StateOverride, error) {
+	var (
+		so  = make(evm.StateOverride)
+		err error
+	)
+	in.ForEach(func(key, value gjson.Result) bool {
+		if !common.IsHexAddress(key.String()) {
+			err = errors.Wrapf(errUnkownType, "address: %s", key.String())
+			return false
+		}
+		var override evm.AccountOverride
+		if override, err = parseAccountOverride(&value); err != nil {
+			err = errors.Wrapf(err, "account %s", key.String())
+			return false
+		}
+		so[common.HexToAddress(key.String())] = override
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return so, nil
+}
+
+// parseBlockOverride parses the overrides of the number, time and coinbase of a block
+func parseBlockOverride(in gjson.Result) (*evm.BlockOverride, error) {
+	bo := &evm.BlockOverride{}
+	if num := in.Get("number"); num.Exists() {
+		n, err := hexStringToNumber(num.String())
+		if err != nil {
+			return nil, errors.Wrapf(errUnkownType, "number: %s", num.String())
+		}
+		bo.Number = &n
+	}
+	if ts := in.Get("time"); ts.Exists() {
+		t, err := hexStringToNumber(ts.String())
+		if err != nil {
+			return nil, errors.Wrapf(errUnkownType, "time: %s", ts.String())
+		}
+		bo.Time = &t
+	}
+	if coinbase := in.Get("coinbase"); coinbase.Exists() {
+		if !common.IsHexAddress(coinbase.String()) {
+			return nil, errors.Wrapf(errUnkownType, "coinbase: %s", coinbase.String())
+		}
+		addr := common.HexToAddress(coinbase.String())
+		bo.Coinbase = &addr
+	}
+	return bo, nil
+}
+
+func parseAccountOverride(in *gjson.Result) (evm.AccountOverride, error) {
+	var override evm.AccountOverride
+	if balance := in.Get("balance"); balance.Exists() {
+		b, ok := new(big.Int).SetString(util.Remove0xPrefix(balance.String()), 16)
+		if !ok {
+			return override, errors.Wrapf(errUnkownType, "balance: %s", balance.String())
+		}
+		override.Balance = b
+	}
+	if nonce := in.Get("nonce"); nonce.Exists() {
+		n, err := hexStringToNumber(nonce.String())
+		if err != nil {
+			return override, errors.Wrapf(errUnkownType, "nonce: %s", nonce.String())
+		}
+		override.Nonce = &n
+	}
+	if code := in.Get("code"); code.Exists() {
+		override.Code = common.FromHex(code.String())
+		if override.Code == nil {
+			override.Code = []byte{}
+		}
+	}
+	parseStorage := func(field string) (map[common.Hash]common.Hash, error) {
+		storage := in.Get(field)
+		if !storage.Exists() {
+			return nil, nil
+		}
+		if !storage.IsObject() {
+			return nil, errors.Wrapf(errUnkownType, "%s: %s", field, storage.Raw)
+		}
+		slots := make(map[common.Hash]common.Hash)
+		for k, v := range storage.Map() {
+			slots[common.HexToHash(k)] = common.HexToHash(v.String())
+		}
+		return slots, nil
+	}
+	var err error
+	if override.State, err = parseStorage("state"); err != nil {
+		return override, err
+	}
+	if override.StateDiff, err = parseStorage("stateDiff"); err != nil {
+		return override, err
+	}
+	if override.State != nil && override.StateDiff != nil {
+		return override, errors.Wrap(errUnkownType, "both state and stateDiff are set")
+	}
+	return override, nil
+}
+
+// parseTraceConfig parses the trace options in the format of debug_traceCall
+func parseTraceConfig(options gjson.Result) *tracers.TraceConfig {
+	cfg := &tracers.TraceConfig{
+		Config: &logger.Config{
+			EnableMemory:     options.Get("enableMemory").Bool(),
+			DisableStack:     options.Get("disableStack").Bool(),
+			DisableStorage:   options.Get("disableStorage").Bool(),
+			EnableReturnData: options.Get("enableReturnData").Bool(),
+		},
+	}
+	if tracer := options.Get("tracer"); tracer.Exists() {
+		cfg.Tracer = new(string)
+		*cfg.Tracer = tracer.String()
+		if tracerConfig := options.Get("tracerConfig"); tracerConfig.Exists() {
+			cfg.TracerConfig = json.RawMessage(tracerConfig.Raw)
+		}
+	}
+	if timeout := options.Get("timeout"); timeout.Exists() {
+		cfg.Timeout = new(string)
+		*cfg.Timeout = timeout.String()
+	}
+	return cfg
+}
+
 func (svr *web3Handler) getLogQueryRange(fromStr, toStr string, logHeight uint64) (from uint64, to uint64, hasNewLogs bool, err error) {
 	if from, to, err = svr.parseBlockRange(fromStr, toStr); err != nil {
 		return
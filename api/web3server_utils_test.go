@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
@@ -110,12 +111,61 @@ func TestParseCallObject(t *testing.T) {
 
 }
 
+func TestParseSimulationOverride(t *testing.T) {
+	require := require.New(t)
+
+	in := gjson.Parse(`{"params":[{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"}, "latest"]}`)
+	_, ok, err := parseSimulationOverride(&in)
+	require.NoError(err)
+	require.False(ok)
+
+	in = gjson.Parse(`{"params":[{"to":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"}, "latest", {
+		"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5": {
+			"balance": "0x10",
+			"nonce": "0x2",
+			"code": "0x6000",
+			"stateDiff": {"0x01": "0x02"}
+		},
+		"0x0000000000000000000000000000000000000001": {"state": {}}
+	}, {"number": "0x64", "time": "0x65", "coinbase": "0x00000000000000000000000000000000000000ff"}]}`)
+	so, ok, err := parseSimulationOverride(&in)
+	require.NoError(err)
+	require.True(ok)
+	require.Len(so.State, 2)
+	override := so.State[common.HexToAddress("0x7c13866F9253DEf79e20034eDD011e1d69E67fe5")]
+	require.Equal(big.NewInt(16), override.Balance)
+	require.Equal(uint64(2), *override.Nonce)
+	require.Equal([]byte{0x60, 0x00}, override.Code)
+	require.Nil(override.State)
+	require.Equal(map[common.Hash]common.Hash{common.HexToHash("0x01"): common.HexToHash("0x02")}, override.StateDiff)
+	override = so.State[common.HexToAddress("0x0000000000000000000000000000000000000001")]
+	require.Nil(override.Balance)
+	require.Nil(override.Nonce)
+	require.Nil(override.Code)
+	require.NotNil(override.State)
+	require.Empty(override.State)
+	require.Equal(uint64(100), *so.Block.Number)
+	require.Equal(uint64(101), *so.Block.Time)
+	require.Equal(common.HexToAddress("0xff"), *so.Block.Coinbase)
+
+	for _, params := range []string{
+		`[{}, "latest", {"0x1234": {}}]`,
+		`[{}, "latest", {"0x0000000000000000000000000000000000000001": {"balance": "0xzz"}}]`,
+		`[{}, "latest", {"0x0000000000000000000000000000000000000001": {"state": {}, "stateDiff": {}}}]`,
+		`[{}, "latest", {}, {"coinbase": "0x12"}]`,
+	} {
+		in := gjson.Parse(`{"params":` + params + `}`)
+		_, _, err := parseSimulationOverride(&in)
+		require.ErrorIs(err, errUnkownType, params)
+	}
+}
+
 func TestParseBlockNumber(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil, nil, nil, nil}
 
 	t.Run("earliest block number", func(t *testing.T) {
 		num, _ := web3svr.parseBlockNumber("earliest")
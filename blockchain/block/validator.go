@@ -19,6 +19,12 @@ type Validator interface {
 	Validate(ctx context.Context, block *Block) error
 }
 
+// Prefetcher is the interface of a validator which could warm up the states that a block is going to read
+type Prefetcher interface {
+	// Prefetch starts loading the states of the given block in background
+	Prefetch(ctx context.Context, block *Block)
+}
+
 type validator struct {
 	subValidator Validator
 	validators   []action.SealedEnvelopeValidator
@@ -31,6 +37,10 @@ func NewValidator(subsequenceValidator Validator, validators ...action.SealedEnv
 
 func (v *validator) Validate(ctx context.Context, blk *Block) error {
 	actions := blk.Actions
+	if p, ok := v.subValidator.(Prefetcher); ok {
+		// keeps running while the block is executed, and is cancelled when the block is committed
+		p.Prefetch(ctx, blk)
+	}
 	// Verify transfers, votes, executions, witness, and secrets
 	errChan := make(chan error, len(actions))
 
@@ -53,6 +53,11 @@ type (
 		FooterByHeight(uint64) (*block.Footer, error)
 	}
 
+	// BlockRemover removes the blocks above a target height, as well as the indexes of the blocks
+	BlockRemover interface {
+		DeleteBlockToTarget(context.Context, uint64) error
+	}
+
 	blockDAO struct {
 		blockStore   BlockDAO
 		indexers     []BlockIndexer
@@ -278,6 +283,61 @@ func (dao *blockDAO) PutBlock(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
+// DeleteBlockToTarget deletes the blocks above the target height one by one from the tip. Each block is
+// removed from the indexers in the reverse order of PutBlock, and then from the block store
+func (dao *blockDAO) DeleteBlockToTarget(ctx context.Context, targetHeight uint64) error {
+	store, ok := dao.blockStore.(interface{ DeleteTipBlock() error })
+	if !ok {
+		return errors.Errorf("block store %T does not support deleting blocks", dao.blockStore)
+	}
+	tipHeight, err := dao.blockStore.Height()
+	if err != nil {
+		return err
+	}
+	for ; tipHeight > targetHeight; tipHeight-- {
+		blk, err := dao.blockStore.GetBlockByHeight(tipHeight)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d", tipHeight)
+		}
+		if blk.Receipts == nil {
+			if blk.Receipts, err = dao.blockStore.GetReceipts(tipHeight); err != nil {
+				return errors.Wrapf(err, "failed to get receipts of block %d", tipHeight)
+			}
+		}
+		for i := len(dao.indexers) - 1; i >= 0; i-- {
+			indexer := dao.indexers[i]
+			height, err := indexer.Height()
+			if err != nil {
+				return err
+			}
+			if tipHeight > height {
+				// the block has not been indexed by the indexer
+				continue
+			}
+			if err := indexer.DeleteTipBlock(ctx, blk); err != nil {
+				return errors.Wrapf(err, "failed to delete block %d from indexer %d", tipHeight, i)
+			}
+		}
+		if err := store.DeleteTipBlock(); err != nil {
+			return errors.Wrapf(err, "failed to delete block %d", tipHeight)
+		}
+		atomic.StoreUint64(&dao.tipHeight, tipHeight-1)
+		dao.removeFromCache(blk)
+		log.L().Info("Deleted block.", zap.Uint64("height", tipHeight))
+	}
+	return nil
+}
+
+func (dao *blockDAO) removeFromCache(blk *block.Block) {
+	h := blk.HashBlock()
+	for _, c := range []cache.LRUCache{dao.headerCache, dao.footerCache, dao.receiptCache, dao.blockCache} {
+		if c != nil {
+			c.Remove(blk.Height())
+			c.Remove(h)
+		}
+	}
+}
+
 func lruCacheGet(c cache.LRUCache, key interface{}) (interface{}, bool) {
 	if c != nil {
 		return c.Get(key)
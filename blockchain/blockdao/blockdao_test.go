@@ -574,6 +574,79 @@ func Test_blockDAO_PutBlock(t *testing.T) {
 	})
 }
 
+func Test_blockDAO_DeleteBlockToTarget(t *testing.T) {
+	r := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	store, err := filedao.NewFileDAOInMemForTest()
+	r.NoError(err)
+	r.NoError(store.Start(ctx))
+	defer func() {
+		r.NoError(store.Stop(ctx))
+	}()
+	blks := getTestBlocks(t)
+	for _, blk := range blks {
+		r.NoError(store.PutBlock(ctx, blk))
+	}
+	indexer1 := mock_blockdao.NewMockBlockIndexer(ctrl)
+	indexer2 := mock_blockdao.NewMockBlockIndexer(ctrl)
+	dao := &blockDAO{
+		blockStore:  store,
+		indexers:    []BlockIndexer{indexer1, indexer2},
+		headerCache: cache.NewThreadSafeLruCache(8),
+	}
+	header, err := dao.HeaderByHeight(3)
+	r.NoError(err)
+	r.Equal(blks[2].HashBlock(), header.HashBlock())
+
+	t.Run("FailedToDeleteFromIndexer", func(t *testing.T) {
+		indexer2.EXPECT().Height().Return(uint64(3), nil).Times(1)
+		indexer2.EXPECT().DeleteTipBlock(gomock.Any(), gomock.Any()).Return(errors.New(t.Name())).Times(1)
+
+		r.ErrorContains(dao.DeleteBlockToTarget(ctx, 1), t.Name())
+		height, err := dao.Height()
+		r.NoError(err)
+		r.Equal(uint64(3), height)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		deleted := map[BlockIndexer][]uint64{}
+		deleteTipBlock := func(indexer BlockIndexer) func(context.Context, *block.Block) error {
+			return func(_ context.Context, blk *block.Block) error {
+				deleted[indexer] = append(deleted[indexer], blk.Height())
+				return nil
+			}
+		}
+		gomock.InOrder(
+			// indexer2 has not indexed block 3
+			indexer2.EXPECT().Height().Return(uint64(2), nil).Times(1),
+			indexer1.EXPECT().Height().Return(uint64(3), nil).Times(1),
+			indexer1.EXPECT().DeleteTipBlock(gomock.Any(), gomock.Any()).DoAndReturn(deleteTipBlock(indexer1)).Times(1),
+			indexer2.EXPECT().Height().Return(uint64(2), nil).Times(1),
+			indexer2.EXPECT().DeleteTipBlock(gomock.Any(), gomock.Any()).DoAndReturn(deleteTipBlock(indexer2)).Times(1),
+			indexer1.EXPECT().Height().Return(uint64(2), nil).Times(1),
+			indexer1.EXPECT().DeleteTipBlock(gomock.Any(), gomock.Any()).DoAndReturn(deleteTipBlock(indexer1)).Times(1),
+		)
+
+		r.NoError(dao.DeleteBlockToTarget(ctx, 1))
+		r.Equal([]uint64{3, 2}, deleted[indexer1])
+		r.Equal([]uint64{2}, deleted[indexer2])
+		height, err := dao.Height()
+		r.NoError(err)
+		r.Equal(uint64(1), height)
+		_, err = dao.HeaderByHeight(3)
+		r.Error(err)
+		// the deleted blocks could be put again
+		r.NoError(store.PutBlock(ctx, blks[1]))
+		header, err := dao.HeaderByHeight(2)
+		r.NoError(err)
+		r.Equal(blks[1].HashBlock(), header.HashBlock())
+	})
+}
+
 func Test_lruCache(t *testing.T) {
 	r := require.New(t)
 
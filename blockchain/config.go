@@ -35,6 +35,9 @@ type (
 		SGDIndexDBPath               string           `yaml:"sgdIndexDBPath"`
 		ContractStakingIndexDBPath   string           `yaml:"contractStakingIndexDBPath"`
 		ContractStakingIndexV2DBPath string           `yaml:"contractStakingIndexV2DBPath"`
+		StateDiffIndexDBPath         string           `yaml:"stateDiffIndexDBPath"`
+		StakingHistoryIndexDBPath    string           `yaml:"stakingHistoryIndexDBPath"`
+		IndexJournalDBPath           string           `yaml:"indexJournalDBPath"`
 		ID                           uint32           `yaml:"id"`
 		EVMNetworkID                 uint32           `yaml:"evmNetworkID"`
 		Address                      string           `yaml:"address"`
@@ -50,14 +53,25 @@ type (
 		EnableStateDBCaching bool `yaml:"enableStateDBCaching"`
 		// EnableArchiveMode is only meaningful when EnableTrielessStateDB is false
 		EnableArchiveMode bool `yaml:"enableArchiveMode"`
+		// EnableContractStakingHistory keeps the history of buckets in the contract staking indexers, so that
+		// the buckets could be read at earlier heights. It is independent of the state DB and the archive mode
+		EnableContractStakingHistory bool `yaml:"enableContractStakingHistory"`
 		// EnableAsyncIndexWrite enables writing the block actions' and receipts' index asynchronously
 		EnableAsyncIndexWrite bool `yaml:"enableAsyncIndexWrite"`
+		// EnableIndexJournal keeps a durable journal of the asynchronous index writes, so that async indexers
+		// resume where they stopped and could be rebuilt in the background, the bloomfilter index is also
+		// written asynchronously if it is enabled together with EnableAsyncIndexWrite
+		EnableIndexJournal bool `yaml:"enableIndexJournal"`
 		// deprecated
 		EnableSystemLogIndexer bool `yaml:"enableSystemLog"`
 		// EnableStakingProtocol enables staking protocol
 		EnableStakingProtocol bool `yaml:"enableStakingProtocol"`
 		// EnableStakingIndexer enables staking indexer
 		EnableStakingIndexer bool `yaml:"enableStakingIndexer"`
+		// EnableStateDiffIndexer enables indexing the states changed by each block, only supported by trieless state db
+		EnableStateDiffIndexer bool `yaml:"enableStateDiffIndexer"`
+		// EnableStakingHistoryIndexer enables indexing the lifecycle events of staking buckets by owner
+		EnableStakingHistoryIndexer bool `yaml:"enableStakingHistoryIndexer"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
 		// MaxCacheSize is the max number of blocks that will be put into an LRU cache. 0 means disabled
@@ -72,6 +86,15 @@ type (
 		StreamingBlockBufferSize uint64 `yaml:"streamingBlockBufferSize"`
 		// PersistStakingPatchBlock is the block to persist staking patch
 		PersistStakingPatchBlock uint64 `yaml:"persistStakingPatchBlock"`
+		// ParallelExecutionWorkers is the number of workers to execute a block's actions optimistically in parallel
+		// when validating the block. 0 means disabled
+		ParallelExecutionWorkers int `yaml:"parallelExecutionWorkers"`
+		// StatePrefetchWorkers is the number of workers to load the states of a received block into the state db
+		// cache before the block is executed. 0 means disabled
+		StatePrefetchWorkers int `yaml:"statePrefetchWorkers"`
+		// RollbackRetention is the number of most recent blocks whose undo logs are kept in the state db and
+		// indexers, so that the chain could be rolled back by up to this number of blocks. 0 means disabled
+		RollbackRetention uint64 `yaml:"rollbackRetention"`
 	}
 )
 
@@ -89,6 +112,9 @@ var (
 		SGDIndexDBPath:               "/var/data/sgd.index.db",
 		ContractStakingIndexDBPath:   "/var/data/contractstaking.index.db",
 		ContractStakingIndexV2DBPath: "/var/data/contractstaking.index.v2.db",
+		StateDiffIndexDBPath:         "/var/data/statediff.index.db",
+		StakingHistoryIndexDBPath:    "/var/data/stakinghistory.index.db",
+		IndexJournalDBPath:           "/var/data/index.journal.db",
 		ID:                           1,
 		EVMNetworkID:                 4689,
 		Address:                      "",
@@ -102,10 +128,14 @@ var (
 		EnableTrielessStateDB:         true,
 		EnableStateDBCaching:          false,
 		EnableArchiveMode:             false,
+		EnableContractStakingHistory:  false,
 		EnableAsyncIndexWrite:         true,
+		EnableIndexJournal:            false,
 		EnableSystemLogIndexer:        false,
 		EnableStakingProtocol:         true,
 		EnableStakingIndexer:          false,
+		EnableStateDiffIndexer:        false,
+		EnableStakingHistoryIndexer:   false,
 		AllowedBlockGasResidue:        10000,
 		MaxCacheSize:                  0,
 		PollInitialCandidatesInterval: 10 * time.Second,
@@ -113,6 +143,9 @@ var (
 		WorkingSetCacheSize:           20,
 		StreamingBlockBufferSize:      200,
 		PersistStakingPatchBlock:      19778037,
+		ParallelExecutionWorkers:      0,
+		StatePrefetchWorkers:          0,
+		RollbackRetention:             0,
 	}
 
 	// ErrConfig config error
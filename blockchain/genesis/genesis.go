@@ -124,6 +124,12 @@ func defaultConfig() Genesis {
 			MinStakeAmount:                   unit.ConvertIotxToRau(100).String(),
 			BootstrapCandidates:              []BootstrapCandidate{},
 			EndorsementWithdrawWaitingBlocks: 24 * 60 * 60 / 5,
+			NameReleaseWaitingBlocks:         7 * 24 * 60 * 60 / 5,
+		},
+		Governance: Governance{
+			ProposalFeeStr: unit.ConvertIotxToRau(100).String(),
+			VotingEpochs:   168,
+			PassThreshold:  50,
 		},
 	}
 }
@@ -160,6 +166,7 @@ type (
 		Poll       `yaml:"poll"`
 		Rewarding  `yaml:"rewarding"`
 		Staking    `yaml:"staking"`
+		Governance `yaml:"governance"`
 	}
 	// Blockchain contains blockchain level configs
 	Blockchain struct {
@@ -324,6 +331,13 @@ type (
 		SystemStakingContractV2Address string `yaml:"systemStakingContractV2Address"`
 		// SystemStakingContractV2Height is the height of system staking contract
 		SystemStakingContractV2Height uint64 `yaml:"systemStakingContractV2Height"`
+		// SlashProbationThreshold is the number of epochs on probation within the probation period, from which the
+		// self-stake of a delegate is slashed, 0 disables slashing
+		SlashProbationThreshold uint32 `yaml:"slashProbationThreshold"`
+		// SlashRate is the rate in percentage of the self-stake slashed in each epoch range from [0, 100]
+		SlashRate uint32 `yaml:"slashRate"`
+		// SlashBeneficiary is the address receiving the slashed self-stake, the slashed self-stake is burnt if it is empty
+		SlashBeneficiary string `yaml:"slashBeneficiary"`
 	}
 	// Delegate defines a delegate with address and votes
 	Delegate struct {
@@ -371,6 +385,9 @@ type (
 		MinStakeAmount                   string               `yaml:"minStakeAmount"`
 		BootstrapCandidates              []BootstrapCandidate `yaml:"bootstrapCandidates"`
 		EndorsementWithdrawWaitingBlocks uint64               `yaml:"endorsementWithdrawWaitingBlocks"`
+		// NameReleaseWaitingBlocks is the number of blocks after which the name of a deactivated candidate can be
+		// taken by another candidate
+		NameReleaseWaitingBlocks uint64 `yaml:"nameReleaseWaitingBlocks"`
 	}
 
 	// VoteWeightCalConsts contains the configs for calculating vote weight
@@ -386,6 +403,16 @@ type (
 		MinSelfStake string `yaml:"minSelfStake"`
 	}
 
+	// Governance contains the configs for governance protocol
+	Governance struct {
+		// ProposalFeeStr is the fee charged to create a proposal in decimal string format
+		ProposalFeeStr string `yaml:"proposalFee"`
+		// VotingEpochs is the number of epochs during which a proposal can be voted
+		VotingEpochs uint64 `yaml:"votingEpochs"`
+		// PassThreshold is the percentage of yes votes among the yes and no votes for a proposal to pass
+		PassThreshold uint64 `yaml:"passThreshold"`
+	}
+
 	// BootstrapCandidate is the candidate data need to be provided to bootstrap candidate.
 	BootstrapCandidate struct {
 		OwnerAddress      string `yaml:"ownerAddress"`
@@ -780,3 +807,12 @@ func (r *Rewarding) FoundationBonus() *big.Int {
 	}
 	return val
 }
+
+// ProposalFee returns the fee charged to create a governance proposal
+func (g *Governance) ProposalFee() *big.Int {
+	val, ok := new(big.Int).SetString(g.ProposalFeeStr, 10)
+	if !ok {
+		log.S().Panicf("Error when casting proposal fee string %s into big int", g.ProposalFeeStr)
+	}
+	return val
+}
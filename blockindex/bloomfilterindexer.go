@@ -179,15 +179,54 @@ func (bfx *bloomfilterIndexer) PutBlock(ctx context.Context, blk *block.Block) (
 }
 
 // DeleteTipBlock deletes tip height from underlying DB if necessary
+// the logs of the deleted block are not removed from the range bloomfilter, which only
+// causes false positives when filtering blocks
 func (bfx *bloomfilterIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) (err error) {
 	bfx.mutex.Lock()
 	defer bfx.mutex.Unlock()
 	height := blk.Height()
-	if err := bfx.kvStore.Delete(BlockBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(height)); err != nil {
+	tipHeight, err := bfx.Height()
+	if err != nil {
 		return err
 	}
-	bfx.curRangeBloomfilter = nil
-	return nil
+	if height != tipHeight {
+		return errors.Errorf("invalid block height %d, expect tip %d", height, tipHeight)
+	}
+	if height == 0 {
+		return errors.New("cannot delete genesis block")
+	}
+	if bfx.curRangeBloomfilter.Start() == height+1 {
+		// the range was rolled over after the tip block, go back to the previous range
+		if err := bfx.revertRangeBloomFilter(height + 1); err != nil {
+			return err
+		}
+	}
+	b := batch.NewBatch()
+	switch {
+	case bfx.curRangeBloomfilter.Start() < height:
+		bfx.curRangeBloomfilter.SetEnd(height - 1)
+		bfBytes, err := bfx.curRangeBloomfilter.Bytes()
+		if err != nil {
+			return err
+		}
+		b.Put(RangeBloomFilterNamespace, bfx.currRangeBfKey, bfBytes, "failed to put range bloom filter")
+	case height == 1:
+		// the tip block is the only block indexed
+		if bfx.curRangeBloomfilter, err = newBloomRange(bfx.bfSize, bfx.bfNumHash); err != nil {
+			return err
+		}
+		bfx.curRangeBloomfilter.SetStart(1)
+		b.Delete(RangeBloomFilterNamespace, bfx.currRangeBfKey, "failed to delete range bloom filter")
+	default:
+		// the tip block is the only block in the range, go back to the previous range
+		b.Delete(RangeBloomFilterNamespace, bfx.currRangeBfKey, "failed to delete range bloom filter")
+		if err := bfx.revertRangeBloomFilter(height); err != nil {
+			return err
+		}
+	}
+	b.Delete(BlockBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(height), "failed to delete block bloom filter")
+	b.Put(RangeBloomFilterNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(height-1), "failed to put current height")
+	return bfx.kvStore.WriteBatch(b)
 }
 
 // RangeBloomFilterNumElements returns the number of elements that each rangeBloomfilter indexes
@@ -365,6 +404,27 @@ func (bfx *bloomfilterIndexer) loadBloomRangeFromDB(br *bloomRange, bfKey []byte
 	return br.FromBytes(bfBytes)
 }
 
+// revertRangeBloomFilter removes the range starting at the height, and loads the previous range
+func (bfx *bloomfilterIndexer) revertRangeBloomFilter(height uint64) error {
+	if err := bfx.totalRange.Delete(height); err != nil {
+		return errors.Wrap(err, "failed to delete bloomfilter index")
+	}
+	key, err := bfx.totalRange.Get(height - 1)
+	if err != nil {
+		return err
+	}
+	br, err := newBloomRange(bfx.bfSize, bfx.bfNumHash)
+	if err != nil {
+		return err
+	}
+	if err := bfx.loadBloomRangeFromDB(br, key); err != nil {
+		return err
+	}
+	bfx.currRangeBfKey = key
+	bfx.curRangeBloomfilter = br
+	return nil
+}
+
 func (bfx *bloomfilterIndexer) getIndexByHeight(height uint64) (uint64, error) {
 	val, err := bfx.totalRange.Get(height)
 	if err != nil {
@@ -258,6 +258,25 @@ func TestBloomfilterIndexer(t *testing.T) {
 			require.NoError(err)
 			require.Equal(expectedRes4[i], res)
 		}
+
+		// delete tip blocks and put them back
+		require.Error(indexer.DeleteTipBlock(ctx, blks[3]))
+		for i := len(blks) - 1; i >= 2; i-- {
+			require.NoError(indexer.DeleteTipBlock(ctx, blks[i]))
+			height, err := indexer.Height()
+			require.NoError(err)
+			require.Equal(blks[i].Height()-1, height)
+			_, err = indexer.BlockFilterByHeight(blks[i].Height())
+			require.Error(err)
+		}
+		for i := 2; i < len(blks); i++ {
+			require.NoError(indexer.PutBlock(ctx, blks[i]))
+		}
+		for i, l := range testFilter {
+			res, err := indexer.FilterBlocksInRange(logfilter.NewLogFilter(l), 1, 5, 0)
+			require.NoError(err)
+			require.Equal(expectedRes2[i], res)
+		}
 	}
 
 	t.Run("Bolt DB indexer", func(t *testing.T) {
@@ -272,7 +272,19 @@ func (s *contractStakingCache) LoadFromDB(kvstore db.KVStore) error {
 
 	}
 	s.putHeight(height)
+	return s.load(kvstore)
+}
+
+// LoadFromHistory loads the cache from the history at the height
+func (s *contractStakingCache) LoadFromHistory(kvstore db.KVStore, height uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.putHeight(height)
+	return s.load(kvstore)
+}
 
+func (s *contractStakingCache) load(kvstore db.KVStore) error {
 	// load total bucket count
 	var totalBucketCount uint64
 	tbc, err := kvstore.Get(_StakingNS, _stakingTotalBucketCountKey)
@@ -288,7 +300,7 @@ func (s *contractStakingCache) LoadFromDB(kvstore db.KVStore) error {
 
 	// load bucket info
 	ks, vs, err := kvstore.Filter(_StakingBucketInfoNS, func(k, v []byte) bool { return true }, nil, nil)
-	if err != nil && !errors.Is(err, db.ErrBucketNotExist) {
+	if err != nil && !errors.Is(err, db.ErrBucketNotExist) && !errors.Is(err, db.ErrNotExist) {
 		return err
 	}
 	for i := range vs {
@@ -301,7 +313,7 @@ func (s *contractStakingCache) LoadFromDB(kvstore db.KVStore) error {
 
 	// load bucket type
 	ks, vs, err = kvstore.Filter(_StakingBucketTypeNS, func(k, v []byte) bool { return true }, nil, nil)
-	if err != nil && !errors.Is(err, db.ErrBucketNotExist) {
+	if err != nil && !errors.Is(err, db.ErrBucketNotExist) && !errors.Is(err, db.ErrNotExist) {
 		return err
 	}
 	for i := range vs {
@@ -6,11 +6,13 @@
 package contractstaking
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
@@ -22,6 +24,8 @@ import (
 
 const (
 	maxBlockNumber uint64 = math.MaxUint64
+	// _historyCacheSize is the number of heights whose index data loaded from history are cached
+	_historyCacheSize = 32
 )
 
 type (
@@ -33,8 +37,14 @@ type (
 		kvstore db.KVStore            // persistent storage, used to initialize index cache at startup
 		cache   *contractStakingCache // in-memory index for clean data, used to query index data
 		config  Config                // indexer config
+		history *db.VersionedHistory  // history of index data, used to query index data at earlier heights
+		// historyCache caches the index data loaded from history, keyed by height
+		historyCache cache.LRUCache
 	}
 
+	// IndexerOption is the option to create the indexer
+	IndexerOption func(*Indexer)
+
 	// Config is the config for contract staking indexer
 	Config struct {
 		ContractAddress      string // stake contract ContractAddress
@@ -42,13 +52,26 @@ type (
 		// TODO: move calculateVoteWeightFunc out of config
 		CalculateVoteWeight calculateVoteWeightFunc // calculate vote weight function
 		BlockInterval       time.Duration           // block produce interval
+		RollbackRetention   uint64                  // number of recent blocks which could be deleted from indexer
 	}
 
 	calculateVoteWeightFunc func(v *Bucket) *big.Int
 )
 
+// WithHistory keeps the history of buckets in the versioned store, so that the buckets at an
+// earlier height could be read. The kv store of the indexer should be the base of the versioned store
+func WithHistory(kv db.KvVersioned) IndexerOption {
+	return func(s *Indexer) {
+		s.history = db.NewVersionedHistory(kv, func(ns string, key []byte) bool {
+			// the height is the version of history
+			return ns != _StakingNS || !bytes.Equal(key, _stakingHeightKey)
+		}, _StakingNS, _StakingBucketInfoNS, _StakingBucketTypeNS)
+		s.historyCache = cache.NewThreadSafeLruCache(_historyCacheSize)
+	}
+}
+
 // NewContractStakingIndexer creates a new contract staking indexer
-func NewContractStakingIndexer(kvStore db.KVStore, config Config) (*Indexer, error) {
+func NewContractStakingIndexer(kvStore db.KVStore, config Config, opts ...IndexerOption) (*Indexer, error) {
 	if kvStore == nil {
 		return nil, errors.New("kv store is nil")
 	}
@@ -58,11 +81,15 @@ func NewContractStakingIndexer(kvStore db.KVStore, config Config) (*Indexer, err
 	if config.CalculateVoteWeight == nil {
 		return nil, errors.New("calculate vote weight function is nil")
 	}
-	return &Indexer{
+	s := &Indexer{
 		kvstore: kvStore,
 		cache:   newContractStakingCache(config),
 		config:  config,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // Start starts the indexer
@@ -70,7 +97,13 @@ func (s *Indexer) Start(ctx context.Context) error {
 	if err := s.kvstore.Start(ctx); err != nil {
 		return err
 	}
-	return s.loadFromDB()
+	if err := s.loadFromDB(); err != nil {
+		return err
+	}
+	if s.history != nil {
+		return s.history.Sync(s.cache.Height())
+	}
+	return nil
 }
 
 // Stop stops the indexer
@@ -102,7 +135,11 @@ func (s *Indexer) CandidateVotes(ctx context.Context, candidate address.Address,
 	if s.isIgnored(height) {
 		return big.NewInt(0), nil
 	}
-	return s.cache.CandidateVotes(ctx, candidate, height)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return cache.CandidateVotes(ctx, candidate, height)
 }
 
 // Buckets returns the buckets
@@ -110,7 +147,11 @@ func (s *Indexer) Buckets(height uint64) ([]*Bucket, error) {
 	if s.isIgnored(height) {
 		return []*Bucket{}, nil
 	}
-	return s.cache.Buckets(height)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return cache.Buckets(height)
 }
 
 // Bucket returns the bucket
@@ -118,7 +159,11 @@ func (s *Indexer) Bucket(id uint64, height uint64) (*Bucket, bool, error) {
 	if s.isIgnored(height) {
 		return nil, false, nil
 	}
-	return s.cache.Bucket(id, height)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, false, err
+	}
+	return cache.Bucket(id, height)
 }
 
 // BucketsByIndices returns the buckets by indices
@@ -126,7 +171,11 @@ func (s *Indexer) BucketsByIndices(indices []uint64, height uint64) ([]*Bucket,
 	if s.isIgnored(height) {
 		return []*Bucket{}, nil
 	}
-	return s.cache.BucketsByIndices(indices, height)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return cache.BucketsByIndices(indices, height)
 }
 
 // BucketsByCandidate returns the buckets by candidate
@@ -134,7 +183,11 @@ func (s *Indexer) BucketsByCandidate(candidate address.Address, height uint64) (
 	if s.isIgnored(height) {
 		return []*Bucket{}, nil
 	}
-	return s.cache.BucketsByCandidate(candidate, height)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return cache.BucketsByCandidate(candidate, height)
 }
 
 // TotalBucketCount returns the total bucket count including active and burnt buckets
@@ -142,7 +195,11 @@ func (s *Indexer) TotalBucketCount(height uint64) (uint64, error) {
 	if s.isIgnored(height) {
 		return 0, nil
 	}
-	return s.cache.TotalBucketCount(height)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return 0, err
+	}
+	return cache.TotalBucketCount(height)
 }
 
 // BucketTypes returns the active bucket types
@@ -150,7 +207,11 @@ func (s *Indexer) BucketTypes(height uint64) ([]*BucketType, error) {
 	if s.isIgnored(height) {
 		return []*BucketType{}, nil
 	}
-	btMap, err := s.cache.ActiveBucketTypes(height)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	btMap, err := cache.ActiveBucketTypes(height)
 	if err != nil {
 		return nil, err
 	}
@@ -196,8 +257,24 @@ func (s *Indexer) PutBlock(ctx context.Context, blk *block.Block) error {
 }
 
 // DeleteTipBlock deletes the tip block from indexer
-func (s *Indexer) DeleteTipBlock(context.Context, *block.Block) error {
-	return errors.New("not implemented")
+func (s *Indexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	if tip := s.cache.Height(); blk.Height() != tip {
+		return errors.Errorf("invalid block height %d, expect tip %d", blk.Height(), tip)
+	}
+	b, err := db.UndoBatch(s.kvstore, blk.Height())
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete block %d from contract staking indexer", blk.Height())
+	}
+	if s.history != nil {
+		s.historyCache.Clear()
+		err = s.history.Revert(blk.Height(), b)
+	} else {
+		err = s.kvstore.WriteBatch(b)
+	}
+	if err != nil {
+		return err
+	}
+	return s.reloadCache()
 }
 
 func (s *Indexer) commit(handler *contractStakingEventHandler, height uint64) error {
@@ -209,7 +286,17 @@ func (s *Indexer) commit(handler *contractStakingEventHandler, height uint64) er
 	}
 	// update db
 	batch.Put(_StakingNS, _stakingHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
-	if err := s.kvstore.WriteBatch(batch); err != nil {
+	if err := db.PutUndoLog(s.kvstore, batch, height, s.config.RollbackRetention); err != nil {
+		s.reloadCache()
+		return err
+	}
+	var err error
+	if s.history != nil {
+		err = s.history.Commit(height, batch)
+	} else {
+		err = s.kvstore.WriteBatch(batch)
+	}
+	if err != nil {
 		s.reloadCache()
 		return err
 	}
@@ -225,6 +312,28 @@ func (s *Indexer) loadFromDB() error {
 	return s.cache.LoadFromDB(s.kvstore)
 }
 
+// cacheAt returns the cache of index data at the height, which is loaded from the history if
+// the height is earlier than the tip. Without history, the tip cache is returned
+func (s *Indexer) cacheAt(height uint64) (*contractStakingCache, error) {
+	tip := s.cache
+	if s.history == nil || height == 0 || height >= tip.Height() {
+		return tip, nil
+	}
+	if c, ok := s.historyCache.Get(height); ok {
+		return c.(*contractStakingCache), nil
+	}
+	kv, err := s.history.At(height)
+	if err != nil {
+		return nil, err
+	}
+	c := newContractStakingCache(s.config)
+	if err := c.LoadFromHistory(kv, height); err != nil {
+		return nil, errors.Wrapf(err, "failed to load buckets at height %d", height)
+	}
+	s.historyCache.Add(height, c)
+	return c, nil
+}
+
 // isIgnored returns true if before cotractDeployHeight.
 // it aims to be compatible with blocks between feature hard-fork and contract deployed
 // read interface should return empty result instead of invalid height error if it returns true
@@ -1155,6 +1155,155 @@ func TestIndexer_PutBlock(t *testing.T) {
 
 }
 
+func TestIndexer_DeleteTipBlock(t *testing.T) {
+	r := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile("staking.db")
+	r.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testDBPath
+	indexer, err := NewContractStakingIndexer(db.NewBoltDB(cfg), Config{
+		ContractAddress:      _testStakingContractAddress,
+		ContractDeployHeight: 0,
+		CalculateVoteWeight:  calculateVoteWeightGen(genesis.Default.VoteWeightCalConsts),
+		BlockInterval:        _blockInterval,
+		RollbackRetention:    2,
+	})
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+	blockAt := func(height uint64) *block.Block {
+		builder := block.NewBuilder(block.NewRunnableActionsBuilder().Build())
+		builder.SetHeight(height)
+		blk, err := builder.SignAndBuild(identityset.PrivateKey(1))
+		r.NoError(err)
+		return &blk
+	}
+
+	owner, delegate := identityset.Address(0), identityset.Address(1)
+	for height := uint64(1); height <= 3; height++ {
+		handler := newContractStakingEventHandler(indexer.cache)
+		activateBucketType(r, handler, int64(height)*10, 10, height)
+		stake(r, handler, owner, delegate, int64(height), int64(height)*10, 10, height)
+		r.NoError(indexer.commit(handler, height))
+	}
+	buckets, err := indexer.Buckets(3)
+	r.NoError(err)
+	r.Len(buckets, 3)
+
+	// only the tip block could be deleted
+	r.ErrorContains(indexer.DeleteTipBlock(ctx, blockAt(2)), "invalid block height 2, expect tip 3")
+	r.NoError(indexer.DeleteTipBlock(ctx, blockAt(3)))
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(2, height)
+	buckets, err = indexer.Buckets(2)
+	r.NoError(err)
+	r.Len(buckets, 2)
+	bts, err := indexer.BucketTypes(2)
+	r.NoError(err)
+	r.Len(bts, 2)
+	r.NoError(indexer.DeleteTipBlock(ctx, blockAt(2)))
+	// the undo log of block 1 is out of retention
+	r.ErrorIs(indexer.DeleteTipBlock(ctx, blockAt(1)), db.ErrUndoLogNotExist)
+	buckets, err = indexer.Buckets(1)
+	r.NoError(err)
+	r.Len(buckets, 1)
+
+	// the state is persisted
+	r.NoError(indexer.Stop(ctx))
+	r.NoError(indexer.Start(ctx))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.EqualValues(1, height)
+	buckets, err = indexer.Buckets(1)
+	r.NoError(err)
+	r.Len(buckets, 1)
+}
+
+func TestIndexer_History(t *testing.T) {
+	r := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile("staking.db")
+	r.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testDBPath
+	vdb := db.NewBoltDBVersioned(cfg)
+	indexer, err := NewContractStakingIndexer(vdb.Base(), Config{
+		ContractAddress:      _testStakingContractAddress,
+		ContractDeployHeight: 0,
+		CalculateVoteWeight:  calculateVoteWeightGen(genesis.Default.VoteWeightCalConsts),
+		BlockInterval:        _blockInterval,
+	}, WithHistory(vdb))
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+
+	owner, delegate := identityset.Address(0), identityset.Address(1)
+	for height := uint64(1); height <= 3; height++ {
+		handler := newContractStakingEventHandler(indexer.cache)
+		activateBucketType(r, handler, int64(height)*10, 10, height)
+		stake(r, handler, owner, delegate, int64(height), int64(height)*10, 10, height)
+		if height == 3 {
+			unstake(r, handler, 1, height)
+			withdraw(r, handler, 2)
+		}
+		r.NoError(indexer.commit(handler, height))
+	}
+	check := func() {
+		for height := uint64(1); height <= 3; height++ {
+			buckets, err := indexer.Buckets(height)
+			r.NoError(err)
+			bts, err := indexer.BucketTypes(height)
+			r.NoError(err)
+			r.Len(bts, int(height))
+			tbc, err := indexer.TotalBucketCount(height)
+			r.NoError(err)
+			r.EqualValues(height, tbc)
+			switch height {
+			case 1, 2:
+				r.Len(buckets, int(height))
+				for _, b := range buckets {
+					r.Equal(maxBlockNumber, b.UnstakeStartBlockHeight)
+				}
+			case 3:
+				// bucket 2 is withdrawn and bucket 1 is unstaked at height 3
+				r.Len(buckets, 2)
+				bkt, ok, err := indexer.Bucket(1, height)
+				r.NoError(err)
+				r.True(ok)
+				r.EqualValues(3, bkt.UnstakeStartBlockHeight)
+			}
+			bkt, ok, err := indexer.Bucket(1, 2)
+			r.NoError(err)
+			r.True(ok)
+			r.Equal(maxBlockNumber, bkt.UnstakeStartBlockHeight)
+		}
+	}
+	check()
+	_, err = indexer.Buckets(4)
+	r.ErrorIs(err, ErrInvalidHeight)
+	// the index data at earlier heights are cached
+	c1, err := indexer.cacheAt(2)
+	r.NoError(err)
+	c2, err := indexer.cacheAt(2)
+	r.NoError(err)
+	r.Same(c1, c2)
+	_, ok := indexer.historyCache.Get(uint64(1))
+	r.True(ok)
+
+	// the history is persisted
+	r.NoError(indexer.Stop(ctx))
+	r.NoError(indexer.Start(ctx))
+	check()
+}
+
 func BenchmarkIndexer_PutBlockBeforeContractHeight(b *testing.B) {
 	// Create a new Indexer with a contract height of 100
 	indexer := &Indexer{config: Config{ContractDeployHeight: 100}}
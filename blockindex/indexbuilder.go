@@ -7,7 +7,10 @@ package blockindex
 
 import (
 	"strconv"
+	"sync"
+	"sync/atomic"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -28,8 +31,17 @@ var batchSizeMtc = prometheus.NewGaugeVec(
 	[]string{},
 )
 
+var _indexerLagMtc = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "iotex_indexer_lag",
+		Help: "Number of blocks the indexer lags behind the chain",
+	},
+	[]string{"indexer"},
+)
+
 func init() {
 	prometheus.MustRegister(batchSizeMtc)
+	prometheus.MustRegister(_indexerLagMtc)
 }
 
 // these NS belong to old DB before migrating to separate index
@@ -44,16 +56,50 @@ const (
 	_transferAmountNS                 = "tfa"
 )
 
-// IndexBuilder defines the index builder
-type IndexBuilder struct {
-	timerFactory *prometheustimer.TimerFactory
-	dao          blockdao.BlockDAO
-	indexer      Indexer
-	genesis      genesis.Genesis
+type (
+	// IndexBuilder defines the index builder, which writes the index of blocks asynchronously
+	IndexBuilder struct {
+		timerFactory *prometheustimer.TimerFactory
+		dao          blockdao.BlockDAO
+		indexer      blockdao.BlockIndexer
+		genesis      genesis.Genesis
+		name         string
+		journal      *IndexJournal
+		mutex        sync.Mutex // serializes the writes to the indexer
+		rebuilding   atomic.Bool
+		lag          atomic.Uint64
+		wg           sync.WaitGroup
+		cancel       context.CancelFunc
+	}
+
+	// IndexBuilderOption is the option to create an index builder
+	IndexBuilderOption func(*IndexBuilder)
+
+	// IndexerStatus is the status of an indexer
+	IndexerStatus struct {
+		Name       string `json:"name"`
+		Height     uint64 `json:"height"`
+		Lag        uint64 `json:"lag"`
+		Rebuilding bool   `json:"rebuilding"`
+	}
+)
+
+// WithIndexJournal sets the journal to record the checkpoint of the indexer
+func WithIndexJournal(journal *IndexJournal) IndexBuilderOption {
+	return func(ib *IndexBuilder) {
+		ib.journal = journal
+	}
+}
+
+// WithIndexerName sets the name of the indexer
+func WithIndexerName(name string) IndexBuilderOption {
+	return func(ib *IndexBuilder) {
+		ib.name = name
+	}
 }
 
 // NewIndexBuilder instantiates an index builder
-func NewIndexBuilder(chainID uint32, g genesis.Genesis, dao blockdao.BlockDAO, indexer Indexer) (*IndexBuilder, error) {
+func NewIndexBuilder(chainID uint32, g genesis.Genesis, dao blockdao.BlockDAO, indexer blockdao.BlockIndexer, opts ...IndexBuilderOption) (*IndexBuilder, error) {
 	timerFactory, err := prometheustimer.New(
 		"iotex_indexer_batch_time",
 		"Indexer batch time",
@@ -63,12 +109,17 @@ func NewIndexBuilder(chainID uint32, g genesis.Genesis, dao blockdao.BlockDAO, i
 	if err != nil {
 		return nil, err
 	}
-	return &IndexBuilder{
+	ib := &IndexBuilder{
 		timerFactory: timerFactory,
 		dao:          dao,
 		indexer:      indexer,
 		genesis:      g,
-	}, nil
+		name:         "indexer",
+	}
+	for _, opt := range opts {
+		opt(ib)
+	}
+	return ib, nil
 }
 
 // Start starts the index builder
@@ -85,20 +136,53 @@ func (ib *IndexBuilder) Start(ctx context.Context) error {
 
 // Stop stops the index builder
 func (ib *IndexBuilder) Stop(ctx context.Context) error {
+	ib.mutex.Lock()
+	if ib.cancel != nil {
+		ib.cancel()
+	}
+	ib.mutex.Unlock()
+	ib.wg.Wait()
 	return ib.indexer.Stop(ctx)
 }
 
+// Name returns the name of the indexer
+func (ib *IndexBuilder) Name() string {
+	return ib.name
+}
+
 // Indexer returns the indexer
-func (ib *IndexBuilder) Indexer() Indexer {
+func (ib *IndexBuilder) Indexer() blockdao.BlockIndexer {
 	return ib.indexer
 }
 
+// Status returns the status of the indexer
+func (ib *IndexBuilder) Status() (*IndexerStatus, error) {
+	height, err := ib.indexer.Height()
+	if err != nil {
+		return nil, err
+	}
+	return &IndexerStatus{
+		Name:       ib.name,
+		Height:     height,
+		Lag:        ib.lag.Load(),
+		Rebuilding: ib.rebuilding.Load(),
+	}, nil
+}
+
 // ReceiveBlock handles the block and create the indices for the actions and receipts in it
 func (ib *IndexBuilder) ReceiveBlock(blk *block.Block) error {
+	ib.mutex.Lock()
+	defer ib.mutex.Unlock()
+	if ib.rebuilding.Load() {
+		// the block will be indexed by the rebuilding
+		ib.updateLag()
+		return nil
+	}
 	timer := ib.timerFactory.NewTimer("indexBlock")
-	if err := ib.indexer.PutBlock(genesis.WithGenesisContext(context.Background(), ib.genesis), blk); err != nil {
+	if err := ib.catchUp(genesis.WithGenesisContext(context.Background(), ib.genesis), blk.Height(), blk); err != nil {
 		log.L().Error(
 			"Error when indexing the block",
+			zap.String("indexer", ib.name),
 			zap.Uint64("height", blk.Height()),
 			zap.Error(err),
 		)
@@ -106,8 +190,42 @@ func (ib *IndexBuilder) ReceiveBlock(blk *block.Block) error {
 	}
 	timer.End()
 	if blk.Height()%100 == 0 {
-		log.L().Info("indexing new block", zap.Uint64("height", blk.Height()))
+		log.L().Info("indexing new block", zap.String("indexer", ib.name), zap.Uint64("height", blk.Height()))
+	}
+	return nil
+}
+
+// Rebuild rebuilds the index from the height in the background, the blocks above the height
+// are deleted from the index and then indexed again up to the tip of the chain
+func (ib *IndexBuilder) Rebuild(from uint64) error {
+	if from == 0 {
+		return errors.New("cannot rebuild index from height 0")
+	}
+	ib.mutex.Lock()
+	defer ib.mutex.Unlock()
+	if !ib.rebuilding.CompareAndSwap(false, true) {
+		return errors.Errorf("indexer %s is rebuilding", ib.name)
+	}
+	ctx, cancel := context.WithCancel(genesis.WithGenesisContext(context.Background(), ib.genesis))
+	ib.cancel = cancel
+	ib.wg.Add(1)
+	go func() {
+		defer ib.wg.Done()
+		if err := ib.rebuild(ctx, from); err != nil {
+			log.L().Error("Failed to rebuild index", zap.String("indexer", ib.name), zap.Uint64("from", from), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// RevertTo deletes the blocks above the target height from the index
+func (ib *IndexBuilder) RevertTo(ctx context.Context, target uint64) error {
+	ib.mutex.Lock()
+	defer ib.mutex.Unlock()
+	if err := ib.revertTo(genesis.WithGenesisContext(ctx, ib.genesis), target); err != nil {
+		return err
 	}
+	log.L().Info("Reverted index", zap.String("indexer", ib.name), zap.Uint64("height", target))
 	return nil
 }
 
@@ -120,11 +238,6 @@ func (ib *IndexBuilder) init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if startHeight == tipHeight {
-		// indexer height consistent with dao height
-		zap.L().Info("Consistent DB", zap.Uint64("height", startHeight))
-		return nil
-	}
 	if startHeight > tipHeight {
 		// indexer height > dao height
 		// this shouldn't happen unless blocks are deliberately removed from dao w/o removing index
@@ -133,29 +246,215 @@ func (ib *IndexBuilder) init(ctx context.Context) error {
 		zap.L().Error(err.Error())
 		return err
 	}
+	if err := ib.checkJournal(startHeight); err != nil {
+		return err
+	}
+	if startHeight == tipHeight {
+		// indexer height consistent with dao height
+		zap.L().Info("Consistent DB", zap.String("indexer", ib.name), zap.Uint64("height", startHeight))
+		ib.updateLag()
+		return nil
+	}
 	// update index to latest block
+	if err := ib.catchUp(genesis.WithGenesisContext(ctx, ib.genesis), tipHeight, nil); err != nil {
+		return err
+	}
+	zap.L().Info("Finished migrating DB", zap.String("indexer", ib.name), zap.Uint64("height", tipHeight))
+	return nil
+}
+
+// checkJournal verifies the checkpoint of the indexer is on the chain, the blocks after the
+// checkpoint are indexed again by catching up to the tip
+func (ib *IndexBuilder) checkJournal(height uint64) error {
+	if ib.journal == nil {
+		return nil
+	}
+	checkpoint, err := ib.journal.Checkpoint(ib.name)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil || checkpoint.Height == 0 || checkpoint.Height > height {
+		return nil
+	}
+	h, err := ib.dao.GetBlockHash(checkpoint.Height)
+	if err != nil {
+		return err
+	}
+	if h != checkpoint.Hash {
+		return errors.Wrapf(ErrIndexDiverged, "indexer %s has indexed block %x at height %d, but chain has %x, please rebuild the index",
+			ib.name, checkpoint.Hash, checkpoint.Height, h)
+	}
+	return nil
+}
+
+// catchUp indexes the blocks up to the tip height, the last block is used if it's at the tip height
+func (ib *IndexBuilder) catchUp(ctx context.Context, tipHeight uint64, last *block.Block) error {
+	height, err := ib.indexer.Height()
+	if err != nil {
+		return err
+	}
 	var (
-		gCtx = genesis.WithGenesisContext(ctx, ib.genesis)
 		blks = make([]*block.Block, 0, 5000)
+		blk  *block.Block
 	)
-	for startHeight++; startHeight <= tipHeight; startHeight++ {
-		blk, err := ib.dao.GetBlockByHeight(startHeight)
-		if err != nil {
+	for height++; height <= tipHeight; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if last != nil && height == last.Height() {
+			blk = last
+		} else if blk, err = ib.getBlock(height); err != nil {
 			return err
 		}
 		blks = append(blks, blk)
 		// commit once every 5000 blocks
-		if startHeight%5000 == 0 || startHeight == tipHeight {
-			if err := ib.indexer.PutBlocks(gCtx, blks); err != nil {
+		if height%5000 == 0 || height == tipHeight {
+			if err := ib.putBlocks(ctx, blks); err != nil {
 				return err
 			}
 			blks = blks[:0]
-			zap.L().Info("Finished indexing blocks up to", zap.Uint64("height", startHeight))
+			if height%5000 == 0 {
+				zap.L().Info("Finished indexing blocks up to", zap.String("indexer", ib.name), zap.Uint64("height", height))
+			}
+		}
+	}
+	return nil
+}
+
+func (ib *IndexBuilder) putBlocks(ctx context.Context, blks []*block.Block) error {
+	if len(blks) == 0 {
+		return nil
+	}
+	if indexer, ok := ib.indexer.(interface {
+		PutBlocks(context.Context, []*block.Block) error
+	}); ok {
+		if err := indexer.PutBlocks(ctx, blks); err != nil {
+			return err
+		}
+	} else {
+		for _, blk := range blks {
+			if err := ib.indexer.PutBlock(ctx, blk); err != nil {
+				return err
+			}
 		}
 	}
-	if startHeight >= tipHeight {
-		// successfully migrated to latest block
-		zap.L().Info("Finished migrating DB", zap.Uint64("height", startHeight))
+	last := blks[len(blks)-1]
+	if ib.journal != nil {
+		if err := ib.journal.Commit(ib.name, last.Height(), last.HashBlock()); err != nil {
+			return err
+		}
 	}
+	ib.updateLag()
 	return nil
 }
+
+func (ib *IndexBuilder) rebuild(ctx context.Context, from uint64) error {
+	defer ib.rebuilding.Store(false)
+	log.L().Info("Start rebuilding index", zap.String("indexer", ib.name), zap.Uint64("from", from))
+	// delete one block at a time, so that the lock is not held for long
+	for {
+		ib.mutex.Lock()
+		height, err := ib.indexer.Height()
+		if err == nil && height >= from {
+			err = ib.revertTo(ctx, height-1)
+		}
+		ib.mutex.Unlock()
+		if err != nil {
+			return err
+		}
+		if height < from {
+			break
+		}
+	}
+	for {
+		ib.mutex.Lock()
+		height, err := ib.indexer.Height()
+		if err != nil {
+			ib.mutex.Unlock()
+			return err
+		}
+		tipHeight, err := ib.dao.Height()
+		if err != nil {
+			ib.mutex.Unlock()
+			return err
+		}
+		if height >= tipHeight {
+			// caught up with the chain, new blocks are indexed by ReceiveBlock since now
+			ib.rebuilding.Store(false)
+			ib.mutex.Unlock()
+			log.L().Info("Finished rebuilding index", zap.String("indexer", ib.name), zap.Uint64("height", height))
+			return nil
+		}
+		// index one block at a time, so that the lock is not held for long
+		err = ib.catchUp(ctx, height+1, nil)
+		ib.mutex.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (ib *IndexBuilder) revertTo(ctx context.Context, target uint64) error {
+	height, err := ib.indexer.Height()
+	if err != nil {
+		return err
+	}
+	if height <= target {
+		return nil
+	}
+	for ; height > target; height-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		blk, err := ib.getBlock(height)
+		if err != nil {
+			return err
+		}
+		if err := ib.indexer.DeleteTipBlock(ctx, blk); err != nil {
+			return errors.Wrapf(err, "failed to delete block %d from indexer %s", height, ib.name)
+		}
+	}
+	if ib.journal != nil {
+		h := hash.ZeroHash256
+		if target > 0 {
+			if h, err = ib.dao.GetBlockHash(target); err != nil {
+				return err
+			}
+		}
+		if err := ib.journal.Commit(ib.name, target, h); err != nil {
+			return err
+		}
+	}
+	ib.updateLag()
+	return nil
+}
+
+func (ib *IndexBuilder) getBlock(height uint64) (*block.Block, error) {
+	blk, err := ib.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Receipts == nil {
+		if blk.Receipts, err = ib.dao.GetReceipts(height); err != nil {
+			return nil, err
+		}
+	}
+	return blk, nil
+}
+
+func (ib *IndexBuilder) updateLag() {
+	height, err := ib.indexer.Height()
+	if err != nil {
+		return
+	}
+	tipHeight, err := ib.dao.Height()
+	if err != nil {
+		return
+	}
+	var lag uint64
+	if tipHeight > height {
+		lag = tipHeight - height
+	}
+	ib.lag.Store(lag)
+	_indexerLagMtc.WithLabelValues(ib.name).Set(float64(lag))
+}
@@ -194,6 +194,88 @@ func TestIndexBuilder(t *testing.T) {
 	}
 }
 
+func TestIndexBuilder_Rebuild(t *testing.T) {
+	r := require.New(t)
+
+	blks := getTestBlocks(t)
+	ctx := protocol.WithBlockchainCtx(
+		genesis.WithGenesisContext(context.Background(), genesis.Default),
+		protocol.BlockchainCtx{
+			ChainID: blockchain.DefaultConfig.ID,
+		})
+	dao, err := filedao.NewFileDAOInMemForTest()
+	r.NoError(err)
+	r.NoError(dao.Start(ctx))
+	defer func() {
+		r.NoError(dao.Stop(ctx))
+	}()
+	for _, blk := range blks {
+		r.NoError(dao.PutBlock(ctx, blk))
+	}
+	testPath, err := testutil.PathOfTempFile("index-journal")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	journal, err := NewIndexJournal(db.NewBoltDB(cfg))
+	r.NoError(err)
+	r.NoError(journal.Start(ctx))
+	defer func() {
+		r.NoError(journal.Stop(ctx))
+	}()
+	indexer, err := NewIndexer(db.NewMemKVStore(), hash.ZeroHash256)
+	r.NoError(err)
+	ib, err := NewIndexBuilder(blockchain.DefaultConfig.ID, genesis.Default, dao, indexer, WithIndexJournal(journal), WithIndexerName("test"))
+	r.NoError(err)
+	r.NoError(ib.Start(ctx))
+	defer func() {
+		r.NoError(ib.Stop(ctx))
+	}()
+
+	// the indexer catches up with the chain, and the checkpoint is recorded
+	status, err := ib.Status()
+	r.NoError(err)
+	r.Equal(&IndexerStatus{Name: "test", Height: 3}, status)
+	checkpoint, err := journal.Checkpoint("test")
+	r.NoError(err)
+	r.Equal(&JournalEntry{Height: 3, Hash: blks[2].HashBlock()}, checkpoint)
+
+	// rebuild from height 2
+	r.Error(ib.Rebuild(0))
+	r.NoError(ib.Rebuild(2))
+	r.Eventually(func() bool {
+		status, err := ib.Status()
+		return err == nil && !status.Rebuilding
+	}, 5*time.Second, 10*time.Millisecond)
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(3, height)
+	for _, blk := range blks {
+		h, err := indexer.GetBlockHash(blk.Height())
+		r.NoError(err)
+		r.Equal(blk.HashBlock(), h)
+	}
+	total, err := indexer.GetTotalActions()
+	r.NoError(err)
+	r.EqualValues(9, total)
+
+	// revert to height 1
+	r.NoError(ib.RevertTo(ctx, 1))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.EqualValues(1, height)
+	checkpoint, err = journal.Checkpoint("test")
+	r.NoError(err)
+	r.Equal(&JournalEntry{Height: 1, Hash: blks[0].HashBlock()}, checkpoint)
+	status, err = ib.Status()
+	r.NoError(err)
+	r.EqualValues(2, status.Lag)
+
+	// the index diverges from the chain
+	r.NoError(journal.Commit("test", 1, hash.ZeroHash256))
+	r.ErrorIs(ib.init(ctx), ErrIndexDiverged)
+}
+
 // classifyActions classfies actions
 func classifyActions(actions []*action.SealedEnvelope) ([]*action.Transfer, []*action.Execution) {
 	tsfs := make([]*action.Transfer, 0)
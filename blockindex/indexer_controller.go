@@ -0,0 +1,83 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// IndexerController controls the asynchronous indexers
+type IndexerController struct {
+	builders []*IndexBuilder
+}
+
+// NewIndexerController constructs an indexer controller instance
+func NewIndexerController(builders ...*IndexBuilder) *IndexerController {
+	return &IndexerController{
+		builders: builders,
+	}
+}
+
+// Handle handles admin request, it returns the status of the indexers, or rebuilds an
+// indexer from a height with a POST request, e.g., POST /indexer?name=bloomfilter&rebuild=100
+func (ic *IndexerController) Handle(w http.ResponseWriter, r *http.Request) {
+	var (
+		name    = r.URL.Query().Get("name")
+		rebuild = r.URL.Query().Get("rebuild")
+	)
+	if rebuild != "" {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "rebuild requires POST", http.StatusMethodNotAllowed)
+			return
+		}
+		from, err := strconv.ParseUint(rebuild, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid rebuild height", http.StatusBadRequest)
+			return
+		}
+		ib := ic.builder(name)
+		if ib == nil {
+			http.Error(w, "indexer not found", http.StatusNotFound)
+			return
+		}
+		if err := ib.Rebuild(from); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.L().Info("Rebuild indexer", zap.String("indexer", name), zap.Uint64("from", from))
+	}
+	status := make([]*IndexerStatus, 0, len(ic.builders))
+	for _, ib := range ic.builders {
+		if name != "" && ib.Name() != name {
+			continue
+		}
+		s, err := ib.Status()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		status = append(status, s)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (ic *IndexerController) builder(name string) *IndexBuilder {
+	for _, ib := range ic.builders {
+		if ib.Name() == name {
+			return ib
+		}
+	}
+	return nil
+}
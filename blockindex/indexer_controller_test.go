@@ -0,0 +1,34 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerController(t *testing.T) {
+	r := require.New(t)
+	ic := NewIndexerController()
+
+	for _, test := range []struct {
+		method string
+		url    string
+		code   int
+	}{
+		{http.MethodGet, "/indexer", http.StatusOK},
+		{http.MethodGet, "/indexer?name=test&rebuild=1", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/indexer?name=test&rebuild=x", http.StatusBadRequest},
+		{http.MethodPost, "/indexer?name=test&rebuild=1", http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		ic.Handle(w, httptest.NewRequest(test.method, test.url, nil))
+		r.Equal(test.code, w.Code, test.url)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// _journalCheckpointNS is the namespace to store the last indexed block of indexers
+	_journalCheckpointNS = "JournalCheckpoint"
+)
+
+var (
+	// ErrIndexDiverged indicates the indexer has indexed a block which is not on the chain
+	ErrIndexDiverged = errors.New("index diverged from chain")
+)
+
+type (
+	// IndexJournal is a durable journal of the asynchronous index writes, which keeps the last
+	// indexed block of each indexer as its checkpoint. The indexer resumes from the checkpoint
+	// by catching up to the chain tip, after the checkpoint is verified to be on the chain
+	IndexJournal struct {
+		kvStore db.KVStore
+	}
+
+	// JournalEntry is an entry of block in the journal
+	JournalEntry struct {
+		Height uint64
+		Hash   hash.Hash256
+	}
+)
+
+// NewIndexJournal creates a new index journal
+func NewIndexJournal(kv db.KVStore) (*IndexJournal, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	return &IndexJournal{kvStore: kv}, nil
+}
+
+// Start starts the journal
+func (j *IndexJournal) Start(ctx context.Context) error {
+	return j.kvStore.Start(ctx)
+}
+
+// Stop stops the journal
+func (j *IndexJournal) Stop(ctx context.Context) error {
+	return j.kvStore.Stop(ctx)
+}
+
+// Commit marks the blocks up to the height as indexed, the block becomes the checkpoint of the indexer
+func (j *IndexJournal) Commit(name string, height uint64, h hash.Hash256) error {
+	return j.kvStore.Put(_journalCheckpointNS, []byte(name), (&JournalEntry{Height: height, Hash: h}).serialize())
+}
+
+// Checkpoint returns the last indexed block of the indexer, nil if the indexer has no checkpoint
+func (j *IndexJournal) Checkpoint(name string) (*JournalEntry, error) {
+	value, err := j.kvStore.Get(_journalCheckpointNS, []byte(name))
+	if err != nil {
+		if cause := errors.Cause(err); cause == db.ErrNotExist || cause == db.ErrBucketNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return deserializeJournalEntry(value)
+}
+
+func (e *JournalEntry) serialize() []byte {
+	return append(byteutil.Uint64ToBytesBigEndian(e.Height), e.Hash[:]...)
+}
+
+func deserializeJournalEntry(data []byte) (*JournalEntry, error) {
+	if len(data) != 8+len(hash.ZeroHash256) {
+		return nil, errors.Errorf("invalid journal entry %x", data)
+	}
+	return &JournalEntry{
+		Height: byteutil.BytesToUint64BigEndian(data[:8]),
+		Hash:   hash.BytesToHash256(data[8:]),
+	}, nil
+}
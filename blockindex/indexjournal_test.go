@@ -0,0 +1,53 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestIndexJournal(t *testing.T) {
+	r := require.New(t)
+
+	testPath, err := testutil.PathOfTempFile("index-journal")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	ctx := context.Background()
+	j, err := NewIndexJournal(db.NewBoltDB(cfg))
+	r.NoError(err)
+	r.NoError(j.Start(ctx))
+
+	const name = "test"
+	checkpoint, err := j.Checkpoint(name)
+	r.NoError(err)
+	r.Nil(checkpoint)
+
+	h := func(i uint64) hash.Hash256 { return hash.Hash256b([]byte{byte(i)}) }
+	r.NoError(j.Commit(name, 1, h(1)))
+	r.NoError(j.Commit(name, 2, h(2)))
+
+	// the journal survives restart
+	r.NoError(j.Stop(ctx))
+	r.NoError(j.Start(ctx))
+	defer func() {
+		r.NoError(j.Stop(ctx))
+	}()
+	checkpoint, err = j.Checkpoint(name)
+	r.NoError(err)
+	r.Equal(&JournalEntry{Height: 2, Hash: h(2)}, checkpoint)
+	checkpoint, err = j.Checkpoint("other")
+	r.NoError(err)
+	r.Nil(checkpoint)
+}
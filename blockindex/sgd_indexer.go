@@ -198,10 +198,14 @@ type (
 	}
 
 	sgdRegistry struct {
-		contract    string
-		startHeight uint64
-		kvStore     db.KVStore
+		contract          string
+		startHeight       uint64
+		kvStore           db.KVStore
+		rollbackRetention uint64
 	}
+
+	// SGDRegistryOption is the option to create the SGDRegistry
+	SGDRegistryOption func(*sgdRegistry)
 	// SGDIndex is the struct for SGDIndex
 	SGDIndex struct {
 		Contract address.Address
@@ -233,8 +237,15 @@ func newSgdIndex(contract, receiver []byte) *indexpb.SGDIndex {
 	}
 }
 
+// SGDRollbackRetentionOption sets the number of recent blocks which could be deleted from the SGDRegistry
+func SGDRollbackRetentionOption(retention uint64) SGDRegistryOption {
+	return func(sgd *sgdRegistry) {
+		sgd.rollbackRetention = retention
+	}
+}
+
 // NewSGDRegistry creates a new SGDIndexer
-func NewSGDRegistry(contract string, startHeight uint64, kv db.KVStore) SGDRegistry {
+func NewSGDRegistry(contract string, startHeight uint64, kv db.KVStore, opts ...SGDRegistryOption) SGDRegistry {
 	if kv == nil {
 		panic("nil kvstore")
 	}
@@ -243,11 +254,15 @@ func NewSGDRegistry(contract string, startHeight uint64, kv db.KVStore) SGDRegis
 			panic("invalid contract address")
 		}
 	}
-	return &sgdRegistry{
+	sgd := &sgdRegistry{
 		contract:    contract,
 		startHeight: startHeight,
 		kvStore:     kv,
 	}
+	for _, opt := range opts {
+		opt(sgd)
+	}
+	return sgd
 }
 
 // Start starts the SGDIndexer
@@ -307,6 +322,9 @@ func (sgd *sgdRegistry) PutBlock(ctx context.Context, blk *block.Block) error {
 		}
 	}
 	b.Put(_sgdToHeightNS, _sgdCurrentHeight, byteutil.Uint64ToBytesBigEndian(blk.Height()), "failed to put current height")
+	if err := db.PutUndoLog(sgd.kvStore, b, blk.Height(), sgd.rollbackRetention); err != nil {
+		return err
+	}
 	return sgd.kvStore.WriteBatch(b)
 }
 
@@ -419,8 +437,19 @@ func (sgd *sgdRegistry) deleteIndex(b batch.KVStoreBatch, contract []byte) error
 }
 
 // DeleteTipBlock deletes the tip block from SGDIndexer
-func (sgd *sgdRegistry) DeleteTipBlock(context.Context, *block.Block) error {
-	return errors.New("cannot remove block from indexer")
+func (sgd *sgdRegistry) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	tipHeight, err := sgd.height()
+	if err != nil {
+		return err
+	}
+	if blk.Height() != tipHeight {
+		return errors.Errorf("invalid block height %d, expect tip %d", blk.Height(), tipHeight)
+	}
+	b, err := db.UndoBatch(sgd.kvStore, blk.Height())
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete block %d from sgd indexer", blk.Height())
+	}
+	return sgd.kvStore.WriteBatch(b)
 }
 
 // CheckContract checks if the contract is a SGD contract
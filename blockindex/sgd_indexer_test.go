@@ -50,7 +50,7 @@ func TestNewSGDRegistry(t *testing.T) {
 		cfg := db.DefaultConfig
 		cfg.DbPath = testDBPath
 		kvStore := db.NewBoltDB(cfg)
-		sgdRegistry := NewSGDRegistry(_testSGDContractAddress, 0, kvStore)
+		sgdRegistry := NewSGDRegistry(_testSGDContractAddress, 0, kvStore, SGDRollbackRetentionOption(2))
 		r.NoError(sgdRegistry.Start(ctx))
 		defer func() {
 			r.NoError(sgdRegistry.Stop(ctx))
@@ -159,6 +159,31 @@ func TestNewSGDRegistry(t *testing.T) {
 			_, err = sgdRegistry.FetchContracts(ctx, blk.Height())
 			r.ErrorIs(err, state.ErrStateNotExist)
 		})
+		t.Run("deleteTipBlock", func(t *testing.T) {
+			blockAt := func(height uint64) *block.Block {
+				blk, err := block.NewTestingBuilder().SetHeight(height).SignAndBuild(identityset.PrivateKey(27))
+				r.NoError(err)
+				return &blk
+			}
+			r.ErrorContains(sgdRegistry.DeleteTipBlock(ctx, blockAt(3)), "invalid block height 3, expect tip 4")
+			// revert removeContract
+			r.NoError(sgdRegistry.DeleteTipBlock(ctx, blockAt(4)))
+			hh, err := sgdRegistry.Height()
+			r.NoError(err)
+			r.Equal(uint64(3), hh)
+			receiver, _, isApproved, err := sgdRegistry.CheckContract(ctx, registerAddress.String(), 3)
+			r.NoError(err)
+			r.Equal(receiverAddress, receiver)
+			r.False(isApproved)
+			// revert disapproveContract
+			r.NoError(sgdRegistry.DeleteTipBlock(ctx, blockAt(3)))
+			receiver, _, isApproved, err = sgdRegistry.CheckContract(ctx, registerAddress.String(), 2)
+			r.NoError(err)
+			r.Equal(receiverAddress, receiver)
+			r.True(isApproved)
+			// the undo log of block 2 is out of retention
+			r.ErrorIs(sgdRegistry.DeleteTipBlock(ctx, blockAt(2)), db.ErrUndoLogNotExist)
+		})
 	})
 	t.Run("heightRestriction", func(t *testing.T) {
 		cases := []struct {
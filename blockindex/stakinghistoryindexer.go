@@ -0,0 +1,790 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/abiutil"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	_stakingHistoryNS       = "sth"
+	_stakingHistoryCountNS  = "stc"
+	_stakingBucketOwnerNS   = "sto"
+	_stakingHistoryHeightNS = "sthh"
+)
+
+// staking events
+const (
+	StakingEventCreate StakingEventType = iota + 1
+	StakingEventDeposit
+	StakingEventChangeCandidate
+	StakingEventTransferIn
+	StakingEventTransferOut
+	StakingEventUnstake
+	StakingEventWithdraw
+	StakingEventEndorse
+	StakingEventUnendorse
+	StakingEventRestake
+	StakingEventLock
+	StakingEventUnlock
+	StakingEventSplit
+	StakingEventMerge
+	StakingEventDonate
+)
+
+var (
+	_stakingHistoryCurrentHeight = []byte("currentHeight")
+
+	_stakingEventNames = map[StakingEventType]string{
+		StakingEventCreate:          "create",
+		StakingEventDeposit:         "deposit",
+		StakingEventChangeCandidate: "changeCandidate",
+		StakingEventTransferIn:      "transferIn",
+		StakingEventTransferOut:     "transferOut",
+		StakingEventUnstake:         "unstake",
+		StakingEventWithdraw:        "withdraw",
+		StakingEventEndorse:         "endorse",
+		StakingEventUnendorse:       "unendorse",
+		StakingEventRestake:         "restake",
+		StakingEventLock:            "lock",
+		StakingEventUnlock:          "unlock",
+		StakingEventSplit:           "split",
+		StakingEventMerge:           "merge",
+		StakingEventDonate:          "donate",
+	}
+
+	// _nativeStakingTopics maps the first topic of the native staking receipt logs to the handler names
+	_nativeStakingTopics = func() map[hash.Hash256]string {
+		topics := make(map[hash.Hash256]string)
+		for _, name := range []string{
+			staking.HandleCreateStake,
+			staking.HandleUnstake,
+			staking.HandleWithdrawStake,
+			staking.HandleChangeCandidate,
+			staking.HandleTransferStake,
+			staking.HandleDepositToStake,
+			staking.HandleRestake,
+			staking.HandleCandidateRegister,
+			staking.HandleCandidateEndorsement,
+			staking.HandleSplitStake,
+			staking.HandleMergeStake,
+		} {
+			topics[hash.BytesToHash256([]byte(name))] = name
+		}
+		return topics
+	}()
+
+	errInvalidStakingEvent = errors.New("invalid staking event data")
+)
+
+type (
+	// StakingEventType is the type of a bucket lifecycle event
+	StakingEventType uint8
+
+	// StakingEvent is a lifecycle event of a staking bucket
+	StakingEvent struct {
+		Type StakingEventType
+		// Contract is the address of the staking contract, empty for native staking
+		Contract    string
+		BucketIndex uint64
+		Height      uint64
+		ActionHash  hash.Hash256
+		// Candidate is the candidate the bucket votes for, nil if not known by the event
+		Candidate address.Address
+		// Amount is the staked amount of create, the deposited amount of deposit, the amount split out
+		// of split, the donated amount of donate, and the bucket amount after the event of contract
+		// merge and expand. It is nil for the other events
+		Amount *big.Int
+		// Counterparty is the other owner of transfer, and the beneficiary of donate
+		Counterparty address.Address
+		// Related are the new bucket of split, and the buckets merged into the bucket of merge
+		Related []uint64
+	}
+
+	// StakingHistoryIndexer is the indexer of the lifecycle events of staking buckets by owner, which
+	// covers the native staking and the system staking contracts
+	StakingHistoryIndexer interface {
+		blockdao.BlockIndexer
+		// StakingHistory returns the events of the buckets owned by the address in the order they
+		// happened, starting from offset, together with the total number of events of the address
+		StakingHistory(owner address.Address, offset, limit uint64) ([]*StakingEvent, uint64, error)
+	}
+
+	// stakingHistoryIndexer derives the events from the receipt logs. The native staking events are
+	// parsed from the receipt logs of the new staking receipt format, which has the bucket index as
+	// a topic, while the contract staking events are parsed with the system staking contract abi
+	stakingHistoryIndexer struct {
+		kvStore           db.KVStore
+		contracts         map[string]struct{}
+		rollbackRetention uint64
+	}
+
+	// StakingHistoryIndexerOption is the option to create the StakingHistoryIndexer
+	StakingHistoryIndexerOption func(*stakingHistoryIndexer)
+
+	// stakingHistoryDelta holds the changes of a block, with a view of the counts and owners
+	// updated in the block
+	stakingHistoryDelta struct {
+		kvStore db.KVStore
+		batch   batch.KVStoreBatch
+		counts  map[string]uint64
+		owners  map[string]address.Address
+	}
+)
+
+// String returns the name of the event type
+func (t StakingEventType) String() string {
+	if name, ok := _stakingEventNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// StakingHistoryRollbackRetentionOption sets the number of recent blocks which could be deleted from the indexer
+func StakingHistoryRollbackRetentionOption(retention uint64) StakingHistoryIndexerOption {
+	return func(shi *stakingHistoryIndexer) {
+		shi.rollbackRetention = retention
+	}
+}
+
+// NewStakingHistoryIndexer creates a new staking history indexer of the native staking and the staking contracts
+func NewStakingHistoryIndexer(kv db.KVStore, contracts []string, opts ...StakingHistoryIndexerOption) (StakingHistoryIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	shi := &stakingHistoryIndexer{
+		kvStore:   kv,
+		contracts: make(map[string]struct{}),
+	}
+	for _, contract := range contracts {
+		if contract == "" {
+			continue
+		}
+		if _, err := address.FromString(contract); err != nil {
+			return nil, errors.Wrapf(err, "invalid staking contract address %s", contract)
+		}
+		shi.contracts[contract] = struct{}{}
+	}
+	for _, opt := range opts {
+		opt(shi)
+	}
+	return shi, nil
+}
+
+// Start starts the indexer
+func (shi *stakingHistoryIndexer) Start(ctx context.Context) error {
+	return shi.kvStore.Start(ctx)
+}
+
+// Stop stops the indexer
+func (shi *stakingHistoryIndexer) Stop(ctx context.Context) error {
+	return shi.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the latest indexed block
+func (shi *stakingHistoryIndexer) Height() (uint64, error) {
+	value, err := shi.kvStore.Get(_stakingHistoryHeightNS, _stakingHistoryCurrentHeight)
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(value), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// PutBlock indexes the staking events of the block
+func (shi *stakingHistoryIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	tipHeight, err := shi.Height()
+	if err != nil {
+		return err
+	}
+	if blk.Height() <= tipHeight {
+		return nil
+	}
+	if blk.Height() > tipHeight+1 {
+		return errors.Errorf("invalid block height %d, expect %d", blk.Height(), tipHeight+1)
+	}
+	acts := make(map[hash.Hash256]*action.SealedEnvelope, len(blk.Actions))
+	for _, selp := range blk.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			return err
+		}
+		acts[h] = selp
+	}
+	delta := &stakingHistoryDelta{
+		kvStore: shi.kvStore,
+		batch:   batch.NewBatch(),
+		counts:  make(map[string]uint64),
+		owners:  make(map[string]address.Address),
+	}
+	for _, receipt := range blk.Receipts {
+		if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+			continue
+		}
+		for _, log := range receipt.Logs() {
+			if len(log.Topics) == 0 {
+				continue
+			}
+			if log.Address == address.StakingProtocolAddr {
+				selp, ok := acts[receipt.ActionHash]
+				if !ok {
+					return errors.Errorf("action %x of receipt does not exist in block %d", receipt.ActionHash, blk.Height())
+				}
+				if err := delta.handleNativeLog(log, blk.Height(), selp); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, ok := shi.contracts[log.Address]; ok {
+				if err := delta.handleContractLog(log, blk.Height(), receipt.ActionHash); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	delta.batch.Put(_stakingHistoryHeightNS, _stakingHistoryCurrentHeight, byteutil.Uint64ToBytesBigEndian(blk.Height()), "failed to put current height")
+	if err := db.PutUndoLog(shi.kvStore, delta.batch, blk.Height(), shi.rollbackRetention); err != nil {
+		return err
+	}
+	return shi.kvStore.WriteBatch(delta.batch)
+}
+
+// DeleteTipBlock reverts the staking events of the tip block
+func (shi *stakingHistoryIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	tipHeight, err := shi.Height()
+	if err != nil {
+		return err
+	}
+	if blk.Height() != tipHeight {
+		return errors.Errorf("invalid block height %d, expect tip %d", blk.Height(), tipHeight)
+	}
+	b, err := db.UndoBatch(shi.kvStore, blk.Height())
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete block %d from staking history indexer", blk.Height())
+	}
+	return shi.kvStore.WriteBatch(b)
+}
+
+// StakingHistory returns the events of the buckets owned by the address
+func (shi *stakingHistoryIndexer) StakingHistory(owner address.Address, offset, limit uint64) ([]*StakingEvent, uint64, error) {
+	total, err := getStakingEventCount(shi.kvStore, owner)
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset >= total || limit == 0 {
+		return nil, total, nil
+	}
+	end := offset + limit
+	if end > total || end < offset {
+		end = total
+	}
+	events := make([]*StakingEvent, 0, end-offset)
+	for i := offset; i < end; i++ {
+		value, err := shi.kvStore.Get(_stakingHistoryNS, stakingEventKey(owner, i))
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to get event %d of %s", i, owner.String())
+		}
+		event, err := deserializeStakingEvent(value)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+	return events, total, nil
+}
+
+func (delta *stakingHistoryDelta) handleNativeLog(log *action.Log, height uint64, selp *action.SealedEnvelope) error {
+	actHash, err := selp.Hash()
+	if err != nil {
+		return err
+	}
+	name, ok := _nativeStakingTopics[log.Topics[0]]
+	if !ok || len(log.Topics) < 2 {
+		// the logs of the old staking receipt format don't carry the bucket index
+		return nil
+	}
+	var (
+		sender = selp.SenderAddress()
+		topic  = func(i int) (address.Address, error) {
+			if len(log.Topics) <= i {
+				return nil, errors.Wrapf(errInvalidStakingEvent, "missing topic %d of %s", i, name)
+			}
+			return address.FromBytes(log.Topics[i][12:])
+		}
+		index = func(i int) uint64 {
+			return byteutil.BytesToUint64BigEndian(log.Topics[i][24:])
+		}
+		event = &StakingEvent{
+			BucketIndex: index(1),
+			Height:      height,
+			ActionHash:  actHash,
+		}
+		owner address.Address
+	)
+	switch name {
+	case staking.HandleCreateStake:
+		event.Type, owner = StakingEventCreate, sender
+		if act, ok := selp.Action().(*action.CreateStake); ok {
+			event.Amount = act.Amount()
+		}
+		delta.putOwner(nil, event.BucketIndex, owner)
+	case staking.HandleCandidateRegister:
+		act, ok := selp.Action().(*action.CandidateRegister)
+		if !ok || act.Amount().Sign() == 0 {
+			// registered without self-stake bucket
+			return nil
+		}
+		event.Type, event.Amount, owner = StakingEventCreate, act.Amount(), sender
+		if act.OwnerAddress() != nil {
+			owner = act.OwnerAddress()
+		}
+		delta.putOwner(nil, event.BucketIndex, owner)
+	case staking.HandleDepositToStake:
+		event.Type = StakingEventDeposit
+		if owner, err = topic(2); err != nil {
+			return err
+		}
+		if event.Candidate, err = topic(3); err != nil {
+			return err
+		}
+		if act, ok := selp.Action().(*action.DepositToStake); ok {
+			event.Amount = act.Amount()
+		}
+		return delta.addEvent(owner, event)
+	case staking.HandleTransferStake:
+		newOwner, err := topic(2)
+		if err != nil {
+			return err
+		}
+		if event.Candidate, err = topic(3); err != nil {
+			return err
+		}
+		if owner, err = delta.ownerOrSender(nil, event.BucketIndex, sender); err != nil {
+			return err
+		}
+		if address.Equal(owner, newOwner) {
+			return nil
+		}
+		out := *event
+		out.Type, out.Counterparty = StakingEventTransferOut, newOwner
+		if err := delta.addEvent(owner, &out); err != nil {
+			return err
+		}
+		event.Type, event.Counterparty = StakingEventTransferIn, owner
+		delta.putOwner(nil, event.BucketIndex, newOwner)
+		return delta.addEvent(newOwner, event)
+	case staking.HandleChangeCandidate:
+		event.Type = StakingEventChangeCandidate
+		if owner, err = delta.ownerOrSender(nil, event.BucketIndex, sender); err != nil {
+			return err
+		}
+		if event.Candidate, err = topic(3); err != nil {
+			return err
+		}
+		return delta.addEvent(owner, event)
+	case staking.HandleUnstake, staking.HandleWithdrawStake, staking.HandleRestake:
+		event.Type = map[string]StakingEventType{
+			staking.HandleUnstake:       StakingEventUnstake,
+			staking.HandleWithdrawStake: StakingEventWithdraw,
+			staking.HandleRestake:       StakingEventRestake,
+		}[name]
+		if owner, err = delta.ownerOrSender(nil, event.BucketIndex, sender); err != nil {
+			return err
+		}
+	case staking.HandleCandidateEndorsement:
+		if len(log.Topics) < 4 {
+			return errors.Wrapf(errInvalidStakingEvent, "missing topic 3 of %s", name)
+		}
+		event.Type = StakingEventUnendorse
+		if log.Topics[3][31] != 0 {
+			event.Type = StakingEventEndorse
+		}
+		if owner, err = delta.ownerOrSender(nil, event.BucketIndex, sender); err != nil {
+			return err
+		}
+	case staking.HandleSplitStake:
+		if len(log.Topics) < 4 {
+			return errors.Wrapf(errInvalidStakingEvent, "missing topic 3 of %s", name)
+		}
+		event.Type, event.Related = StakingEventSplit, []uint64{index(3)}
+		if act, ok := selp.Action().(*action.SplitStake); ok {
+			event.Amount = act.Amount()
+		}
+		if owner, err = delta.ownerOrSender(nil, event.BucketIndex, sender); err != nil {
+			return err
+		}
+		delta.putOwner(nil, index(3), owner)
+	case staking.HandleMergeStake:
+		event.Type = StakingEventMerge
+		for i := 3; i < len(log.Topics); i++ {
+			event.Related = append(event.Related, index(i))
+		}
+		if owner, err = delta.ownerOrSender(nil, event.BucketIndex, sender); err != nil {
+			return err
+		}
+	}
+	if event.Candidate, err = topic(2); err != nil {
+		return err
+	}
+	return delta.addEvent(owner, event)
+}
+
+func (delta *stakingHistoryDelta) handleContractLog(log *action.Log, height uint64, actHash hash.Hash256) error {
+	abiEvent, err := staking.StakingContractABI.EventByID(common.Hash(log.Topics[0]))
+	if err != nil {
+		// not a staking event
+		return nil
+	}
+	param, err := abiutil.UnpackEventParam(abiEvent, log)
+	if err != nil {
+		return err
+	}
+	contract, err := address.FromString(log.Address)
+	if err != nil {
+		return err
+	}
+	if abiEvent.Name == "Transfer" {
+		return delta.handleContractTransfer(contract, param, height, actHash)
+	}
+	switch abiEvent.Name {
+	case "Staked", "Locked", "Unlocked", "Unstaked", "Merged", "BucketExpanded", "DelegateChanged", "Withdrawal", "Donated":
+	default:
+		return nil
+	}
+	event := &StakingEvent{
+		Contract:   log.Address,
+		Height:     height,
+		ActionHash: actHash,
+	}
+	if abiEvent.Name == "Merged" {
+		ids, err := param.FieldByIDUint256Slice(0)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return errors.Wrap(errInvalidStakingEvent, "no bucket merged")
+		}
+		event.BucketIndex = ids[0].Uint64()
+		for _, id := range ids[1:] {
+			event.Related = append(event.Related, id.Uint64())
+		}
+	} else {
+		id, err := param.FieldByIDUint256(0)
+		if err != nil {
+			return err
+		}
+		event.BucketIndex = id.Uint64()
+	}
+	switch abiEvent.Name {
+	case "Staked":
+		event.Type = StakingEventCreate
+		if event.Candidate, err = param.FieldByIDAddress(1); err != nil {
+			return err
+		}
+		if event.Amount, err = param.FieldByIDUint256(2); err != nil {
+			return err
+		}
+	case "Locked":
+		event.Type = StakingEventLock
+	case "Unlocked":
+		event.Type = StakingEventUnlock
+	case "Unstaked":
+		event.Type = StakingEventUnstake
+	case "Merged":
+		event.Type = StakingEventMerge
+		if event.Amount, err = param.FieldByIDUint256(1); err != nil {
+			return err
+		}
+	case "BucketExpanded":
+		event.Type = StakingEventDeposit
+		if event.Amount, err = param.FieldByIDUint256(1); err != nil {
+			return err
+		}
+	case "DelegateChanged":
+		event.Type = StakingEventChangeCandidate
+		if event.Candidate, err = param.FieldByIDAddress(1); err != nil {
+			return err
+		}
+	case "Withdrawal":
+		event.Type = StakingEventWithdraw
+	case "Donated":
+		event.Type = StakingEventDonate
+		if event.Counterparty, err = param.FieldByIDAddress(1); err != nil {
+			return err
+		}
+		if event.Amount, err = param.FieldByIDUint256(2); err != nil {
+			return err
+		}
+	}
+	owner, err := delta.owner(contract.Bytes(), event.BucketIndex)
+	if err != nil {
+		return err
+	}
+	if owner == nil {
+		return errors.Errorf("no owner for bucket %d of contract %s", event.BucketIndex, log.Address)
+	}
+	return delta.addEvent(owner, event)
+}
+
+func (delta *stakingHistoryDelta) handleContractTransfer(contract address.Address, param *abiutil.EventParam, height uint64, actHash hash.Hash256) error {
+	from, err := param.FieldByIDAddress(0)
+	if err != nil {
+		return err
+	}
+	to, err := param.FieldByIDAddress(1)
+	if err != nil {
+		return err
+	}
+	id, err := param.FieldByIDUint256(2)
+	if err != nil {
+		return err
+	}
+	var (
+		zero  = make([]byte, len(from.Bytes()))
+		index = id.Uint64()
+	)
+	switch {
+	case bytes.Equal(from.Bytes(), zero):
+		// minted, the event is recorded by the following Staked
+		delta.putOwner(contract.Bytes(), index, to)
+		return nil
+	case bytes.Equal(to.Bytes(), zero):
+		// burned, the owner is kept for the Withdrawal in the same transaction
+		return nil
+	}
+	event := &StakingEvent{
+		Type:         StakingEventTransferOut,
+		Contract:     contract.String(),
+		BucketIndex:  index,
+		Height:       height,
+		ActionHash:   actHash,
+		Counterparty: to,
+	}
+	if err := delta.addEvent(from, event); err != nil {
+		return err
+	}
+	in := *event
+	in.Type, in.Counterparty = StakingEventTransferIn, from
+	delta.putOwner(contract.Bytes(), index, to)
+	return delta.addEvent(to, &in)
+}
+
+// ownerOrSender returns the owner of the bucket, or the sender if the bucket is not indexed, which
+// is the case of the buckets created in genesis
+func (delta *stakingHistoryDelta) ownerOrSender(contract []byte, index uint64, sender address.Address) (address.Address, error) {
+	owner, err := delta.owner(contract, index)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return sender, nil
+	}
+	return owner, nil
+}
+
+func (delta *stakingHistoryDelta) owner(contract []byte, index uint64) (address.Address, error) {
+	key := bucketOwnerKey(contract, index)
+	if owner, ok := delta.owners[string(key)]; ok {
+		return owner, nil
+	}
+	value, err := delta.kvStore.Get(_stakingBucketOwnerNS, key)
+	switch errors.Cause(err) {
+	case nil:
+		return address.FromBytes(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func (delta *stakingHistoryDelta) putOwner(contract []byte, index uint64, owner address.Address) {
+	key := bucketOwnerKey(contract, index)
+	delta.owners[string(key)] = owner
+	delta.batch.Put(_stakingBucketOwnerNS, key, owner.Bytes(), "failed to put owner of bucket")
+}
+
+func (delta *stakingHistoryDelta) addEvent(owner address.Address, event *StakingEvent) error {
+	count, ok := delta.counts[owner.String()]
+	if !ok {
+		var err error
+		if count, err = getStakingEventCount(delta.kvStore, owner); err != nil {
+			return err
+		}
+	}
+	delta.batch.Put(_stakingHistoryNS, stakingEventKey(owner, count), serializeStakingEvent(event), "failed to put staking event")
+	delta.counts[owner.String()] = count + 1
+	delta.batch.Put(_stakingHistoryCountNS, owner.Bytes(), byteutil.Uint64ToBytesBigEndian(count+1), "failed to put staking event count")
+	return nil
+}
+
+func getStakingEventCount(kv db.KVStore, owner address.Address) (uint64, error) {
+	value, err := kv.Get(_stakingHistoryCountNS, owner.Bytes())
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(value), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+func stakingEventKey(owner address.Address, seq uint64) []byte {
+	return append(owner.Bytes(), byteutil.Uint64ToBytesBigEndian(seq)...)
+}
+
+// bucketOwnerKey returns the key of the owner of a bucket, the contract is nil for native staking
+func bucketOwnerKey(contract []byte, index uint64) []byte {
+	return append(append([]byte{}, contract...), byteutil.Uint64ToBytesBigEndian(index)...)
+}
+
+// serializeStakingEvent encodes the event as the type followed by the fields, the variable-length
+// fields are length-prefixed, and the numbers are uvarints
+func serializeStakingEvent(event *StakingEvent) []byte {
+	var (
+		data        = []byte{byte(event.Type)}
+		appendBytes = func(data, b []byte) []byte {
+			data = binary.AppendUvarint(data, uint64(len(b)))
+			return append(data, b...)
+		}
+		appendAddress = func(data []byte, addr address.Address) []byte {
+			if addr == nil {
+				return appendBytes(data, nil)
+			}
+			return appendBytes(data, addr.Bytes())
+		}
+	)
+	data = appendBytes(data, []byte(event.Contract))
+	data = binary.AppendUvarint(data, event.BucketIndex)
+	data = binary.AppendUvarint(data, event.Height)
+	data = append(data, event.ActionHash[:]...)
+	data = appendAddress(data, event.Candidate)
+	if event.Amount == nil {
+		data = append(data, 0)
+	} else {
+		data = appendBytes(append(data, 1), event.Amount.Bytes())
+	}
+	data = appendAddress(data, event.Counterparty)
+	data = binary.AppendUvarint(data, uint64(len(event.Related)))
+	for _, index := range event.Related {
+		data = binary.AppendUvarint(data, index)
+	}
+	return data
+}
+
+func deserializeStakingEvent(data []byte) (*StakingEvent, error) {
+	readUvarint := func() (uint64, error) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, errInvalidStakingEvent
+		}
+		data = data[n:]
+		return v, nil
+	}
+	readBytes := func() ([]byte, error) {
+		l, err := readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(data)) < l {
+			return nil, errInvalidStakingEvent
+		}
+		b := make([]byte, l)
+		copy(b, data)
+		data = data[l:]
+		return b, nil
+	}
+	readAddress := func() (address.Address, error) {
+		b, err := readBytes()
+		if err != nil || len(b) == 0 {
+			return nil, err
+		}
+		return address.FromBytes(b)
+	}
+	if len(data) == 0 {
+		return nil, errInvalidStakingEvent
+	}
+	event := &StakingEvent{Type: StakingEventType(data[0])}
+	data = data[1:]
+	contract, err := readBytes()
+	if err != nil {
+		return nil, err
+	}
+	event.Contract = string(contract)
+	if event.BucketIndex, err = readUvarint(); err != nil {
+		return nil, err
+	}
+	if event.Height, err = readUvarint(); err != nil {
+		return nil, err
+	}
+	if len(data) < len(event.ActionHash) {
+		return nil, errInvalidStakingEvent
+	}
+	copy(event.ActionHash[:], data)
+	data = data[len(event.ActionHash):]
+	if event.Candidate, err = readAddress(); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errInvalidStakingEvent
+	}
+	flag := data[0]
+	data = data[1:]
+	switch flag {
+	case 0:
+	case 1:
+		amount, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		event.Amount = new(big.Int).SetBytes(amount)
+	default:
+		return nil, errInvalidStakingEvent
+	}
+	if event.Counterparty, err = readAddress(); err != nil {
+		return nil, err
+	}
+	size, err := readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if size > uint64(len(data)) {
+		return nil, errInvalidStakingEvent
+	}
+	for i := uint64(0); i < size; i++ {
+		index, err := readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		event.Related = append(event.Related, index)
+	}
+	if len(data) != 0 {
+		return nil, errInvalidStakingEvent
+	}
+	return event, nil
+}
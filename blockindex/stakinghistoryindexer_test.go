@@ -0,0 +1,198 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestStakingHistoryIndexer(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewStakingHistoryIndexer(nil, nil)
+	r.Error(err)
+	_, err = NewStakingHistoryIndexer(db.NewMemKVStore(), []string{"invalid"})
+	r.Error(err)
+
+	var (
+		ctx      = context.Background()
+		contract = identityset.Address(30)
+		owner1   = identityset.Address(1)
+		owner2   = identityset.Address(2)
+		cand     = identityset.Address(3)
+		gasPrice = big.NewInt(1)
+	)
+	indexer, err := NewStakingHistoryIndexer(db.NewMemKVStore(), []string{contract.String(), ""}, StakingHistoryRollbackRetentionOption(10))
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+
+	nativeLog := func(name string, topics ...[]byte) *action.Log {
+		log := &action.Log{
+			Address: address.StakingProtocolAddr,
+			Topics:  action.Topics{hash.BytesToHash256([]byte(name))},
+		}
+		for _, topic := range topics {
+			log.Topics = append(log.Topics, hash.BytesToHash256(topic))
+		}
+		return log
+	}
+	contractLog := func(name string, topics []hash.Hash256, data ...any) *action.Log {
+		event := staking.StakingContractABI.Events[name]
+		packed, err := event.Inputs.NonIndexed().Pack(data...)
+		r.NoError(err)
+		return &action.Log{
+			Address: contract.String(),
+			Topics:  append(action.Topics{hash.Hash256(event.ID)}, topics...),
+			Data:    packed,
+		}
+	}
+	putBlock := func(height uint64, acts []*action.SealedEnvelope, logs [][]*action.Log) *block.Block {
+		receipts := make([]*action.Receipt, 0, len(acts))
+		for i, selp := range acts {
+			h, err := selp.Hash()
+			r.NoError(err)
+			receipt := &action.Receipt{
+				Status:      uint64(iotextypes.ReceiptStatus_Success),
+				BlockHeight: height,
+				ActionHash:  h,
+			}
+			receipts = append(receipts, receipt.AddLogs(logs[i]...))
+		}
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			AddActions(acts...).
+			SetReceipts(receipts).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		r.NoError(indexer.PutBlock(ctx, &blk))
+		return &blk
+	}
+	index := func(i uint64) []byte { return byteutil.Uint64ToBytesBigEndian(i) }
+
+	// block 1: owner1 creates bucket 5 and owner2 deposits to a bucket created in genesis
+	create, err := action.SignedCreateStake(1, "cand", "100", 1, true, nil, 10000, gasPrice, identityset.PrivateKey(1))
+	r.NoError(err)
+	deposit, err := action.SignedDepositToStake(1, 1, "20", nil, 10000, gasPrice, identityset.PrivateKey(2))
+	r.NoError(err)
+	putBlock(1, []*action.SealedEnvelope{create, deposit}, [][]*action.Log{
+		{nativeLog(staking.HandleCreateStake, index(5), cand.Bytes())},
+		{nativeLog(staking.HandleDepositToStake, index(1), owner2.Bytes(), cand.Bytes())},
+	})
+
+	// block 2: owner1 transfers bucket 5 to owner2, who then unstakes it
+	transfer, err := action.SignedTransferStake(2, owner2.String(), 5, nil, 10000, gasPrice, identityset.PrivateKey(1))
+	r.NoError(err)
+	unstake, err := action.SignedReclaimStake(false, 2, 5, nil, 10000, gasPrice, identityset.PrivateKey(2))
+	r.NoError(err)
+	putBlock(2, []*action.SealedEnvelope{transfer, unstake}, [][]*action.Log{
+		{nativeLog(staking.HandleTransferStake, index(5), owner2.Bytes(), cand.Bytes())},
+		{nativeLog(staking.HandleUnstake, index(5), cand.Bytes())},
+	})
+
+	// block 3: owner1 stakes token 7 in the contract
+	exec, err := action.SignedExecution(contract.String(), identityset.PrivateKey(1), 3, big.NewInt(100), 10000, gasPrice, nil)
+	r.NoError(err)
+	tokenID := hash.BytesToHash256(index(7))
+	blk3 := putBlock(3, []*action.SealedEnvelope{exec}, [][]*action.Log{{
+		contractLog("Transfer", []hash.Hash256{{}, hash.BytesToHash256(owner1.Bytes()), tokenID}),
+		contractLog("Staked", []hash.Hash256{tokenID}, common.BytesToAddress(cand.Bytes()), big.NewInt(100), big.NewInt(1000)),
+	}})
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(3), height)
+	r.NoError(indexer.PutBlock(ctx, blk3))
+	blk5, err := block.NewTestingBuilder().SetHeight(5).SignAndBuild(identityset.PrivateKey(27))
+	r.NoError(err)
+	r.Error(indexer.PutBlock(ctx, &blk5))
+
+	events, total, err := indexer.StakingHistory(owner1, 0, 10)
+	r.NoError(err)
+	r.Equal(uint64(3), total)
+	r.Len(events, 3)
+	r.Equal(StakingEventCreate, events[0].Type)
+	r.Equal(uint64(5), events[0].BucketIndex)
+	r.Equal("100", events[0].Amount.String())
+	r.Equal(cand.String(), events[0].Candidate.String())
+	r.Empty(events[0].Contract)
+	r.Equal(StakingEventTransferOut, events[1].Type)
+	r.Equal(owner2.String(), events[1].Counterparty.String())
+	r.Equal(uint64(2), events[1].Height)
+	r.Equal(StakingEventCreate, events[2].Type)
+	r.Equal(contract.String(), events[2].Contract)
+	r.Equal(uint64(7), events[2].BucketIndex)
+	r.Equal("100", events[2].Amount.String())
+
+	events, total, err = indexer.StakingHistory(owner2, 1, 2)
+	r.NoError(err)
+	r.Equal(uint64(3), total)
+	r.Len(events, 2)
+	r.Equal(StakingEventTransferIn, events[0].Type)
+	r.Equal(owner1.String(), events[0].Counterparty.String())
+	r.Equal(StakingEventUnstake, events[1].Type)
+	r.Equal("unstake", events[1].Type.String())
+	events, _, err = indexer.StakingHistory(owner2, 0, 1)
+	r.NoError(err)
+	r.Equal(StakingEventDeposit, events[0].Type)
+	r.Equal("20", events[0].Amount.String())
+	events, _, err = indexer.StakingHistory(owner2, 3, 1)
+	r.NoError(err)
+	r.Empty(events)
+
+	// the events of the tip block are reverted
+	r.Error(indexer.DeleteTipBlock(ctx, &blk5))
+	r.NoError(indexer.DeleteTipBlock(ctx, blk3))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(2), height)
+	_, total, err = indexer.StakingHistory(owner1, 0, 10)
+	r.NoError(err)
+	r.Equal(uint64(2), total)
+}
+
+func TestStakingEventSerialization(t *testing.T) {
+	r := require.New(t)
+	for _, event := range []*StakingEvent{
+		{Type: StakingEventWithdraw, BucketIndex: 1, Height: 2},
+		{
+			Type:         StakingEventMerge,
+			Contract:     identityset.Address(30).String(),
+			BucketIndex:  1,
+			Height:       2,
+			ActionHash:   hash.Hash256b([]byte("merge")),
+			Candidate:    identityset.Address(1),
+			Amount:       big.NewInt(300),
+			Counterparty: identityset.Address(2),
+			Related:      []uint64{3, 1 << 40},
+		},
+	} {
+		data := serializeStakingEvent(event)
+		decoded, err := deserializeStakingEvent(data)
+		r.NoError(err)
+		r.Equal(event, decoded)
+		_, err = deserializeStakingEvent(data[:len(data)-1])
+		r.Error(err)
+	}
+	_, err := deserializeStakingEvent(nil)
+	r.Error(err)
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex/indexpb"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+const (
+	_stateDiffNS       = "sd"
+	_stateDiffHeightNS = "sdh"
+)
+
+var (
+	_stateDiffCurrentHeight = []byte("currentHeight")
+	_stateDiffGaps          = []byte("gaps")
+
+	// ErrStateDiffNotExist indicates the state diff of a block is not indexed
+	ErrStateDiffNotExist = errors.New("state diff does not exist")
+	// ErrStateDiffMissing indicates the block is committed after the indexer is enabled, but the
+	// indexer failed to receive its state diff
+	ErrStateDiffMissing = errors.New("state diff is missing")
+)
+
+type (
+	// StateDiffIndexer is the indexer of the states changed by each block
+	StateDiffIndexer interface {
+		lifecycle.StartStopper
+		factory.StateChangeSubscriber
+		// Height returns the height of the latest indexed block
+		Height() (uint64, error)
+		// StateDiff returns the state changes of the block at the height
+		StateDiff(height uint64) ([]*factory.StateChange, error)
+	}
+
+	// stateDiffIndexer stores the state changes received from the state db, so only the blocks
+	// committed after the indexer is enabled are indexed. The blocks whose state changes failed
+	// to be received are recorded as gaps
+	stateDiffIndexer struct {
+		kvStore db.KVStore
+	}
+
+	// stateDiffGap is a range of blocks missing in the indexer
+	stateDiffGap struct {
+		start, end uint64
+	}
+)
+
+// NewStateDiffIndexer creates a new state diff indexer
+func NewStateDiffIndexer(kv db.KVStore) (StateDiffIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	return &stateDiffIndexer{kvStore: kv}, nil
+}
+
+// Start starts the indexer
+func (sdi *stateDiffIndexer) Start(ctx context.Context) error {
+	return sdi.kvStore.Start(ctx)
+}
+
+// Stop stops the indexer
+func (sdi *stateDiffIndexer) Stop(ctx context.Context) error {
+	return sdi.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the latest indexed block
+func (sdi *stateDiffIndexer) Height() (uint64, error) {
+	value, err := sdi.kvStore.Get(_stateDiffHeightNS, _stateDiffCurrentHeight)
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(value), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// ReceiveStateChanges stores the state changes of a block
+func (sdi *stateDiffIndexer) ReceiveStateChanges(height uint64, changes []*factory.StateChange) error {
+	tip, err := sdi.Height()
+	if err != nil {
+		return err
+	}
+	b := batch.NewBatch()
+	if tip > 0 && height > tip+1 {
+		gaps, err := sdi.gaps()
+		if err != nil {
+			return err
+		}
+		gaps = addStateDiffGap(gaps, stateDiffGap{start: tip + 1, end: height - 1})
+		b.Put(_stateDiffHeightNS, _stateDiffGaps, serializeStateDiffGaps(gaps), "failed to put gaps")
+	}
+	key := byteutil.Uint64ToBytesBigEndian(height)
+	b.Put(_stateDiffNS, key, serializeStateChanges(changes), "failed to put state diff")
+	b.Put(_stateDiffHeightNS, _stateDiffCurrentHeight, key, "failed to put current height")
+	return sdi.kvStore.WriteBatch(b)
+}
+
+// DeleteTipBlock deletes the state changes of the tip block
+func (sdi *stateDiffIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	tip, err := sdi.Height()
+	if err != nil {
+		return err
+	}
+	height := blk.Height()
+	switch {
+	case height > tip:
+		// the block is not indexed
+		return nil
+	case height < tip:
+		return errors.Errorf("cannot delete block %d, indexer height is %d", height, tip)
+	}
+	b := batch.NewBatch()
+	b.Delete(_stateDiffNS, byteutil.Uint64ToBytesBigEndian(height), "failed to delete state diff")
+	b.Put(_stateDiffHeightNS, _stateDiffCurrentHeight, byteutil.Uint64ToBytesBigEndian(height-1), "failed to put current height")
+	return sdi.kvStore.WriteBatch(b)
+}
+
+// StateDiff returns the state changes of the block at the height
+func (sdi *stateDiffIndexer) StateDiff(height uint64) ([]*factory.StateChange, error) {
+	value, err := sdi.kvStore.Get(_stateDiffNS, byteutil.Uint64ToBytesBigEndian(height))
+	switch errors.Cause(err) {
+	case nil:
+		return deserializeStateChanges(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		gaps, err := sdi.gaps()
+		if err != nil {
+			return nil, err
+		}
+		for _, gap := range gaps {
+			if height >= gap.start && height <= gap.end {
+				return nil, errors.Wrapf(ErrStateDiffMissing, "height = %d", height)
+			}
+		}
+		return nil, errors.Wrapf(ErrStateDiffNotExist, "height = %d", height)
+	default:
+		return nil, err
+	}
+}
+
+func (sdi *stateDiffIndexer) gaps() ([]stateDiffGap, error) {
+	value, err := sdi.kvStore.Get(_stateDiffHeightNS, _stateDiffGaps)
+	switch errors.Cause(err) {
+	case nil:
+		return deserializeStateDiffGaps(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+// addStateDiffGap adds the gap to the gaps sorted by start, and merges the overlapping or adjacent ones
+func addStateDiffGap(gaps []stateDiffGap, gap stateDiffGap) []stateDiffGap {
+	i := sort.Search(len(gaps), func(i int) bool {
+		return gaps[i].start > gap.start
+	})
+	gaps = append(gaps[:i], append([]stateDiffGap{gap}, gaps[i:]...)...)
+	merged := gaps[:1]
+	for _, g := range gaps[1:] {
+		last := &merged[len(merged)-1]
+		if g.start > last.end+1 {
+			merged = append(merged, g)
+			continue
+		}
+		if g.end > last.end {
+			last.end = g.end
+		}
+	}
+	return merged
+}
+
+func serializeStateDiffGaps(gaps []stateDiffGap) []byte {
+	pb := &indexpb.StateDiffGaps{
+		Gaps: make([]*indexpb.StateDiffGap, 0, len(gaps)),
+	}
+	for _, gap := range gaps {
+		pb.Gaps = append(pb.Gaps, &indexpb.StateDiffGap{
+			Start: gap.start,
+			End:   gap.end,
+		})
+	}
+	return byteutil.Must(proto.Marshal(pb))
+}
+
+func deserializeStateDiffGaps(data []byte) ([]stateDiffGap, error) {
+	pb := &indexpb.StateDiffGaps{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, err
+	}
+	gaps := make([]stateDiffGap, 0, len(pb.Gaps))
+	for _, gap := range pb.Gaps {
+		gaps = append(gaps, stateDiffGap{
+			start: gap.Start,
+			end:   gap.End,
+		})
+	}
+	return gaps, nil
+}
+
+// serializeStateChanges encodes the changes as indexpb.StateChanges, a value not existing is
+// encoded as a nil indexpb.StateValue, to be distinguished from an empty value
+func serializeStateChanges(changes []*factory.StateChange) []byte {
+	toValue := func(v []byte) *indexpb.StateValue {
+		if v == nil {
+			return nil
+		}
+		return &indexpb.StateValue{Value: v}
+	}
+	pb := &indexpb.StateChanges{
+		Changes: make([]*indexpb.StateChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		pb.Changes = append(pb.Changes, &indexpb.StateChange{
+			Namespace: change.Namespace,
+			Key:       change.Key,
+			OldValue:  toValue(change.OldValue),
+			NewValue:  toValue(change.NewValue),
+		})
+	}
+	return byteutil.Must(proto.Marshal(pb))
+}
+
+func deserializeStateChanges(data []byte) ([]*factory.StateChange, error) {
+	fromValue := func(v *indexpb.StateValue) []byte {
+		if v == nil {
+			return nil
+		}
+		return append([]byte{}, v.Value...)
+	}
+	pb := &indexpb.StateChanges{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, err
+	}
+	changes := make([]*factory.StateChange, 0, len(pb.Changes))
+	for _, change := range pb.Changes {
+		changes = append(changes, &factory.StateChange{
+			Namespace: change.Namespace,
+			Key:       change.Key,
+			OldValue:  fromValue(change.OldValue),
+			NewValue:  fromValue(change.NewValue),
+		})
+	}
+	return changes, nil
+}
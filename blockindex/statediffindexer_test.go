@@ -0,0 +1,140 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func newBlockAtHeight(t *testing.T, height uint64) *block.Block {
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(t, err)
+	return &blk
+}
+
+func TestStateDiffIndexer(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewStateDiffIndexer(nil)
+	r.Error(err)
+
+	testPath, err := testutil.PathOfTempFile("statediff")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	indexer, err := NewStateDiffIndexer(db.NewBoltDB(cfg))
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.Zero(height)
+	_, err = indexer.StateDiff(1)
+	r.Equal(ErrStateDiffNotExist, errors.Cause(err))
+
+	changes := []*factory.StateChange{
+		{Namespace: "Account", Key: []byte{1}, NewValue: []byte{2}},
+		{Namespace: "Account", Key: []byte{3}, OldValue: []byte{4}, NewValue: []byte{}},
+		{Namespace: "Contract", Key: []byte{5, 6}, OldValue: []byte{7}},
+	}
+	r.NoError(indexer.ReceiveStateChanges(1, changes))
+	r.NoError(indexer.ReceiveStateChanges(2, nil))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(2), height)
+
+	diff, err := indexer.StateDiff(1)
+	r.NoError(err)
+	r.Equal(changes, diff)
+	r.NotNil(diff[1].NewValue)
+	r.Nil(diff[2].NewValue)
+	diff, err = indexer.StateDiff(2)
+	r.NoError(err)
+	r.Empty(diff)
+
+	// the blocks skipped are recorded as missing
+	r.NoError(indexer.ReceiveStateChanges(5, changes))
+	for _, h := range []uint64{3, 4} {
+		_, err = indexer.StateDiff(h)
+		r.Equal(ErrStateDiffMissing, errors.Cause(err))
+	}
+	_, err = indexer.StateDiff(6)
+	r.Equal(ErrStateDiffNotExist, errors.Cause(err))
+
+	// delete tip block
+	r.NoError(indexer.DeleteTipBlock(ctx, newBlockAtHeight(t, 6)))
+	r.Error(indexer.DeleteTipBlock(ctx, newBlockAtHeight(t, 4)))
+	r.NoError(indexer.DeleteTipBlock(ctx, newBlockAtHeight(t, 5)))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(4), height)
+	_, err = indexer.StateDiff(5)
+	r.Equal(ErrStateDiffNotExist, errors.Cause(err))
+	r.NoError(indexer.ReceiveStateChanges(5, nil))
+	diff, err = indexer.StateDiff(5)
+	r.NoError(err)
+	r.Empty(diff)
+}
+
+func TestSerializeStateChanges(t *testing.T) {
+	r := require.New(t)
+
+	changes := []*factory.StateChange{
+		{Namespace: "Account", Key: []byte{1}, OldValue: []byte{2}, NewValue: []byte{3}},
+		{Namespace: "Account", Key: []byte{4}, OldValue: []byte{}},
+	}
+	diff, err := deserializeStateChanges(serializeStateChanges(changes))
+	r.NoError(err)
+	r.Equal(changes, diff)
+	r.NotNil(diff[1].OldValue)
+	r.Nil(diff[1].NewValue)
+	_, err = deserializeStateChanges([]byte{0xff})
+	r.Error(err)
+}
+
+func TestAddStateDiffGap(t *testing.T) {
+	r := require.New(t)
+
+	var gaps []stateDiffGap
+	for _, c := range []struct {
+		gap    stateDiffGap
+		expect []stateDiffGap
+	}{
+		{stateDiffGap{3, 4}, []stateDiffGap{{3, 4}}},
+		{stateDiffGap{8, 9}, []stateDiffGap{{3, 4}, {8, 9}}},
+		// adjacent
+		{stateDiffGap{5, 6}, []stateDiffGap{{3, 6}, {8, 9}}},
+		{stateDiffGap{7, 7}, []stateDiffGap{{3, 9}}},
+		// overlapping, after the tip blocks are deleted
+		{stateDiffGap{9, 12}, []stateDiffGap{{3, 12}}},
+		{stateDiffGap{1, 1}, []stateDiffGap{{1, 1}, {3, 12}}},
+		{stateDiffGap{2, 20}, []stateDiffGap{{1, 20}}},
+	} {
+		gaps = addStateDiffGap(gaps, c.gap)
+		r.Equal(c.expect, gaps)
+		data, err := deserializeStateDiffGaps(serializeStateDiffGaps(gaps))
+		r.NoError(err)
+		r.Equal(gaps, data)
+	}
+}
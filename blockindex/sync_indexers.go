@@ -70,8 +70,17 @@ func (ig *SyncIndexers) PutBlock(ctx context.Context, blk *block.Block) error {
 }
 
 // DeleteTipBlock deletes the tip block from the indexers in the group
+// in the reverse order of PutBlock, skipping the indexers which have not indexed the block
 func (ig *SyncIndexers) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
-	for _, indexer := range ig.indexers {
+	for i := len(ig.indexers) - 1; i >= 0; i-- {
+		indexer := ig.indexers[i]
+		height, err := indexer.Height()
+		if err != nil {
+			return err
+		}
+		if blk.Height() > height {
+			continue
+		}
 		if err := indexer.DeleteTipBlock(ctx, blk); err != nil {
 			return err
 		}
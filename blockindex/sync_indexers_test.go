@@ -216,3 +216,37 @@ func TestSyncIndexers_PutBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncIndexers_DeleteTipBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		indexers       []blockdao.BlockIndexer
+		indexersHeight = []uint64{300, 300, 299}
+		deleted        []int
+	)
+	for id := range indexersHeight {
+		idx := id
+		mockIndexer := mock_blockdao.NewMockBlockIndexer(ctrl)
+		mockIndexer.EXPECT().Height().DoAndReturn(func() (uint64, error) {
+			return indexersHeight[idx], nil
+		}).AnyTimes()
+		mockIndexer.EXPECT().DeleteTipBlock(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, blk *block.Block) error {
+			deleted = append(deleted, idx)
+			indexersHeight[idx] = blk.Height() - 1
+			return nil
+		}).AnyTimes()
+		indexers = append(indexers, mockIndexer)
+	}
+	ig := NewSyncIndexers(indexers...)
+	for _, blkHeight := range []uint64{300, 299} {
+		blk, err := block.NewBuilder(block.RunnableActions{}).SetHeight(blkHeight).SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		require.NoError(ig.DeleteTipBlock(context.Background(), &blk))
+	}
+	// deleted in the reverse order, and the indexer which has not indexed block 300 is skipped
+	require.Equal([]int{1, 0, 2, 1, 0}, deleted)
+	require.Equal([]uint64{298, 298, 298}, indexersHeight)
+}
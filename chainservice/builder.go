@@ -22,6 +22,7 @@ import (
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
@@ -168,6 +169,9 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 			factory.RegistryStateDBOption(builder.cs.registry),
 			factory.DefaultPatchOption(),
 		}
+		if builder.cs.stateDiffIndexer != nil {
+			opts = append(opts, factory.StateChangeSubscriberStateDBOption(builder.cs.stateDiffIndexer))
+		}
 		if builder.cfg.Chain.EnableStateDBCaching {
 			dao, err = db.CreateKVStoreWithCache(builder.cfg.DB, builder.cfg.Chain.TrieDBPath, builder.cfg.Chain.StateDBCacheSize)
 		} else {
@@ -193,6 +197,30 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 	)
 }
 
+func (builder *Builder) buildStateDiffIndexer(forTest bool) error {
+	if builder.cs.stateDiffIndexer != nil {
+		return nil
+	}
+	if forTest || !builder.cfg.Chain.EnableStateDiffIndexer {
+		return nil
+	}
+	if !builder.cfg.Chain.EnableTrielessStateDB {
+		return errors.New("state diff indexer is only supported by trieless state db")
+	}
+	kvStore, err := db.CreateKVStore(builder.cfg.DB, builder.cfg.Chain.StateDiffIndexDBPath)
+	if err != nil {
+		return err
+	}
+	indexer, err := blockindex.NewStateDiffIndexer(kvStore)
+	if err != nil {
+		return err
+	}
+	builder.cs.stateDiffIndexer = indexer
+	// the indexer should be started before the state db, which may commit blocks on start
+	builder.cs.lifecycle.Add(indexer)
+	return nil
+}
+
 func (builder *Builder) buildElectionCommittee() error {
 	ec, err := builder.createElectionCommittee()
 	if err != nil {
@@ -282,9 +310,12 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	if !builder.cfg.Chain.EnableAsyncIndexWrite && builder.cs.indexer != nil {
 		indexers = append(indexers, builder.cs.indexer)
 	}
-	if builder.cs.bfIndexer != nil {
+	if builder.cs.bfIndexer != nil && !builder.enableIndexJournal() {
 		indexers = append(indexers, builder.cs.bfIndexer)
 	}
+	if builder.cs.stakingHistoryIndexer != nil {
+		indexers = append(indexers, builder.cs.stakingHistoryIndexer)
+	}
 	var (
 		err   error
 		store blockdao.BlockDAO
@@ -316,7 +347,12 @@ func (builder *Builder) buildSGDRegistry(forTest bool) error {
 	if err != nil {
 		return err
 	}
-	builder.cs.sgdIndexer = blockindex.NewSGDRegistry(builder.cfg.Genesis.SystemSGDContractAddress, builder.cfg.Genesis.SystemSGDContractHeight, kvStore)
+	builder.cs.sgdIndexer = blockindex.NewSGDRegistry(
+		builder.cfg.Genesis.SystemSGDContractAddress,
+		builder.cfg.Genesis.SystemSGDContractHeight,
+		kvStore,
+		blockindex.SGDRollbackRetentionOption(builder.cfg.Chain.RollbackRetention),
+	)
 	return nil
 }
 
@@ -334,16 +370,29 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	dbConfig := builder.cfg.DB
 	dbConfig.DbPath = builder.cfg.Chain.ContractStakingIndexDBPath
 	voteCalcConsts := builder.cfg.Genesis.VoteWeightCalConsts
+	var (
+		kvStore db.KVStore = db.NewBoltDB(dbConfig)
+		opts    []contractstaking.IndexerOption
+	)
+	if builder.cfg.Chain.EnableContractStakingHistory {
+		// keep the history of buckets to serve queries at earlier heights
+		vdb := db.NewBoltDBVersioned(dbConfig)
+		kvStore = vdb.Base()
+		opts = append(opts, contractstaking.WithHistory(vdb))
+	}
 	indexer, err := contractstaking.NewContractStakingIndexer(
-		db.NewBoltDB(dbConfig),
+		kvStore,
 		contractstaking.Config{
 			ContractAddress:      builder.cfg.Genesis.SystemStakingContractAddress,
 			ContractDeployHeight: builder.cfg.Genesis.SystemStakingContractHeight,
 			CalculateVoteWeight: func(v *staking.VoteBucket) *big.Int {
 				return staking.CalculateVoteWeight(voteCalcConsts, v, false)
 			},
-			BlockInterval: builder.cfg.DardanellesUpgrade.BlockInterval,
-		})
+			BlockInterval:     builder.cfg.DardanellesUpgrade.BlockInterval,
+			RollbackRetention: builder.cfg.Chain.RollbackRetention,
+		},
+		opts...,
+	)
 	if err != nil {
 		return err
 	}
@@ -364,15 +413,54 @@ func (builder *Builder) buildContractStakingIndexerV2(forTest bool) error {
 	}
 	dbConfig := builder.cfg.DB
 	dbConfig.DbPath = builder.cfg.Chain.ContractStakingIndexV2DBPath
+	var (
+		kvStore db.KVStore = db.NewBoltDB(dbConfig)
+		opts               = []stakingindex.IndexerOption{
+			stakingindex.WithRollbackRetention(builder.cfg.Chain.RollbackRetention),
+		}
+	)
+	if builder.cfg.Chain.EnableContractStakingHistory {
+		// keep the history of buckets to serve queries at earlier heights
+		vdb := db.NewBoltDBVersioned(dbConfig)
+		kvStore = vdb.Base()
+		opts = append(opts, stakingindex.WithHistory(vdb))
+	}
 	indexerV2 := stakingindex.NewIndexer(
-		db.NewBoltDB(dbConfig),
+		kvStore,
 		builder.cfg.Genesis.SystemStakingContractV2Address,
 		builder.cfg.Genesis.SystemStakingContractV2Height, builder.cfg.DardanellesUpgrade.BlockInterval,
+		opts...,
 	)
 	builder.cs.contractStakingIndexerV2 = indexerV2
 	return nil
 }
 
+func (builder *Builder) buildStakingHistoryIndexer(forTest bool) error {
+	if builder.cs.stakingHistoryIndexer != nil {
+		return nil
+	}
+	if forTest || !builder.cfg.Chain.EnableStakingHistoryIndexer {
+		return nil
+	}
+	kvStore, err := db.CreateKVStore(builder.cfg.DB, builder.cfg.Chain.StakingHistoryIndexDBPath)
+	if err != nil {
+		return err
+	}
+	indexer, err := blockindex.NewStakingHistoryIndexer(
+		kvStore,
+		[]string{
+			builder.cfg.Genesis.SystemStakingContractAddress,
+			builder.cfg.Genesis.SystemStakingContractV2Address,
+		},
+		blockindex.StakingHistoryRollbackRetentionOption(builder.cfg.Chain.RollbackRetention),
+	)
+	if err != nil {
+		return err
+	}
+	builder.cs.stakingHistoryIndexer = indexer
+	return nil
+}
+
 func (builder *Builder) buildGatewayComponents(forTest bool) error {
 	indexer, bfIndexer, candidateIndexer, candBucketsIndexer, err := builder.createGateWayComponents(forTest)
 	if err != nil {
@@ -458,20 +546,53 @@ func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	if err := builder.cs.chain.AddSubscriber(builder.cs.actpool); err != nil {
 		return errors.Wrap(err, "failed to add actpool as subscriber")
 	}
-	if builder.cs.indexer != nil && builder.cfg.Chain.EnableAsyncIndexWrite {
-		// config asks for a standalone indexer
-		indexBuilder, err := blockindex.NewIndexBuilder(builder.cs.chain.ChainID(), builder.cfg.Genesis, builder.cs.blockdao, builder.cs.indexer)
+	if !builder.cfg.Chain.EnableAsyncIndexWrite {
+		return nil
+	}
+	var opts []blockindex.IndexBuilderOption
+	if builder.enableIndexJournal() && !forTest {
+		dbConfig := builder.cfg.DB
+		dbConfig.DbPath = builder.cfg.Chain.IndexJournalDBPath
+		journal, err := blockindex.NewIndexJournal(db.NewBoltDB(dbConfig))
+		if err != nil {
+			return errors.Wrap(err, "failed to create index journal")
+		}
+		builder.cs.indexJournal = journal
+		builder.cs.lifecycle.Add(journal)
+		opts = append(opts, blockindex.WithIndexJournal(journal))
+	}
+	// config asks for standalone indexers
+	asyncIndexers := make(map[string]blockdao.BlockIndexer)
+	if builder.cs.indexer != nil {
+		asyncIndexers["blockindexer"] = builder.cs.indexer
+	}
+	if builder.cs.bfIndexer != nil && builder.enableIndexJournal() {
+		asyncIndexers["bloomfilter"] = builder.cs.bfIndexer
+	}
+	for _, name := range []string{"blockindexer", "bloomfilter"} {
+		indexer, ok := asyncIndexers[name]
+		if !ok {
+			continue
+		}
+		indexBuilder, err := blockindex.NewIndexBuilder(builder.cs.chain.ChainID(), builder.cfg.Genesis, builder.cs.blockdao, indexer, append(opts, blockindex.WithIndexerName(name))...)
 		if err != nil {
-			return errors.Wrap(err, "failed to create index builder")
+			return errors.Wrapf(err, "failed to create index builder for %s", name)
 		}
 		builder.cs.lifecycle.Add(indexBuilder)
 		if err := builder.cs.chain.AddSubscriber(indexBuilder); err != nil {
-			return errors.Wrap(err, "failed to add index builder as subscriber")
+			return errors.Wrapf(err, "failed to add index builder %s as subscriber", name)
 		}
+		builder.cs.indexBuilders = append(builder.cs.indexBuilders, indexBuilder)
 	}
 	return nil
 }
 
+// enableIndexJournal returns true if the asynchronous indexers keep a journal,
+// the bloomfilter index is also written asynchronously in this case
+func (builder *Builder) enableIndexJournal() bool {
+	return builder.cfg.Chain.EnableAsyncIndexWrite && builder.cfg.Chain.EnableIndexJournal
+}
+
 func (builder *Builder) createBlockchain(forSubChain, forTest bool) blockchain.Blockchain {
 	if builder.cs.chain != nil {
 		return builder.cs.chain
@@ -610,9 +731,33 @@ func (builder *Builder) registerStakingProtocol() error {
 	return stakingProtocol.Register(builder.cs.registry)
 }
 
+func (builder *Builder) registerGovernanceProtocol() error {
+	// proposals are voted with the staking weights and tallied at the epoch boundaries
+	if builder.cfg.Consensus.Scheme != config.RollDPoSScheme {
+		return nil
+	}
+	stakingProtocol := staking.FindProtocol(builder.cs.registry)
+	if stakingProtocol == nil {
+		return nil
+	}
+	return governance.NewProtocol(
+		rewarding.DepositGas,
+		stakingProtocol.VoterWeight,
+		builder.cfg.Genesis.Governance,
+	).Register(builder.cs.registry)
+}
+
 func (builder *Builder) registerRewardingProtocol() error {
 	// TODO: rewarding protocol for standalone mode is weird, rDPoSProtocol could be passed via context
-	return rewarding.NewProtocol(builder.cfg.Genesis.Rewarding).Register(builder.cs.registry)
+	consensusCfg := consensusfsm.NewConsensusConfig(builder.cfg.Consensus.RollDPoS.FSM, builder.cfg.DardanellesUpgrade, builder.cfg.Genesis, builder.cfg.Consensus.RollDPoS.Delay)
+	chain := builder.cs.chain
+	return rewarding.NewProtocol(
+		builder.cfg.Genesis.Rewarding,
+		rewarding.WithProductivity(func(start, end uint64) (map[string]uint64, error) {
+			return blockchain.Productivity(chain, start, end)
+		}),
+		rewarding.WithBlockInterval(consensusCfg.BlockInterval),
+	).Register(builder.cs.registry)
 }
 
 func (builder *Builder) registerAccountProtocol() error {
@@ -747,6 +892,9 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if builder.cs.p2pAgent == nil {
 		builder.cs.p2pAgent = p2p.NewDummyAgent()
 	}
+	if err := builder.buildStateDiffIndexer(forTest); err != nil {
+		return nil, err
+	}
 	if err := builder.buildFactory(forTest); err != nil {
 		return nil, err
 	}
@@ -768,6 +916,9 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.buildContractStakingIndexerV2(forTest); err != nil {
 		return nil, err
 	}
+	if err := builder.buildStakingHistoryIndexer(forTest); err != nil {
+		return nil, err
+	}
 	if err := builder.buildBlockDAO(forTest); err != nil {
 		return nil, err
 	}
@@ -790,6 +941,9 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.registerExecutionProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register execution protocol")
 	}
+	if err := builder.registerGovernanceProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register governance protocol")
+	}
 	if err := builder.registerRewardingProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register rewarding protocol")
 	}
@@ -73,6 +73,10 @@ type ChainService struct {
 	sgdIndexer               blockindex.SGDRegistry
 	contractStakingIndexer   *contractstaking.Indexer
 	contractStakingIndexerV2 stakingindex.StakingIndexer
+	stateDiffIndexer         blockindex.StateDiffIndexer
+	stakingHistoryIndexer    blockindex.StakingHistoryIndexer
+	indexJournal             *blockindex.IndexJournal
+	indexBuilders            []*blockindex.IndexBuilder
 	registry                 *protocol.Registry
 	nodeInfoManager          *nodeinfo.InfoManager
 	apiStats                 *nodestats.APILocalStats
@@ -160,6 +164,21 @@ func (cs *ChainService) BlockDAO() blockdao.BlockDAO {
 	return cs.blockdao
 }
 
+// BlockIndexer returns the block indexer
+func (cs *ChainService) BlockIndexer() blockindex.Indexer {
+	return cs.indexer
+}
+
+// IndexBuilders returns the builders of the asynchronous indexers
+func (cs *ChainService) IndexBuilders() []*blockindex.IndexBuilder {
+	return cs.indexBuilders
+}
+
+// IndexJournal returns the journal of the asynchronous indexers
+func (cs *ChainService) IndexJournal() *blockindex.IndexJournal {
+	return cs.indexJournal
+}
+
 // ActionPool returns the Action pool
 func (cs *ChainService) ActionPool() actpool.ActPool {
 	return cs.actpool
@@ -196,6 +215,8 @@ func (cs *ChainService) NewAPIServer(cfg api.Config, plugins map[int]interface{}
 		api.WithNativeElection(cs.electionCommittee),
 		api.WithAPIStats(cs.apiStats),
 		api.WithSGDIndexer(cs.sgdIndexer),
+		api.WithStateDiffIndexer(cs.stateDiffIndexer),
+		api.WithStakingHistoryIndexer(cs.stakingHistoryIndexer),
 	}
 
 	svr, err := api.NewServerV2(
@@ -91,6 +91,7 @@ var (
 	Validates = []Validate{
 		ValidateRollDPoS,
 		ValidateArchiveMode,
+		ValidateRollbackRetention,
 		ValidateDispatcher,
 		ValidateAPI,
 		ValidateActPool,
@@ -258,6 +259,15 @@ func ValidateArchiveMode(cfg Config) error {
 	return errors.Wrap(ErrInvalidCfg, "Archive mode is incompatible with trieless state DB")
 }
 
+// ValidateRollbackRetention validates the rollback setting
+func ValidateRollbackRetention(cfg Config) error {
+	if cfg.Chain.RollbackRetention == 0 || cfg.Chain.EnableTrielessStateDB {
+		return nil
+	}
+
+	return errors.Wrap(ErrInvalidCfg, "Rollback is only supported by trieless state DB")
+}
+
 // ValidateAPI validates the api configs
 func ValidateAPI(cfg Config) error {
 	if cfg.API.TpsWindow <= 0 {
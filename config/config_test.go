@@ -269,6 +269,18 @@ func TestValidateArchiveMode(t *testing.T) {
 	require.NoError(t, errors.Cause(ValidateArchiveMode(cfg)))
 }
 
+func TestValidateRollbackRetention(t *testing.T) {
+	cfg := Default
+	cfg.Chain.RollbackRetention = 100
+	cfg.Chain.EnableTrielessStateDB = false
+	require.EqualError(t, ValidateRollbackRetention(cfg), "Rollback is only supported by trieless state DB: invalid config value")
+	cfg.Chain.EnableTrielessStateDB = true
+	require.NoError(t, ValidateRollbackRetention(cfg))
+	cfg.Chain.RollbackRetention = 0
+	cfg.Chain.EnableTrielessStateDB = false
+	require.NoError(t, ValidateRollbackRetention(cfg))
+}
+
 func TestValidateActPool(t *testing.T) {
 	cfg := Default
 	cfg.ActPool.MaxNumActsPerAcct = 0
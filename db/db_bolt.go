@@ -315,17 +315,42 @@ func (b *BoltDB) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 	kvsb.Lock()
 	defer kvsb.Unlock()
 
+	entries, err := uniqueEntries(kvsb)
+	if err != nil {
+		return err
+	}
+	boltdbMtc.WithLabelValues(b.path, "entrySize").Set(float64(kvsb.Size()))
+	boltdbMtc.WithLabelValues(b.path, "uniqueEntrySize").Set(float64(len(entries)))
+	for c := uint8(0); c < b.config.NumRetries; c++ {
+		if err = b.db.Update(func(tx *bolt.Tx) error {
+			return writeEntries(tx, kvsb, entries)
+		}); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, syscall.ENOSPC) {
+			log.L().Fatal("Failed to write batch db.", zap.Error(err))
+		}
+		err = errors.Wrap(ErrIO, err.Error())
+	}
+	return err
+}
+
+// uniqueEntries removes duplicate keys of the batch, only keeps the last write for each key.
+// The entries are in reverse order of the batch
+func uniqueEntries(kvsb batch.KVStoreBatch) ([]*batch.WriteInfo, error) {
 	type doubleKey struct {
 		ns  string
 		key string
 	}
-	// remove duplicate keys, only keep the last write for each key
 	entryKeySet := make(map[doubleKey]struct{})
 	uniqEntries := make([]*batch.WriteInfo, 0)
 	for i := kvsb.Size() - 1; i >= 0; i-- {
 		write, e := kvsb.Entry(i)
 		if e != nil {
-			return e
+			return nil, e
 		}
 		// only handle Put and Delete
 		if write.WriteType() != batch.Put && write.WriteType() != batch.Delete {
@@ -337,49 +362,38 @@ func (b *BoltDB) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 			uniqEntries = append(uniqEntries, write)
 		}
 	}
-	boltdbMtc.WithLabelValues(b.path, "entrySize").Set(float64(kvsb.Size()))
-	boltdbMtc.WithLabelValues(b.path, "uniqueEntrySize").Set(float64(len(entryKeySet)))
-	for c := uint8(0); c < b.config.NumRetries; c++ {
-		if err = b.db.Update(func(tx *bolt.Tx) error {
-			// keep order of the writes same as the original batch
-			for i := len(uniqEntries) - 1; i >= 0; i-- {
-				write := uniqEntries[i]
-				ns := write.Namespace()
-				switch write.WriteType() {
-				case batch.Put:
-					bucket, e := tx.CreateBucketIfNotExists([]byte(ns))
-					if e != nil {
-						return errors.Wrap(e, write.Error())
-					}
-					if p, ok := kvsb.CheckFillPercent(ns); ok {
-						bucket.FillPercent = p
-					}
-					if e := bucket.Put(write.Key(), write.Value()); e != nil {
-						return errors.Wrap(e, write.Error())
-					}
-				case batch.Delete:
-					bucket := tx.Bucket([]byte(ns))
-					if bucket == nil {
-						continue
-					}
-					if e := bucket.Delete(write.Key()); e != nil {
-						return errors.Wrap(e, write.Error())
-					}
-				}
-			}
-			return nil
-		}); err == nil {
-			break
-		}
-	}
+	return uniqEntries, nil
+}
 
-	if err != nil {
-		if errors.Is(err, syscall.ENOSPC) {
-			log.L().Fatal("Failed to write batch db.", zap.Error(err))
+// writeEntries writes the entries returned by uniqueEntries in the transaction
+func writeEntries(tx *bolt.Tx, kvsb batch.KVStoreBatch, uniqEntries []*batch.WriteInfo) error {
+	// keep order of the writes same as the original batch
+	for i := len(uniqEntries) - 1; i >= 0; i-- {
+		write := uniqEntries[i]
+		ns := write.Namespace()
+		switch write.WriteType() {
+		case batch.Put:
+			bucket, e := tx.CreateBucketIfNotExists([]byte(ns))
+			if e != nil {
+				return errors.Wrap(e, write.Error())
+			}
+			if p, ok := kvsb.CheckFillPercent(ns); ok {
+				bucket.FillPercent = p
+			}
+			if e := bucket.Put(write.Key(), write.Value()); e != nil {
+				return errors.Wrap(e, write.Error())
+			}
+		case batch.Delete:
+			bucket := tx.Bucket([]byte(ns))
+			if bucket == nil {
+				continue
+			}
+			if e := bucket.Delete(write.Key()); e != nil {
+				return errors.Wrap(e, write.Error())
+			}
 		}
-		err = errors.Wrap(ErrIO, err.Error())
 	}
-	return err
+	return nil
 }
 
 // BucketExists returns true if bucket exists
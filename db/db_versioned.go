@@ -7,9 +7,34 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"syscall"
 
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/db/batch"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// _versionedBucketPrefix is prepended to the name of a versioned namespace to get the name of
+	// the underlying bucket, so a versioned namespace can co-exist with a normal namespace of the
+	// same name in the DB
+	_versionedBucketPrefix = "versioned."
+
+	// flags of the value stored at (key + version)
+	_valueDeleted byte = 0
+	_valueWritten byte = 1
+)
+
+var (
+	_nsMetaKey = []byte{0}
 )
 
 type (
@@ -29,6 +54,11 @@ type (
 	// 4. hash of the key's last written value (to detect/avoid same write)
 	// If the location does not store a value, the key has never been written.
 	//
+	// The value stored at (key + version) is prefixed with a flag byte, which
+	// tells whether the key is written or deleted at that version. A write is
+	// only allowed at a version no earlier than the key's last version, and a
+	// write at the last version overwrites the previous one.
+	//
 	// How to use a versioned DB:
 	//
 	// db := NewBoltDBVersioned(cfg) // creates a versioned DB
@@ -43,11 +73,20 @@ type (
 	KvVersioned interface {
 		lifecycle.StartStopper
 
+		// Base returns the underlying DB, which stores the unversioned namespaces
+		Base() KVStore
+
 		// Version returns the key's most recent version
 		Version(string, []byte) (uint64, error)
 
 		// SetVersion sets the version, and returns a KVStore to call Put()/Get()
-		SetVersion(uint64) KVStoreBasic
+		SetVersion(uint64) KVStore
+
+		// CommitToDB writes a batch at the version
+		CommitToDB(uint64, batch.KVStoreBatch) error
+
+		// CommitToDBWithBase writes a batch at the version, and a batch into the base DB
+		CommitToDBWithBase(uint64, batch.KVStoreBatch, batch.KVStoreBatch) error
 	}
 
 	// BoltDBVersioned is KvVersioned implementation based on bolt DB
@@ -80,31 +119,82 @@ func (b *BoltDBVersioned) Stop(ctx context.Context) error {
 	return b.db.Stop(ctx)
 }
 
+// Base returns the underlying DB
+func (b *BoltDBVersioned) Base() KVStore {
+	return b.db
+}
+
 // Put writes a <key, value> record
 func (b *BoltDBVersioned) Put(ns string, version uint64, key, value []byte) error {
 	if !b.db.IsReady() {
 		return ErrDBNotStarted
 	}
-	// TODO: implement Put
-	return nil
+	if value == nil {
+		// nil value is reserved for deletion
+		value = []byte{}
+	}
+	return b.update(func(tx *bolt.Tx) error {
+		return writeVersioned(tx, ns, version, key, value)
+	})
 }
 
-// Get retrieves the most recent version
+// Get retrieves the value of the key at the version
 func (b *BoltDBVersioned) Get(ns string, version uint64, key []byte) ([]byte, error) {
 	if !b.db.IsReady() {
 		return nil, ErrDBNotStarted
 	}
-	// TODO: implement Get
-	return nil, nil
+	var value []byte
+	err := b.db.db.View(func(tx *bolt.Tx) error {
+		bucket, _, err := versionedBucket(tx, ns, key)
+		if err != nil {
+			return err
+		}
+		km, err := getKeyMeta(bucket, key)
+		if err != nil {
+			return err
+		}
+		if version < km.firstVersion {
+			return errors.Wrapf(ErrNotExist, "key = %x doesn't exist at version %d", key, version)
+		}
+		var (
+			c      = bucket.Cursor()
+			target = versionedKey(key, version)
+			k, v   = c.Seek(target)
+		)
+		switch {
+		case k == nil:
+			k, v = c.Last()
+		case !bytes.Equal(k, target):
+			k, v = c.Prev()
+		}
+		if k == nil || len(k) != len(target) || !bytes.HasPrefix(k, key) || len(v) == 0 {
+			return errors.Wrapf(ErrNotExist, "key = %x doesn't exist at version %d", key, version)
+		}
+		if v[0] == _valueDeleted {
+			return errors.Wrapf(ErrNotExist, "key = %x is deleted at version %d", key, version)
+		}
+		value = make([]byte, len(v)-1)
+		copy(value, v[1:])
+		return nil
+	})
+	switch errors.Cause(err) {
+	case nil:
+		return value, nil
+	case ErrNotExist, ErrInvalid:
+		return nil, err
+	default:
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
 }
 
-// Delete deletes a record,if key is nil,this will delete the whole bucket
-func (b *BoltDBVersioned) Delete(ns string, key []byte) error {
+// Delete deletes a record at the version
+func (b *BoltDBVersioned) Delete(ns string, version uint64, key []byte) error {
 	if !b.db.IsReady() {
 		return ErrDBNotStarted
 	}
-	// TODO: implement Delete
-	return nil
+	return b.update(func(tx *bolt.Tx) error {
+		return writeVersioned(tx, ns, version, key, nil)
+	})
 }
 
 // Version returns the key's most recent version
@@ -112,18 +202,284 @@ func (b *BoltDBVersioned) Version(ns string, key []byte) (uint64, error) {
 	if !b.db.IsReady() {
 		return 0, ErrDBNotStarted
 	}
-	// TODO: implement Version
-	return 0, nil
+	var version uint64
+	err := b.db.db.View(func(tx *bolt.Tx) error {
+		bucket, _, err := versionedBucket(tx, ns, key)
+		if err != nil {
+			return err
+		}
+		km, err := getKeyMeta(bucket, key)
+		if err != nil {
+			return err
+		}
+		version = km.lastVersion
+		return nil
+	})
+	switch errors.Cause(err) {
+	case nil:
+		return version, nil
+	case ErrNotExist, ErrInvalid:
+		return 0, err
+	default:
+		return 0, errors.Wrap(ErrIO, err.Error())
+	}
+}
+
+// Filter returns the <k, v> pairs at the version in a namespace that meet the condition
+func (b *BoltDBVersioned) Filter(ns string, version uint64, cond Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	if !b.db.IsReady() {
+		return nil, nil, ErrDBNotStarted
+	}
+	var fk, fv [][]byte
+	if err := b.db.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(_versionedBucketPrefix + ns))
+		if bucket == nil {
+			return errors.Wrapf(ErrBucketNotExist, "versioned namespace = %s doesn't exist", ns)
+		}
+		vn, err := getNamespaceMeta(bucket)
+		if err != nil {
+			return err
+		}
+		var (
+			keyLen         = int(vn.keyLen)
+			c              = bucket.Cursor()
+			checkMax       = len(maxKey) > 0
+			lastKey, lastV []byte
+			k, v           []byte
+		)
+		// the value of a key at the version is the last one no later than the version
+		flush := func() {
+			if lastKey == nil || lastV[0] == _valueDeleted {
+				return
+			}
+			value := make([]byte, len(lastV)-1)
+			copy(value, lastV[1:])
+			if cond(lastKey, value) {
+				fk = append(fk, lastKey)
+				fv = append(fv, value)
+			}
+		}
+		if len(minKey) > 0 {
+			k, v = c.Seek(minKey)
+		} else {
+			k, v = c.First()
+		}
+		for ; k != nil; k, v = c.Next() {
+			if len(k) != keyLen+8 || len(v) == 0 {
+				continue
+			}
+			if checkMax && bytes.Compare(k[:keyLen], maxKey) == 1 {
+				break
+			}
+			if lastKey == nil || !bytes.Equal(lastKey, k[:keyLen]) {
+				flush()
+				lastKey, lastV = nil, nil
+			}
+			if byteutil.BytesToUint64BigEndian(k[keyLen:]) > version {
+				continue
+			}
+			if lastKey == nil {
+				lastKey = make([]byte, keyLen)
+				copy(lastKey, k[:keyLen])
+			}
+			lastV = v
+		}
+		flush()
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	if len(fk) == 0 {
+		return nil, nil, errors.Wrap(ErrNotExist, "filter returns no match")
+	}
+	return fk, fv, nil
 }
 
 // SetVersion sets the version, and returns a KVStore to call Put()/Get()
-func (b *BoltDBVersioned) SetVersion(v uint64) KVStoreBasic {
+func (b *BoltDBVersioned) SetVersion(v uint64) KVStore {
 	return &KvWithVersion{
 		db:      b,
 		version: v,
 	}
 }
 
+// CommitToDB writes a batch at the version in a single transaction
+func (b *BoltDBVersioned) CommitToDB(version uint64, kvsb batch.KVStoreBatch) error {
+	if !b.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	kvsb.Lock()
+	defer kvsb.Unlock()
+	return b.update(func(tx *bolt.Tx) error {
+		return writeVersionedBatch(tx, version, kvsb)
+	})
+}
+
+// CommitToDBWithBase writes a batch at the version, and a batch into the base DB, in a single transaction
+func (b *BoltDBVersioned) CommitToDBWithBase(version uint64, kvsb, base batch.KVStoreBatch) error {
+	if !b.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	kvsb.Lock()
+	defer kvsb.Unlock()
+	base.Lock()
+	defer base.Unlock()
+	entries, err := uniqueEntries(base)
+	if err != nil {
+		return err
+	}
+	return b.update(func(tx *bolt.Tx) error {
+		if err := writeVersionedBatch(tx, version, kvsb); err != nil {
+			return err
+		}
+		return writeEntries(tx, base, entries)
+	})
+}
+
+func writeVersionedBatch(tx *bolt.Tx, version uint64, kvsb batch.KVStoreBatch) error {
+	for i := 0; i < kvsb.Size(); i++ {
+		write, err := kvsb.Entry(i)
+		if err != nil {
+			return err
+		}
+		switch write.WriteType() {
+		case batch.Put:
+			value := write.Value()
+			if value == nil {
+				value = []byte{}
+			}
+			if err := writeVersioned(tx, write.Namespace(), version, write.Key(), value); err != nil {
+				return errors.Wrap(err, write.Error())
+			}
+		case batch.Delete:
+			if err := writeVersioned(tx, write.Namespace(), version, write.Key(), nil); err != nil {
+				return errors.Wrap(err, write.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func (b *BoltDBVersioned) update(f func(*bolt.Tx) error) (err error) {
+	for c := uint8(0); c < b.db.config.NumRetries; c++ {
+		if err = b.db.db.Update(f); err == nil || errors.Cause(err) == ErrInvalid {
+			break
+		}
+	}
+	if err != nil && errors.Cause(err) != ErrInvalid {
+		if errors.Is(err, syscall.ENOSPC) {
+			log.L().Fatal("Failed to write versioned db.", zap.Error(err))
+		}
+		err = errors.Wrap(ErrIO, err.Error())
+	}
+	return err
+}
+
+// writeVersioned writes the value of the key at the version, a nil value deletes the key
+func writeVersioned(tx *bolt.Tx, ns string, version uint64, key, value []byte) error {
+	if len(key) == 0 {
+		return errors.Wrap(ErrInvalid, "empty key is not allowed in versioned namespace")
+	}
+	name := []byte(_versionedBucketPrefix + ns)
+	bucket := tx.Bucket(name)
+	if bucket == nil {
+		if value == nil {
+			return nil
+		}
+		var err error
+		if bucket, err = tx.CreateBucket(name); err != nil {
+			return err
+		}
+		vn := &versionedNamespace{name: ns, keyLen: uint32(len(key))}
+		if err = bucket.Put(_nsMetaKey, vn.serialize()); err != nil {
+			return err
+		}
+	}
+	vn, err := getNamespaceMeta(bucket)
+	if err != nil {
+		return err
+	}
+	if len(key) != int(vn.keyLen) {
+		return errors.Wrapf(ErrInvalid, "invalid key length %d in namespace %s, expecting %d", len(key), ns, vn.keyLen)
+	}
+	km, err := getKeyMeta(bucket, key)
+	switch errors.Cause(err) {
+	case nil:
+		if version < km.lastVersion {
+			return errors.Wrapf(ErrInvalid, "cannot write key %x at version %d, which is earlier than the last version %d", key, version, km.lastVersion)
+		}
+	case ErrNotExist:
+		if value == nil {
+			return nil
+		}
+		km = &keyMeta{firstVersion: version}
+	default:
+		return err
+	}
+	var entry []byte
+	if value == nil {
+		if km.lastWriteHash == nil {
+			// already deleted
+			return nil
+		}
+		km.lastWriteHash = nil
+		km.deleteVersion = version
+		entry = []byte{_valueDeleted}
+	} else {
+		h := hash.Hash256b(value)
+		if bytes.Equal(km.lastWriteHash, h[:]) {
+			// same write
+			return nil
+		}
+		km.lastWriteHash = h[:]
+		entry = append([]byte{_valueWritten}, value...)
+	}
+	km.lastVersion = version
+	if err := bucket.Put(versionedKey(key, version), entry); err != nil {
+		return err
+	}
+	return bucket.Put(keyMetaKey(key), km.serialize())
+}
+
+func versionedBucket(tx *bolt.Tx, ns string, key []byte) (*bolt.Bucket, *versionedNamespace, error) {
+	bucket := tx.Bucket([]byte(_versionedBucketPrefix + ns))
+	if bucket == nil {
+		return nil, nil, errors.Wrapf(ErrNotExist, "versioned namespace = %s doesn't exist", ns)
+	}
+	vn, err := getNamespaceMeta(bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(key) != int(vn.keyLen) {
+		return nil, nil, errors.Wrapf(ErrInvalid, "invalid key length %d in namespace %s, expecting %d", len(key), ns, vn.keyLen)
+	}
+	return bucket, vn, nil
+}
+
+func getNamespaceMeta(bucket *bolt.Bucket) (*versionedNamespace, error) {
+	data := bucket.Get(_nsMetaKey)
+	if data == nil {
+		return nil, errors.New("metadata of versioned namespace is missing")
+	}
+	return deserializeVersionedNamespace(data)
+}
+
+func getKeyMeta(bucket *bolt.Bucket, key []byte) (*keyMeta, error) {
+	data := bucket.Get(keyMetaKey(key))
+	if data == nil {
+		return nil, errors.Wrapf(ErrNotExist, "key = %x doesn't exist", key)
+	}
+	return deserializeKeyMeta(data)
+}
+
+func versionedKey(key []byte, version uint64) []byte {
+	return append(append(make([]byte, 0, len(key)+8), key...), byteutil.Uint64ToBytesBigEndian(version)...)
+}
+
+func keyMetaKey(key []byte) []byte {
+	return append(append(make([]byte, 0, len(key)+1), key...), 0)
+}
+
 // KvWithVersion wraps the BoltDBVersioned with a certain version
 type KvWithVersion struct {
 	db      *BoltDBVersioned
@@ -152,5 +508,15 @@ func (b *KvWithVersion) Get(ns string, key []byte) ([]byte, error) {
 
 // Delete deletes a key
 func (b *KvWithVersion) Delete(ns string, key []byte) error {
-	return b.db.Delete(ns, key)
+	return b.db.Delete(ns, b.version, key)
+}
+
+// WriteBatch commits a batch
+func (b *KvWithVersion) WriteBatch(kvsb batch.KVStoreBatch) error {
+	return b.db.CommitToDB(b.version, kvsb)
+}
+
+// Filter returns <k, v> pair in a bucket that meet the condition
+func (b *KvWithVersion) Filter(ns string, cond Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	return b.db.Filter(ns, b.version, cond, minKey, maxKey)
 }
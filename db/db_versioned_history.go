@@ -0,0 +1,166 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// _historyNS is the versioned namespace to store the metadata of the history
+	_historyNS = "History"
+)
+
+var (
+	// ErrHistoryNotAvailable indicates the history of a version is not kept
+	ErrHistoryNotAvailable = errors.New("history is not available")
+
+	_historyTipKey   = []byte{1}
+	_historyStartKey = []byte{2}
+)
+
+type (
+	// VersionedHistory keeps the history of some namespaces of a KVStore in a versioned store,
+	// so that these namespaces could be read at an earlier version (height). The KVStore should
+	// be the base of the versioned store, i.e., the history lives in the same DB file
+	VersionedHistory struct {
+		kv         KvVersioned
+		namespaces map[string]bool
+		keep       func(string, []byte) bool
+	}
+)
+
+// NewVersionedHistory creates a history of the namespaces, keep filters the keys whose history
+// is kept, nil means all keys in the namespaces
+func NewVersionedHistory(kv KvVersioned, keep func(ns string, key []byte) bool, namespaces ...string) *VersionedHistory {
+	h := &VersionedHistory{
+		kv:         kv,
+		namespaces: make(map[string]bool, len(namespaces)),
+		keep:       keep,
+	}
+	for _, ns := range namespaces {
+		h.namespaces[ns] = true
+	}
+	return h
+}
+
+// Sync makes sure the history is continuous up to the tip. If the history does not end at the
+// tip (history was never kept, or was disabled for a while), a snapshot of the namespaces at the
+// tip is written, and the history is available since the tip
+func (h *VersionedHistory) Sync(tip uint64) error {
+	last, err := h.meta(_historyTipKey)
+	switch errors.Cause(err) {
+	case nil:
+		if last == tip {
+			return nil
+		}
+	case ErrNotExist:
+	default:
+		return err
+	}
+	var (
+		b      = batch.NewBatch()
+		latest = h.kv.SetVersion(math.MaxUint64)
+	)
+	for ns := range h.namespaces {
+		current := make(map[string]bool)
+		keys, values, err := h.kv.Base().Filter(ns, func(k, _ []byte) bool { return h.kept(ns, k) }, nil, nil)
+		if err != nil && !isNotExist(err) {
+			return err
+		}
+		for i := range keys {
+			current[string(keys[i])] = true
+			b.Put(ns, keys[i], values[i], "failed to put snapshot")
+		}
+		// delete the keys which no longer exist since the history was last kept
+		keys, _, err = latest.Filter(ns, func([]byte, []byte) bool { return true }, nil, nil)
+		if err != nil && !isNotExist(err) {
+			return err
+		}
+		for _, k := range keys {
+			if !current[string(k)] {
+				b.Delete(ns, k, "failed to delete snapshot")
+			}
+		}
+	}
+	b.Put(_historyNS, _historyStartKey, byteutil.Uint64ToBytesBigEndian(tip), "failed to put history start")
+	b.Put(_historyNS, _historyTipKey, byteutil.Uint64ToBytesBigEndian(tip), "failed to put history tip")
+	if err := h.kv.CommitToDB(tip, b); err != nil {
+		return errors.Wrapf(err, "failed to write history snapshot at %d", tip)
+	}
+	return nil
+}
+
+// Commit writes the batch into the base DB, and records its writes at the version, which becomes
+// the tip of the history. Both are written in a single transaction
+func (h *VersionedHistory) Commit(version uint64, b batch.KVStoreBatch) error {
+	return h.commit(version, version, b)
+}
+
+// Revert writes the batch which reverts the version into the base DB, the tip of the history
+// becomes the version before it. The reverted writes are recorded at the version, so that
+// the history is correct after the version is committed again
+func (h *VersionedHistory) Revert(version uint64, b batch.KVStoreBatch) error {
+	if version == 0 {
+		return errors.Wrap(ErrInvalid, "cannot revert version 0")
+	}
+	return h.commit(version, version-1, b)
+}
+
+// At returns a KVStore to read the namespaces at the version
+func (h *VersionedHistory) At(version uint64) (KVStore, error) {
+	start, err := h.meta(_historyStartKey)
+	if err != nil {
+		if errors.Cause(err) == ErrNotExist {
+			return nil, errors.Wrapf(ErrHistoryNotAvailable, "version %d", version)
+		}
+		return nil, err
+	}
+	tip, err := h.meta(_historyTipKey)
+	if err != nil {
+		return nil, err
+	}
+	if version < start || version > tip {
+		return nil, errors.Wrapf(ErrHistoryNotAvailable, "version %d is out of range [%d, %d]", version, start, tip)
+	}
+	return h.kv.SetVersion(version), nil
+}
+
+func (h *VersionedHistory) commit(version, tip uint64, b batch.KVStoreBatch) error {
+	hb := b.Translate(func(wi *batch.WriteInfo) *batch.WriteInfo {
+		if !h.namespaces[wi.Namespace()] || !h.kept(wi.Namespace(), wi.Key()) {
+			return nil
+		}
+		return wi
+	})
+	hb.Put(_historyNS, _historyTipKey, byteutil.Uint64ToBytesBigEndian(tip), "failed to put history tip")
+	if err := h.kv.CommitToDBWithBase(version, hb, b); err != nil {
+		return errors.Wrapf(err, "failed to write history at %d", version)
+	}
+	return nil
+}
+
+func (h *VersionedHistory) kept(ns string, key []byte) bool {
+	return h.keep == nil || h.keep(ns, key)
+}
+
+func (h *VersionedHistory) meta(key []byte) (uint64, error) {
+	value, err := h.kv.SetVersion(math.MaxUint64).Get(_historyNS, key)
+	if err != nil {
+		return 0, err
+	}
+	return byteutil.BytesToUint64BigEndian(value), nil
+}
+
+func isNotExist(err error) bool {
+	cause := errors.Cause(err)
+	return cause == ErrNotExist || cause == ErrBucketNotExist
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestVersionedHistory(t *testing.T) {
+	r := require.New(t)
+
+	testPath, err := testutil.PathOfTempFile("history")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	vdb := NewBoltDBVersioned(cfg)
+	ctx := context.Background()
+	r.NoError(vdb.Start(ctx))
+	defer func() {
+		r.NoError(vdb.Stop(ctx))
+	}()
+
+	var (
+		kv        = vdb.Base()
+		heightKey = []byte("height")
+		k1        = []byte{1}
+		k2        = []byte{2}
+		h         = NewVersionedHistory(vdb, func(ns string, key []byte) bool {
+			return !bytes.Equal(key, heightKey)
+		}, "ns")
+		commit = func(height uint64, b batch.KVStoreBatch) {
+			b.Put("ns", heightKey, []byte{byte(height)}, "")
+			r.NoError(h.Commit(height, b))
+			value, err := kv.Get("ns", heightKey)
+			r.NoError(err)
+			r.Equal([]byte{byte(height)}, value)
+		}
+		check = func(height uint64, key, expected []byte) {
+			history, err := h.At(height)
+			r.NoError(err)
+			value, err := history.Get("ns", key)
+			if expected == nil {
+				r.Equal(ErrNotExist, errors.Cause(err))
+				return
+			}
+			r.NoError(err)
+			r.Equal(expected, value)
+		}
+	)
+	// data written before the history is kept
+	r.NoError(kv.Put("ns", k1, []byte("a")))
+	r.NoError(kv.Put("ns", heightKey, []byte{2}))
+	_, err = h.At(2)
+	r.Equal(ErrHistoryNotAvailable, errors.Cause(err))
+	r.NoError(h.Sync(2))
+	check(2, k1, []byte("a"))
+
+	b := batch.NewBatch()
+	b.Put("ns", k1, []byte("b"), "")
+	b.Put("ns", k2, []byte("c"), "")
+	commit(3, b)
+	b = batch.NewBatch()
+	b.Delete("ns", k1, "")
+	commit(4, b)
+
+	_, err = h.At(1)
+	r.Equal(ErrHistoryNotAvailable, errors.Cause(err))
+	_, err = h.At(5)
+	r.Equal(ErrHistoryNotAvailable, errors.Cause(err))
+	check(2, k1, []byte("a"))
+	check(2, k2, nil)
+	check(3, k1, []byte("b"))
+	check(3, k2, []byte("c"))
+	check(4, k1, nil)
+	check(4, k2, []byte("c"))
+	history, err := h.At(4)
+	r.NoError(err)
+	_, err = history.Get("ns", heightKey)
+	r.Error(err)
+
+	// revert height 4
+	b = batch.NewBatch()
+	b.Put("ns", k1, []byte("b"), "")
+	r.NoError(h.Revert(4, b))
+	value, err := kv.Get("ns", k1)
+	r.NoError(err)
+	r.Equal([]byte("b"), value)
+	_, err = h.At(4)
+	r.Equal(ErrHistoryNotAvailable, errors.Cause(err))
+	b = batch.NewBatch()
+	b.Put("ns", k2, []byte("d"), "")
+	commit(4, b)
+	check(4, k1, []byte("b"))
+	check(4, k2, []byte("d"))
+
+	// the base DB is not written if the history fails to be written
+	b = batch.NewBatch()
+	b.Put("ns", k1, []byte("x"), "")
+	r.Equal(ErrInvalid, errors.Cause(h.Commit(3, b)))
+	value, err = kv.Get("ns", k1)
+	r.NoError(err)
+	r.Equal([]byte("b"), value)
+
+	// sync is no-op if the history is continuous
+	r.NoError(h.Sync(4))
+	check(2, k1, []byte("a"))
+
+	// the history is rebuilt from a snapshot after a gap
+	r.NoError(kv.Delete("ns", k2))
+	r.NoError(kv.Put("ns", k1, []byte("e")))
+	r.NoError(h.Sync(6))
+	_, err = h.At(5)
+	r.Equal(ErrHistoryNotAvailable, errors.Cause(err))
+	check(6, k1, []byte("e"))
+	check(6, k2, nil)
+}
@@ -7,10 +7,15 @@
 package db
 
 import (
+	"context"
 	"encoding/hex"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/testutil"
 )
 
 func TestPb(t *testing.T) {
@@ -25,3 +30,126 @@ func TestPb(t *testing.T) {
 	r.NoError(err)
 	r.Equal(vn, vn1)
 }
+
+func TestVersionedDB(t *testing.T) {
+	r := require.New(t)
+
+	testPath, err := testutil.PathOfTempFile("versioned")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	db := NewBoltDBVersioned(cfg)
+	ctx := context.Background()
+	r.NoError(db.Start(ctx))
+	defer func() {
+		r.NoError(db.Stop(ctx))
+	}()
+
+	var (
+		ns   = "ns"
+		k1   = []byte{1, 1}
+		k2   = []byte{2, 2}
+		k3   = []byte{3, 3}
+		v1   = []byte("v1")
+		v2   = []byte("v2")
+		v3   = []byte("v3")
+		none = []byte(nil)
+	)
+	_, err = db.Get(ns, 1, k1)
+	r.Equal(ErrNotExist, errors.Cause(err))
+	r.NoError(db.Put(ns, 2, k1, v1))
+	r.NoError(db.Put(ns, 5, k1, v2))
+	r.NoError(db.Delete(ns, 7, k1))
+	r.NoError(db.Put(ns, 9, k1, v3))
+	// same write does not create a new version
+	r.NoError(db.Put(ns, 10, k1, v3))
+	r.NoError(db.Put(ns, 4, k2, v1))
+	// overwrite at the last version
+	r.NoError(db.Put(ns, 6, k2, v2))
+	r.NoError(db.Put(ns, 6, k2, v3))
+	// deleting a non-existing key is no-op
+	r.NoError(db.Delete(ns, 6, k3))
+
+	// invalid writes
+	r.Equal(ErrInvalid, errors.Cause(db.Put(ns, 8, k1, v1)))
+	r.Equal(ErrInvalid, errors.Cause(db.Put(ns, 10, []byte{1}, v1)))
+	r.Equal(ErrInvalid, errors.Cause(db.Put("ns2", 10, nil, v1)))
+
+	for _, e := range []struct {
+		key     []byte
+		version uint64
+		value   []byte
+	}{
+		{k1, 0, none},
+		{k1, 1, none},
+		{k1, 2, v1},
+		{k1, 4, v1},
+		{k1, 5, v2},
+		{k1, 6, v2},
+		{k1, 7, none},
+		{k1, 8, none},
+		{k1, 9, v3},
+		{k1, 100, v3},
+		{k2, 3, none},
+		{k2, 5, v1},
+		{k2, 6, v3},
+		{k2, 100, v3},
+		{k3, 6, none},
+	} {
+		value, err := db.Get(ns, e.version, e.key)
+		if e.value == nil {
+			r.Equal(ErrNotExist, errors.Cause(err))
+			continue
+		}
+		r.NoError(err)
+		r.Equal(e.value, value)
+	}
+	version, err := db.Version(ns, k1)
+	r.NoError(err)
+	r.EqualValues(9, version)
+	version, err = db.Version(ns, k2)
+	r.NoError(err)
+	r.EqualValues(6, version)
+	_, err = db.Version(ns, k3)
+	r.Equal(ErrNotExist, errors.Cause(err))
+
+	// filter
+	all := func([]byte, []byte) bool { return true }
+	_, _, err = db.Filter(ns, 1, all, nil, nil)
+	r.Equal(ErrNotExist, errors.Cause(err))
+	keys, values, err := db.Filter(ns, 5, all, nil, nil)
+	r.NoError(err)
+	r.Equal([][]byte{k1, k2}, keys)
+	r.Equal([][]byte{v2, v1}, values)
+	keys, values, err = db.Filter(ns, 7, all, nil, nil)
+	r.NoError(err)
+	r.Equal([][]byte{k2}, keys)
+	r.Equal([][]byte{v3}, values)
+	keys, _, err = db.Filter(ns, 9, all, nil, k1)
+	r.NoError(err)
+	r.Equal([][]byte{k1}, keys)
+	keys, _, err = db.Filter(ns, 9, all, k2, nil)
+	r.NoError(err)
+	r.Equal([][]byte{k2}, keys)
+
+	// batch writes at a version, and the versioned namespace does not affect the normal one
+	r.NoError(db.Base().Put(ns, k3, v1))
+	b := batch.NewBatch()
+	b.Put(ns, k3, v1, "")
+	b.Delete(ns, k2, "")
+	b.Put(ns, k3, v2, "")
+	r.NoError(db.SetVersion(11).WriteBatch(b))
+	kv := db.SetVersion(11)
+	value, err := kv.Get(ns, k3)
+	r.NoError(err)
+	r.Equal(v2, value)
+	_, err = kv.Get(ns, k2)
+	r.Equal(ErrNotExist, errors.Cause(err))
+	value, err = db.SetVersion(10).Get(ns, k2)
+	r.NoError(err)
+	r.Equal(v3, value)
+	value, err = db.Base().Get(ns, k3)
+	r.NoError(err)
+	r.Equal(v1, value)
+}
@@ -46,3 +46,43 @@ func deserializeVersionedNamespace(buf []byte) (*versionedNamespace, error) {
 	}
 	return fromProtoVN(&vn), nil
 }
+
+// keyMeta is the metadata for versioned key
+type keyMeta struct {
+	lastWriteHash []byte // hash of value that was last written, nil if the key is deleted
+	firstVersion  uint64
+	lastVersion   uint64
+	deleteVersion uint64
+}
+
+// serialize to bytes
+func (km *keyMeta) serialize() []byte {
+	return byteutil.Must(proto.Marshal(km.toProto()))
+}
+
+func (km *keyMeta) toProto() *versionpb.KeyMeta {
+	return &versionpb.KeyMeta{
+		LastWriteHash: km.lastWriteHash,
+		FirstVersion:  km.firstVersion,
+		LastVersion:   km.lastVersion,
+		DeleteVersion: km.deleteVersion,
+	}
+}
+
+func fromProtoKM(pb *versionpb.KeyMeta) *keyMeta {
+	return &keyMeta{
+		lastWriteHash: pb.LastWriteHash,
+		firstVersion:  pb.FirstVersion,
+		lastVersion:   pb.LastVersion,
+		deleteVersion: pb.DeleteVersion,
+	}
+}
+
+// deserializeKeyMeta deserializes byte-stream to KeyMeta
+func deserializeKeyMeta(buf []byte) (*keyMeta, error) {
+	var km versionpb.KeyMeta
+	if err := proto.Unmarshal(buf, &km); err != nil {
+		return nil, err
+	}
+	return fromProtoKM(&km), nil
+}
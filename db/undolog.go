@@ -0,0 +1,198 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// UndoLogNamespace is the namespace to store the undo logs of blocks
+	UndoLogNamespace = "UndoLog"
+)
+
+var (
+	// ErrUndoLogNotExist indicates the undo log of a height does not exist
+	ErrUndoLogNotExist = errors.New("undo log does not exist")
+
+	errInvalidUndoLog = errors.New("invalid undo log")
+)
+
+type (
+	// WriteQueue is a sequence of writes
+	WriteQueue interface {
+		// Size returns the number of writes
+		Size() int
+		// Entry returns the write at the index
+		Entry(int) (*batch.WriteInfo, error)
+	}
+
+	// undoEntry restores a key to its value before a block, value is nil if the key did not exist
+	undoEntry struct {
+		ns    string
+		key   []byte
+		value []byte
+	}
+)
+
+// UndoLogKey returns the key of the undo log of a height
+func UndoLogKey(height uint64) []byte {
+	return byteutil.Uint64ToBytesBigEndian(height)
+}
+
+// NewUndoLog creates the undo log of the writes, which records the values of the written keys in kv
+// before the writes are applied, so that the writes could be reverted with UndoBatch later
+func NewUndoLog(kv KVStoreBasic, writes WriteQueue) ([]byte, error) {
+	var (
+		entries []*undoEntry
+		written = make(map[string]map[string]struct{})
+	)
+	for i := 0; i < writes.Size(); i++ {
+		wi, err := writes.Entry(i)
+		if err != nil {
+			return nil, err
+		}
+		if wi.WriteType() != batch.Put && wi.WriteType() != batch.Delete {
+			continue
+		}
+		ns, key := wi.Namespace(), wi.Key()
+		if _, ok := written[ns]; !ok {
+			written[ns] = make(map[string]struct{})
+		}
+		if _, ok := written[ns][string(key)]; ok {
+			continue
+		}
+		written[ns][string(key)] = struct{}{}
+		value, err := kv.Get(ns, key)
+		switch errors.Cause(err) {
+		case nil:
+		case ErrNotExist, ErrBucketNotExist:
+			value = nil
+		default:
+			return nil, errors.Wrapf(err, "failed to get value of ns = %s and key = %x", ns, key)
+		}
+		entries = append(entries, &undoEntry{ns: ns, key: key, value: value})
+	}
+	return serializeUndoEntries(entries), nil
+}
+
+// PutUndoLog adds the undo log of the writes in the batch into the batch itself, and removes the undo
+// log which is older than the retention. No undo log is kept if the retention is 0
+func PutUndoLog(kv KVStoreBasic, b batch.KVStoreBatch, height, retention uint64) error {
+	if retention == 0 {
+		return nil
+	}
+	undoLog, err := NewUndoLog(kv, b)
+	if err != nil {
+		return err
+	}
+	b.Put(UndoLogNamespace, UndoLogKey(height), undoLog, "failed to put undo log")
+	if height > retention {
+		b.Delete(UndoLogNamespace, UndoLogKey(height-retention), "failed to delete undo log")
+	}
+	return nil
+}
+
+// UndoBatch returns the batch to revert the writes of a height, which also deletes the undo log
+func UndoBatch(kv KVStoreBasic, height uint64) (batch.KVStoreBatch, error) {
+	undoLog, err := kv.Get(UndoLogNamespace, UndoLogKey(height))
+	switch errors.Cause(err) {
+	case nil:
+	case ErrNotExist, ErrBucketNotExist:
+		return nil, errors.Wrapf(ErrUndoLogNotExist, "height = %d", height)
+	default:
+		return nil, err
+	}
+	entries, err := deserializeUndoEntries(undoLog)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode undo log at height %d", height)
+	}
+	b := batch.NewBatch()
+	for _, e := range entries {
+		if e.value == nil {
+			b.Delete(e.ns, e.key, "failed to undo put")
+		} else {
+			b.Put(e.ns, e.key, e.value, "failed to undo delete")
+		}
+	}
+	b.Delete(UndoLogNamespace, UndoLogKey(height), "failed to delete undo log")
+	return b, nil
+}
+
+// serializeUndoEntries encodes the entries as a list of (namespace, key, value), each field is
+// length-prefixed, and the value is preceded by a flag of existence
+func serializeUndoEntries(entries []*undoEntry) []byte {
+	var (
+		data        = binary.AppendUvarint(nil, uint64(len(entries)))
+		appendBytes = func(data, b []byte) []byte {
+			data = binary.AppendUvarint(data, uint64(len(b)))
+			return append(data, b...)
+		}
+	)
+	for _, e := range entries {
+		data = appendBytes(data, []byte(e.ns))
+		data = appendBytes(data, e.key)
+		if e.value == nil {
+			data = append(data, 0)
+		} else {
+			data = appendBytes(append(data, 1), e.value)
+		}
+	}
+	return data
+}
+
+func deserializeUndoEntries(data []byte) ([]*undoEntry, error) {
+	readBytes := func() ([]byte, error) {
+		l, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < l {
+			return nil, errInvalidUndoLog
+		}
+		b := make([]byte, l)
+		copy(b, data[n:])
+		data = data[n+int(l):]
+		return b, nil
+	}
+	size, n := binary.Uvarint(data)
+	if n <= 0 || size > uint64(len(data)) {
+		return nil, errInvalidUndoLog
+	}
+	data = data[n:]
+	entries := make([]*undoEntry, 0, size)
+	for i := uint64(0); i < size; i++ {
+		ns, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		e := &undoEntry{ns: string(ns)}
+		if e.key, err = readBytes(); err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			return nil, errInvalidUndoLog
+		}
+		flag := data[0]
+		data = data[1:]
+		switch flag {
+		case 0:
+		case 1:
+			if e.value, err = readBytes(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errInvalidUndoLog
+		}
+		entries = append(entries, e)
+	}
+	if len(data) != 0 {
+		return nil, errInvalidUndoLog
+	}
+	return entries, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestUndoLog(t *testing.T) {
+	r := require.New(t)
+
+	testPath, err := testutil.PathOfTempFile("undolog")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	kv := NewBoltDB(cfg)
+	ctx := context.Background()
+	r.NoError(kv.Start(ctx))
+	defer func() {
+		r.NoError(kv.Stop(ctx))
+	}()
+
+	r.NoError(kv.Put("ns", []byte("updated"), []byte("v0")))
+	r.NoError(kv.Put("ns", []byte("deleted"), []byte("v0")))
+	r.NoError(kv.Put("ns", []byte("empty"), []byte{}))
+
+	// retention 0 keeps no undo log
+	b := batch.NewBatch()
+	b.Put("ns", []byte("updated"), []byte("v1"), "")
+	r.NoError(PutUndoLog(kv, b, 1, 0))
+	r.Equal(1, b.Size())
+
+	for height := uint64(1); height <= 3; height++ {
+		b := batch.NewBatch()
+		b.Put("ns", []byte("updated"), []byte{byte(height)}, "")
+		b.Put("ns", []byte("updated"), []byte{byte(height), 1}, "")
+		b.Delete("ns", []byte("deleted"), "")
+		b.Put("ns", []byte("empty"), []byte{byte(height)}, "")
+		b.Put("newns", []byte("created"), []byte{byte(height)}, "")
+		r.NoError(PutUndoLog(kv, b, height, 2))
+		r.NoError(kv.WriteBatch(b))
+	}
+	// the undo log of height 1 is out of retention
+	_, err = UndoBatch(kv, 1)
+	r.Equal(ErrUndoLogNotExist, errors.Cause(err))
+
+	b, err = UndoBatch(kv, 3)
+	r.NoError(err)
+	r.NoError(kv.WriteBatch(b))
+	value, err := kv.Get("ns", []byte("updated"))
+	r.NoError(err)
+	r.Equal([]byte{2, 1}, value)
+	_, err = kv.Get("ns", []byte("deleted"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	value, err = kv.Get("newns", []byte("created"))
+	r.NoError(err)
+	r.Equal([]byte{2}, value)
+	_, err = UndoBatch(kv, 3)
+	r.Equal(ErrUndoLogNotExist, errors.Cause(err))
+
+	b, err = UndoBatch(kv, 2)
+	r.NoError(err)
+	r.NoError(kv.WriteBatch(b))
+	value, err = kv.Get("ns", []byte("updated"))
+	r.NoError(err)
+	r.Equal([]byte{1, 1}, value)
+	_, err = kv.Get("ns", []byte("deleted"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	value, err = kv.Get("ns", []byte("empty"))
+	r.NoError(err)
+	r.Equal([]byte{1}, value)
+	value, err = kv.Get("newns", []byte("created"))
+	r.NoError(err)
+	r.Equal([]byte{1}, value)
+}
+
+func TestDeserializeUndoEntries(t *testing.T) {
+	r := require.New(t)
+
+	data := serializeUndoEntries([]*undoEntry{
+		{ns: "ns", key: []byte{1}, value: []byte{2}},
+		{ns: "ns", key: []byte{3}},
+		{ns: "ns", key: []byte{4}, value: []byte{}},
+	})
+	entries, err := deserializeUndoEntries(data)
+	r.NoError(err)
+	r.Len(entries, 3)
+	r.Equal([]byte{2}, entries[0].value)
+	r.Nil(entries[1].value)
+	r.NotNil(entries[2].value)
+	for i := 0; i < len(data); i++ {
+		_, err := deserializeUndoEntries(data[:i])
+		r.Equal(errInvalidUndoLog, err)
+	}
+	_, err = deserializeUndoEntries(append(data, 0))
+	r.Equal(errInvalidUndoLog, err)
+}
@@ -17,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/chainservice"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/dispatcher"
@@ -92,6 +93,7 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 		if err := cs.Blockchain().AddSubscriber(apiServer); err != nil {
 			return nil, errors.Wrap(err, "failed to add api server as subscriber")
 		}
+		cs.ActionPool().AddSubscriber(apiServer)
 	}
 	// TODO: explorer dependency deleted here at #1085, need to revive by migrating to api
 	chains[cs.ChainID()] = cs
@@ -253,6 +255,8 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		log.RegisterLevelConfigMux(mux)
 		haCtl := ha.New(svr.rootChainService.Consensus())
 		mux.Handle("/ha", http.HandlerFunc(haCtl.Handle))
+		indexerCtl := blockindex.NewIndexerController(svr.rootChainService.IndexBuilders()...)
+		mux.Handle("/indexer", http.HandlerFunc(indexerCtl.Handle))
 		mux.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
 		mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
 		mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
@@ -87,6 +87,8 @@ type (
 		// NewBlockBuilder creates block builder
 		NewBlockBuilder(context.Context, actpool.ActPool, func(action.Envelope) (*action.SealedEnvelope, error)) (*block.Builder, error)
 		SimulateExecution(context.Context, address.Address, *action.Execution) ([]byte, *action.Receipt, error)
+		// NewSimulator creates a simulator of executions on the tip state, the state changes are discarded
+		NewSimulator(context.Context) (*evm.Simulator, error)
 		ReadContractStorage(context.Context, address.Address, []byte) ([]byte, error)
 		PutBlock(context.Context, *block.Block) error
 		DeleteTipBlock(context.Context, *block.Block) error
@@ -98,6 +100,7 @@ type (
 	factory struct {
 		lifecycle                lifecycle.Lifecycle
 		mutex                    sync.RWMutex
+		prefetcher               *statePrefetcher
 		cfg                      Config
 		registry                 *protocol.Registry
 		currentChainHeight       uint64
@@ -181,6 +184,9 @@ func NewFactory(cfg Config, dao db.KVStore, opts ...Option) (Factory, error) {
 		log.L().Error("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	sf.timerFactory = timerFactory
+	sf.prefetcher = newStatePrefetcher(func() (db.KVStoreBasic, error) {
+		return newTrieStateReader(sf.dao)
+	}, &sf.mutex, cfg.Chain.StatePrefetchWorkers)
 
 	return sf, nil
 }
@@ -234,6 +240,7 @@ func (sf *factory) Start(ctx context.Context) error {
 }
 
 func (sf *factory) Stop(ctx context.Context) error {
+	sf.prefetcher.Cancel()
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
 	if err := sf.dao.Stop(ctx); err != nil {
@@ -287,7 +294,9 @@ func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSe
 		}
 	}
 
-	return newWorkingSet(height, store), nil
+	ws := newWorkingSet(height, store)
+	ws.parallelExecutionWorkers = sf.cfg.Chain.ParallelExecutionWorkers
+	return ws, nil
 }
 
 func (sf *factory) flusherOptions(preEaster bool) []db.KVStoreFlusherOption {
@@ -324,6 +333,11 @@ func (sf *factory) Register(p protocol.Protocol) error {
 	return p.Register(sf.registry)
 }
 
+// Prefetch starts loading the states the block is going to read in background
+func (sf *factory) Prefetch(ctx context.Context, blk *block.Block) {
+	sf.prefetcher.Prefetch(ctx, blk.Actions)
+}
+
 func (sf *factory) Validate(ctx context.Context, blk *block.Block) error {
 	ctx = protocol.WithRegistry(ctx, sf.registry)
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
@@ -401,6 +415,17 @@ func (sf *factory) SimulateExecution(
 	return evm.SimulateExecution(ctx, ws, caller, ex)
 }
 
+// NewSimulator creates a simulator of executions on a working set of the tip state
+func (sf *factory) NewSimulator(ctx context.Context) (*evm.Simulator, error) {
+	sf.mutex.Lock()
+	ws, err := sf.newWorkingSet(ctx, sf.currentChainHeight+1)
+	sf.mutex.Unlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	return evm.NewSimulator(ws), nil
+}
+
 // ReadContractStorage reads contract's storage
 func (sf *factory) ReadContractStorage(ctx context.Context, contract address.Address, key []byte) ([]byte, error) {
 	sf.mutex.Lock()
@@ -414,6 +439,8 @@ func (sf *factory) ReadContractStorage(ctx context.Context, contract address.Add
 
 // PutBlock persists all changes in RunActions() into the DB
 func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
+	// must be cancelled before acquiring the lock, which the prefetcher waits for
+	sf.prefetcher.Cancel()
 	sf.mutex.Lock()
 	timer := sf.timerFactory.NewTimer("Commit")
 	sf.mutex.Unlock()
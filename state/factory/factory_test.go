@@ -843,6 +843,86 @@ func testNonce(ctx context.Context, sf Factory, t *testing.T) {
 	require.Equal(t, uint64(2), state.PendingNonce())
 }
 
+func TestSDBDeleteTipBlock(t *testing.T) {
+	require := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile(_stateDBPath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+
+	cfg := DefaultConfig
+	cfg.Chain.TrieDBPath = testDBPath
+	cfg.Chain.RollbackRetention = 2
+	db2, err := db.CreateKVStoreWithCache(db.DefaultConfig, cfg.Chain.TrieDBPath, cfg.Chain.StateDBCacheSize)
+	require.NoError(err)
+	reg := protocol.NewRegistry()
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(reg))
+	subscriber := &testStateChangeSubscriber{}
+	sdb, err := NewStateDB(cfg, db2, SkipBlockValidationStateDBOption(), RegistryStateDBOption(reg), StateChangeSubscriberStateDBOption(subscriber))
+	require.NoError(err)
+
+	a := identityset.Address(28)
+	b := identityset.Address(29)
+	ge := genesis.Default
+	ge.InitBalanceMap[a.String()] = "100"
+	ctx := protocol.WithBlockchainCtx(
+		genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), reg), ge),
+		protocol.BlockchainCtx{ChainID: 1},
+	)
+	require.NoError(sdb.Start(protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{}))))
+	defer func() {
+		require.NoError(sdb.Stop(ctx))
+	}()
+
+	acct, err := accountutil.AccountState(ctx, sdb, b)
+	require.NoError(err)
+	initBalance := acct.Balance
+	blks := make([]*block.Block, 4)
+	for i := 1; i < len(blks); i++ {
+		tx, err := action.NewTransfer(uint64(i-1), big.NewInt(2), b.String(), nil, uint64(20000), big.NewInt(0))
+		require.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetAction(tx).SetNonce(uint64(i - 1)).SetGasLimit(20000).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(28))
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i)).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		blks[i] = &blk
+		require.NoError(sdb.PutBlock(ctx, blks[i]))
+	}
+	checkState := func(height uint64) {
+		h, err := sdb.Height()
+		require.NoError(err)
+		require.Equal(height, h)
+		acct, err := accountutil.AccountState(ctx, sdb, a)
+		require.NoError(err)
+		require.Equal(height, acct.PendingNonce())
+		acct, err = accountutil.AccountState(ctx, sdb, b)
+		require.NoError(err)
+		require.Equal(new(big.Int).Add(initBalance, big.NewInt(int64(2*height))), acct.Balance)
+	}
+	checkState(3)
+
+	// only the tip block could be deleted
+	require.Error(sdb.DeleteTipBlock(ctx, blks[2]))
+	require.NoError(sdb.DeleteTipBlock(ctx, blks[3]))
+	checkState(2)
+	require.Equal(uint64(2), subscriber.height)
+	require.NoError(sdb.DeleteTipBlock(ctx, blks[2]))
+	checkState(1)
+	// the undo log of block 1 is out of retention
+	err = sdb.DeleteTipBlock(ctx, blks[1])
+	require.Equal(db.ErrUndoLogNotExist, errors.Cause(err))
+	checkState(1)
+
+	// the reverted blocks could be committed again
+	require.NoError(sdb.PutBlock(ctx, blks[2]))
+	checkState(2)
+}
+
 func TestLoadStoreHeight(t *testing.T) {
 	testTriePath, err := testutil.PathOfTempFile(_triePath)
 	require.NoError(t, err)
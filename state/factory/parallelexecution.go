@@ -0,0 +1,428 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var (
+	_parallelExecutionMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_parallel_execution",
+			Help: "IoTeX parallel execution of actions in workingset",
+		},
+		[]string{"result"},
+	)
+
+	errSpeculationAborted = errors.New("speculative execution aborted")
+)
+
+func init() {
+	prometheus.MustRegister(_parallelExecutionMtc)
+}
+
+type (
+	kvKey struct {
+		ns  string
+		key string
+	}
+
+	// kvRead is a value read from the base store, exist is false if the key does not exist
+	kvRead struct {
+		value []byte
+		exist bool
+	}
+
+	// kvWrite is a write to the store, or a deferred deposit if deposit is not nil
+	kvWrite struct {
+		ns      string
+		key     []byte
+		value   []byte
+		delete  bool
+		deposit func(protocol.StateManager) error
+	}
+
+	statesRead struct {
+		ns     string
+		keys   [][]byte
+		values [][]byte
+	}
+
+	// speculativeStore is a workingSetStore layered on top of a base store, which is
+	// read-only during the speculation. It buffers all writes locally, and records the
+	// read set, so that the result can be validated and applied to the base store later
+	speculativeStore struct {
+		base       workingSetStore
+		baseMutex  *sync.Mutex
+		reads      map[kvKey]*kvRead
+		statesRead []*statesRead
+		writes     []*kvWrite
+		dirty      map[kvKey]*kvWrite
+		dirtyNs    map[string]bool
+		snapshots  []int
+		viewRead   bool
+		aborted    bool
+	}
+
+	// speculativeDock is a read-only protocol.Dock on top of the base dock
+	speculativeDock struct {
+		base  protocol.Dock
+		store *speculativeStore
+	}
+
+	// speculativeResult is the result of executing an action on a speculativeStore
+	speculativeResult struct {
+		ctx     context.Context
+		store   *speculativeStore
+		receipt *action.Receipt
+		err     error
+	}
+)
+
+func newSpeculativeStore(base workingSetStore, baseMutex *sync.Mutex) *speculativeStore {
+	return &speculativeStore{
+		base:      base,
+		baseMutex: baseMutex,
+		reads:     make(map[kvKey]*kvRead),
+		dirty:     make(map[kvKey]*kvWrite),
+		dirtyNs:   make(map[string]bool),
+	}
+}
+
+func (store *speculativeStore) abort() error {
+	store.aborted = true
+	return errSpeculationAborted
+}
+
+func (store *speculativeStore) Start(context.Context) error {
+	return nil
+}
+
+func (store *speculativeStore) Stop(context.Context) error {
+	return nil
+}
+
+func (store *speculativeStore) Get(ns string, key []byte) ([]byte, error) {
+	k := kvKey{ns, string(key)}
+	if w, ok := store.dirty[k]; ok {
+		if w.delete {
+			return nil, errors.Wrapf(state.ErrStateNotExist, "failed to get state of ns = %x and key = %x", ns, key)
+		}
+		return w.value, nil
+	}
+	r, ok := store.reads[k]
+	if !ok {
+		store.baseMutex.Lock()
+		value, err := store.base.Get(ns, key)
+		store.baseMutex.Unlock()
+		switch errors.Cause(err) {
+		case nil:
+			r = &kvRead{value: value, exist: true}
+		case state.ErrStateNotExist:
+			r = &kvRead{}
+		default:
+			store.aborted = true
+			return nil, err
+		}
+		store.reads[k] = r
+	}
+	if !r.exist {
+		return nil, errors.Wrapf(state.ErrStateNotExist, "failed to get state of ns = %x and key = %x", ns, key)
+	}
+	return r.value, nil
+}
+
+func (store *speculativeStore) Put(ns string, key []byte, value []byte) error {
+	store.write(&kvWrite{ns: ns, key: key, value: value})
+	return nil
+}
+
+func (store *speculativeStore) Delete(ns string, key []byte) error {
+	// base stores differ in how deleting a non-existing key is handled, so leave it to sequential execution
+	if _, err := store.Get(ns, key); err != nil {
+		return store.abort()
+	}
+	store.write(&kvWrite{ns: ns, key: key, delete: true})
+	return nil
+}
+
+func (store *speculativeStore) write(w *kvWrite) {
+	store.writes = append(store.writes, w)
+	store.dirty[kvKey{w.ns, string(w.key)}] = w
+	store.dirtyNs[w.ns] = true
+}
+
+func (store *speculativeStore) States(ns string, keys [][]byte) ([][]byte, error) {
+	if store.dirtyNs[ns] {
+		// cannot merge local writes into the base store's view of the namespace
+		return nil, store.abort()
+	}
+	store.baseMutex.Lock()
+	values, err := store.base.States(ns, keys)
+	store.baseMutex.Unlock()
+	if err != nil {
+		store.aborted = true
+		return nil, err
+	}
+	store.statesRead = append(store.statesRead, &statesRead{
+		ns:     ns,
+		keys:   keys,
+		values: values,
+	})
+	return values, nil
+}
+
+func (store *speculativeStore) Commit() error {
+	return store.abort()
+}
+
+func (store *speculativeStore) Digest() hash.Hash256 {
+	return hash.ZeroHash256
+}
+
+func (store *speculativeStore) Finalize(uint64) error {
+	return store.abort()
+}
+
+func (store *speculativeStore) Snapshot() int {
+	store.snapshots = append(store.snapshots, len(store.writes))
+	return len(store.snapshots) - 1
+}
+
+func (store *speculativeStore) RevertSnapshot(snapshot int) error {
+	if snapshot < 0 || snapshot >= len(store.snapshots) {
+		return errors.Errorf("invalid snapshot number = %d", snapshot)
+	}
+	store.writes = store.writes[:store.snapshots[snapshot]]
+	store.snapshots = store.snapshots[:snapshot+1]
+	store.dirty = make(map[kvKey]*kvWrite)
+	store.dirtyNs = make(map[string]bool)
+	for _, w := range store.writes {
+		if w.deposit != nil {
+			continue
+		}
+		store.dirty[kvKey{w.ns, string(w.key)}] = w
+		store.dirtyNs[w.ns] = true
+	}
+	return nil
+}
+
+func (store *speculativeStore) ResetSnapshots() {
+	store.snapshots = nil
+}
+
+func (store *speculativeStore) ReadView(name string) (interface{}, error) {
+	store.viewRead = true
+	store.baseMutex.Lock()
+	defer store.baseMutex.Unlock()
+	return store.base.ReadView(name)
+}
+
+func (store *speculativeStore) WriteView(string, interface{}) error {
+	return store.abort()
+}
+
+// validate checks that every state read during the speculation still has the same value in the base store
+func (store *speculativeStore) validate() (bool, error) {
+	for k, r := range store.reads {
+		value, err := store.base.Get(k.ns, []byte(k.key))
+		switch errors.Cause(err) {
+		case nil:
+			if !r.exist || !bytes.Equal(value, r.value) {
+				return false, nil
+			}
+		case state.ErrStateNotExist:
+			if r.exist {
+				return false, nil
+			}
+		default:
+			return false, err
+		}
+	}
+	for _, r := range store.statesRead {
+		values, err := store.base.States(r.ns, r.keys)
+		if err != nil {
+			return false, err
+		}
+		if len(values) != len(r.values) {
+			return false, nil
+		}
+		for i := range values {
+			if !bytes.Equal(values[i], r.values[i]) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// deferDeposit is the protocol.DepositDeferrer of the speculation. Deposits into a shared fund are
+// kept out of the read set, otherwise every action paying gas would conflict with the preceding one.
+// The deposit keeps its position in the write log, so the write order is the same as sequential execution
+func (store *speculativeStore) deferDeposit(deposit func(protocol.StateManager) error) {
+	store.writes = append(store.writes, &kvWrite{deposit: deposit})
+}
+
+// apply writes the local writes into the base store and runs the deferred deposits on sm, in the same
+// order as they happened
+func (store *speculativeStore) apply(sm protocol.StateManager) error {
+	for _, w := range store.writes {
+		var err error
+		switch {
+		case w.deposit != nil:
+			err = w.deposit(sm)
+		case w.delete:
+			err = store.base.Delete(w.ns, w.key)
+		default:
+			err = store.base.Put(w.ns, w.key, w.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *speculativeDock) ProtocolDirty(name string) bool {
+	d.store.viewRead = true
+	d.store.baseMutex.Lock()
+	defer d.store.baseMutex.Unlock()
+	return d.base.ProtocolDirty(name)
+}
+
+func (d *speculativeDock) Load(string, string, interface{}) error {
+	return d.store.abort()
+}
+
+func (d *speculativeDock) Unload(name, key string, v interface{}) error {
+	d.store.viewRead = true
+	d.store.baseMutex.Lock()
+	defer d.store.baseMutex.Unlock()
+	return d.base.Unload(name, key, v)
+}
+
+func (d *speculativeDock) Reset() {
+	d.store.aborted = true
+}
+
+// speculative returns whether an action could be executed speculatively
+func speculative(ctx context.Context, selp *action.SealedEnvelope) bool {
+	if action.IsSystemAction(selp) {
+		return false
+	}
+	if protocol.MustGetFeatureCtx(ctx).UseTxContainer {
+		// unfolding a tx container modifies the envelope
+		if _, ok := selp.Action().(action.TxContainer); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// runActionsInParallel executes actions optimistically in parallel against the state at the beginning
+// of the actions, then validates and applies the results one by one in the original order. An action
+// whose read set has been changed by a preceding action is re-executed on the workingset, so the
+// receipts and the state are identical to running the actions sequentially
+func (ws *workingSet) runActionsInParallel(
+	ctx context.Context,
+	elps []*action.SealedEnvelope,
+) ([]*action.Receipt, error) {
+	var (
+		results   = make([]*speculativeResult, len(elps))
+		baseMutex sync.Mutex
+		jobs      = make(chan int, len(elps))
+		wg        sync.WaitGroup
+	)
+	for i, elp := range elps {
+		ctxWithActionContext, err := withActionCtx(ctx, elp)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &speculativeResult{ctx: ctxWithActionContext}
+		if speculative(ctx, elp) {
+			jobs <- i
+		}
+	}
+	close(jobs)
+	for w := 0; w < ws.parallelExecutionWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r := results[i]
+				r.store = newSpeculativeStore(ws.store, &baseMutex)
+				sws := &workingSet{
+					height: ws.height,
+					store:  r.store,
+					dock:   &speculativeDock{base: ws.dock, store: r.store},
+				}
+				r.receipt, r.err = sws.runAction(
+					protocol.WithDepositDeferrerCtx(r.ctx, r.store.deferDeposit),
+					elps[i],
+				)
+			}
+		}()
+	}
+	wg.Wait()
+
+	viewVersion := ws.viewVersion
+	receipts := make([]*action.Receipt, 0, len(elps))
+	for i, elp := range elps {
+		r := results[i]
+		receipt, err := ws.settleSpeculativeResult(r, viewVersion)
+		if err != nil {
+			return nil, err
+		}
+		if receipt == nil {
+			if receipt, err = ws.runAction(r.ctx, elp); err != nil {
+				return nil, errors.Wrap(err, "error when run action")
+			}
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+// settleSpeculativeResult applies a valid speculative result to the workingset, and returns its receipt.
+// It returns nil if the action needs to be executed again
+func (ws *workingSet) settleSpeculativeResult(r *speculativeResult, viewVersion uint64) (*action.Receipt, error) {
+	switch {
+	case r.store == nil:
+		_parallelExecutionMtc.WithLabelValues("skip").Inc()
+		return nil, nil
+	case r.err != nil, r.store.aborted:
+		_parallelExecutionMtc.WithLabelValues("abort").Inc()
+		return nil, nil
+	case r.store.viewRead && ws.viewVersion != viewVersion:
+		_parallelExecutionMtc.WithLabelValues("conflict").Inc()
+		return nil, nil
+	}
+	valid, err := r.store.validate()
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		_parallelExecutionMtc.WithLabelValues("conflict").Inc()
+		return nil, nil
+	}
+	defer ws.ResetSnapshots()
+	if err := r.store.apply(ws); err != nil {
+		return nil, err
+	}
+	_parallelExecutionMtc.WithLabelValues("hit").Inc()
+	return r.receipt, nil
+}
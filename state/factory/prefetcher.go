@@ -0,0 +1,258 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var (
+	_prefetchMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_state_prefetch",
+			Help: "IoTeX state prefetcher",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_prefetchMtc)
+}
+
+type (
+	// prefetchTask is the states of an address to be prefetched
+	prefetchTask struct {
+		addr  hash.Hash160
+		slots []hash.Hash256
+	}
+
+	// statePrefetcher loads the states that a block is going to read into the underlying
+	// store's cache in background, while the block is being executed
+	statePrefetcher struct {
+		newReader func() (db.KVStoreBasic, error)
+		rwMutex   *sync.RWMutex // held while reading, so prefetching never interleaves with a commit
+		workers   int
+
+		mutex  sync.Mutex
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+
+	// trieStateReader reads the states of a trie factory from its account trie
+	trieStateReader struct {
+		tlt trie.TwoLayerTrie
+	}
+)
+
+func newStatePrefetcher(newReader func() (db.KVStoreBasic, error), rwMutex *sync.RWMutex, workers int) *statePrefetcher {
+	return &statePrefetcher{
+		newReader: newReader,
+		rwMutex:   rwMutex,
+		workers:   workers,
+	}
+}
+
+// Prefetch starts prefetching the states read by the actions, the ongoing prefetch is cancelled.
+// The prefetch runs along with the execution of the actions, until it is done or cancelled
+func (p *statePrefetcher) Prefetch(ctx context.Context, actions []*action.SealedEnvelope) {
+	if p == nil || p.workers <= 0 {
+		return
+	}
+	tasks := prefetchTasks(actions)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.stop()
+	ctx, p.cancel = context.WithCancel(ctx)
+	jobs := make(chan *prefetchTask, len(tasks))
+	for _, task := range tasks {
+		jobs <- task
+	}
+	close(jobs)
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			reader, err := p.newReader()
+			if err != nil {
+				log.L().Debug("failed to create state reader", zap.Error(err))
+				return
+			}
+			for task := range jobs {
+				select {
+				case <-ctx.Done():
+					_prefetchMtc.WithLabelValues("cancel").Inc()
+					return
+				default:
+				}
+				if err := p.prefetch(reader, task); err != nil {
+					log.L().Debug("failed to prefetch states", zap.Error(err))
+				}
+			}
+		}()
+	}
+}
+
+// Cancel cancels the ongoing prefetch, and waits for the workers to exit
+func (p *statePrefetcher) Cancel() {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.stop()
+}
+
+func (p *statePrefetcher) stop() {
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+	p.wg.Wait()
+}
+
+func (p *statePrefetcher) prefetch(reader db.KVStoreBasic, task *prefetchTask) error {
+	p.rwMutex.RLock()
+	defer p.rwMutex.RUnlock()
+	_prefetchMtc.WithLabelValues("account").Inc()
+	data, err := reader.Get(AccountKVNamespace, task.addr[:])
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil
+	default:
+		return err
+	}
+	acct := &state.Account{}
+	if err := acct.Deserialize(data); err != nil {
+		return err
+	}
+	if !acct.IsContract() {
+		return nil
+	}
+	_prefetchMtc.WithLabelValues("code").Inc()
+	if _, err := reader.Get(evm.CodeKVNameSpace, acct.CodeHash); err != nil {
+		return err
+	}
+	if len(task.slots) == 0 || acct.Root == hash.ZeroHash256 {
+		return nil
+	}
+	kv, err := trie.NewKVStore(evm.ContractKVNameSpace, reader)
+	if err != nil {
+		return err
+	}
+	addr := task.addr
+	tr, err := mptrie.New(
+		mptrie.KVStoreOption(kv),
+		mptrie.KeyLengthOption(len(hash.Hash256{})),
+		mptrie.HashFuncOption(func(data []byte) []byte {
+			h := hash.Hash256b(append(addr[:], data...))
+			return h[:]
+		}),
+		mptrie.RootHashOption(acct.Root[:]),
+	)
+	if err != nil {
+		return err
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return err
+	}
+	for _, slot := range task.slots {
+		_prefetchMtc.WithLabelValues("storage").Inc()
+		if _, err := tr.Get(slot[:]); err != nil && errors.Cause(err) != trie.ErrNotExist {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTrieStateReader(dao db.KVStore) (db.KVStoreBasic, error) {
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, dao, ArchiveTrieRootKey, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := tlt.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	return &trieStateReader{tlt: tlt}, nil
+}
+
+func (r *trieStateReader) Start(ctx context.Context) error {
+	return r.tlt.Start(ctx)
+}
+
+func (r *trieStateReader) Stop(ctx context.Context) error {
+	return r.tlt.Stop(ctx)
+}
+
+func (r *trieStateReader) Get(ns string, key []byte) ([]byte, error) {
+	data, err := readState(r.tlt, ns, key)
+	if errors.Cause(err) == state.ErrStateNotExist {
+		return nil, errors.Wrap(db.ErrNotExist, err.Error())
+	}
+	return data, err
+}
+
+func (r *trieStateReader) Put(string, []byte, []byte) error {
+	return errors.Wrap(ErrNotSupported, "trie state reader is read-only")
+}
+
+func (r *trieStateReader) Delete(string, []byte) error {
+	return errors.Wrap(ErrNotSupported, "trie state reader is read-only")
+}
+
+// prefetchTasks collects the senders, recipients and access list storage slots of the actions
+func prefetchTasks(actions []*action.SealedEnvelope) []*prefetchTask {
+	var (
+		tasks   []*prefetchTask
+		taskMap = make(map[hash.Hash160]*prefetchTask)
+	)
+	addTask := func(addr hash.Hash160) *prefetchTask {
+		if task, ok := taskMap[addr]; ok {
+			return task
+		}
+		task := &prefetchTask{addr: addr}
+		taskMap[addr] = task
+		tasks = append(tasks, task)
+		return task
+	}
+	for _, selp := range actions {
+		if sender := selp.SenderAddress(); sender != nil {
+			addTask(hash.BytesToHash160(sender.Bytes()))
+		}
+		if dst, ok := selp.Destination(); ok && dst != "" {
+			if addr, err := address.FromString(dst); err == nil {
+				addTask(hash.BytesToHash160(addr.Bytes()))
+			}
+		}
+		exec, ok := selp.Action().(*action.Execution)
+		if !ok {
+			continue
+		}
+		for _, tuple := range exec.AccessList() {
+			task := addTask(hash.BytesToHash160(tuple.Address.Bytes()))
+			for _, key := range tuple.StorageKeys {
+				task.slots = append(task.slots, hash.Hash256(key))
+			}
+		}
+	}
+	return tasks
+}
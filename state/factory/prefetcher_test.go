@@ -0,0 +1,112 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type countingKVStore struct {
+	db.KVStore
+	gets int32
+}
+
+func (kv *countingKVStore) Get(ns string, key []byte) ([]byte, error) {
+	atomic.AddInt32(&kv.gets, 1)
+	return kv.KVStore.Get(ns, key)
+}
+
+func TestPrefetchTasks(t *testing.T) {
+	r := require.New(t)
+	actions := []*action.SealedEnvelope{
+		makeTransferActionFrom(t, 20, 1, 21),
+		makeTransferActionFrom(t, 20, 2, 22),
+		makeTransferActionFrom(t, 21, 1, 20),
+	}
+	tasks := prefetchTasks(actions)
+	r.Len(tasks, 3)
+	for i, id := range []int{20, 21, 22} {
+		r.Equal(hash.BytesToHash160(identityset.Address(id).Bytes()), tasks[i].addr)
+		r.Empty(tasks[i].slots)
+	}
+}
+
+func TestStatePrefetcher(t *testing.T) {
+	r := require.New(t)
+	actions := []*action.SealedEnvelope{
+		makeTransferActionFrom(t, 20, 1, 21),
+	}
+	newFactories := func(kv db.KVStore, workers int) []Factory {
+		cfg := Config{
+			Chain:   blockchain.DefaultConfig,
+			Genesis: genesis.TestDefault(),
+		}
+		cfg.Chain.StatePrefetchWorkers = workers
+		for _, i := range []int{20, 21} {
+			cfg.Genesis.InitBalanceMap[identityset.Address(i).String()] = "100000000000000000000"
+		}
+		registry := protocol.NewRegistry()
+		r.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+		f1, err := NewFactory(cfg, kv, RegistryOption(registry))
+		r.NoError(err)
+		f2, err := NewStateDB(cfg, kv, RegistryStateDBOption(registry))
+		r.NoError(err)
+		return []Factory{f1, f2}
+	}
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{})
+
+	for i := range newFactories(db.NewMemKVStore(), 0) {
+		// disabled
+		kv := &countingKVStore{KVStore: db.NewMemKVStore()}
+		f := newFactories(db.NewKvStoreWithCache(kv, 1000), 0)[i]
+		r.NoError(f.Start(ctx))
+		gets := atomic.LoadInt32(&kv.gets)
+		f.(block.Prefetcher).Prefetch(ctx, &block.Block{Body: block.Body{Actions: actions}})
+		r.Equal(gets, atomic.LoadInt32(&kv.gets))
+		r.NoError(f.Stop(ctx))
+
+		// the states read by the actions are served by the cache afterwards
+		kv = &countingKVStore{KVStore: db.NewMemKVStore()}
+		f = newFactories(db.NewKvStoreWithCache(kv, 1000), 2)[i]
+		r.NoError(f.Start(ctx))
+		f.(block.Prefetcher).Prefetch(ctx, &block.Block{Body: block.Body{Actions: actions}})
+		var p *statePrefetcher
+		switch f := f.(type) {
+		case *factory:
+			p = f.prefetcher
+		case *stateDB:
+			p = f.prefetcher
+		}
+		p.wg.Wait()
+		gets = atomic.LoadInt32(&kv.gets)
+		r.NotZero(gets)
+		ws, err := f.(workingSetCreator).newWorkingSet(ctx, 1)
+		r.NoError(err)
+		for _, id := range []int{20, 21} {
+			_, err := accountutil.AccountState(ctx, ws, identityset.Address(id))
+			r.NoError(err)
+		}
+		r.Equal(gets, atomic.LoadInt32(&kv.gets))
+		r.NoError(f.Stop(ctx))
+	}
+}
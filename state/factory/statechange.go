@@ -0,0 +1,102 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+type (
+	// StateChange is the change of a state made by a block. OldValue is nil if the state
+	// is created by the block, and NewValue is nil if the state is deleted by the block
+	StateChange struct {
+		Namespace string
+		Key       []byte
+		OldValue  []byte
+		NewValue  []byte
+	}
+
+	// StateChangeSubscriber receives the state changes of every block committed into the state db
+	StateChangeSubscriber interface {
+		ReceiveStateChanges(height uint64, changes []*StateChange) error
+		// DeleteTipBlock is called after the states of the tip block are reverted
+		DeleteTipBlock(ctx context.Context, blk *block.Block) error
+	}
+)
+
+// StateChangeSubscriberStateDBOption sets the subscriber of the state changes in state db
+func StateChangeSubscriberStateDBOption(subscriber StateChangeSubscriber) StateDBOption {
+	return func(sdb *stateDB, cfg *Config) error {
+		sdb.stateChangeSubscriber = subscriber
+		return nil
+	}
+}
+
+// stateChanges returns the net changes of the states written into the buffer, in the order
+// of the first write of each state. States written back to the original value and the states
+// kept by the state db itself are omitted
+func (store *stateDBWorkingSetStore) stateChanges() ([]*StateChange, error) {
+	var (
+		kvb     = store.flusher.KVStoreWithBuffer()
+		base    = store.flusher.BaseKVStore()
+		changes []*StateChange
+		written = make(map[kvKey]*StateChange)
+	)
+	for i := 0; i < kvb.Size(); i++ {
+		wi, err := kvb.Entry(i)
+		if err != nil {
+			return nil, err
+		}
+		if isBookkeepingState(wi.Namespace(), wi.Key()) {
+			continue
+		}
+		k := kvKey{wi.Namespace(), string(wi.Key())}
+		change, ok := written[k]
+		if !ok {
+			value, err := base.Get(wi.Namespace(), wi.Key())
+			switch errors.Cause(err) {
+			case nil:
+			case db.ErrNotExist, db.ErrBucketNotExist:
+				value = nil
+			default:
+				return nil, errors.Wrapf(err, "failed to get state of ns = %x and key = %x", wi.Namespace(), wi.Key())
+			}
+			change = &StateChange{
+				Namespace: wi.Namespace(),
+				Key:       wi.Key(),
+				OldValue:  value,
+			}
+			written[k] = change
+			changes = append(changes, change)
+		}
+		switch wi.WriteType() {
+		case batch.Put:
+			change.NewValue = wi.Value()
+		case batch.Delete:
+			change.NewValue = nil
+		}
+	}
+	ret := changes[:0]
+	for _, change := range changes {
+		if (change.OldValue == nil) == (change.NewValue == nil) && bytes.Equal(change.OldValue, change.NewValue) {
+			continue
+		}
+		ret = append(ret, change)
+	}
+	return ret, nil
+}
+
+// isBookkeepingState returns whether the state is kept by the state db itself rather than the protocols
+func isBookkeepingState(ns string, key []byte) bool {
+	return (ns == AccountKVNamespace && string(key) == CurrentHeightKey) || ns == db.UndoLogNamespace
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+type testStateChangeSubscriber struct {
+	height  uint64
+	changes []*StateChange
+}
+
+func (s *testStateChangeSubscriber) ReceiveStateChanges(height uint64, changes []*StateChange) error {
+	s.height = height
+	s.changes = changes
+	return nil
+}
+
+func (s *testStateChangeSubscriber) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	s.height = blk.Height() - 1
+	s.changes = nil
+	return nil
+}
+
+func TestStateChanges(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	ns := "namespace"
+	kv := db.NewMemKVStore()
+	require.NoError(kv.Start(ctx))
+	require.NoError(kv.Put(ns, []byte("existing"), []byte("v0")))
+	require.NoError(kv.Put(ns, []byte("unchanged"), []byte("v0")))
+	require.NoError(kv.Put(ns, []byte("deleted"), []byte("v0")))
+
+	flusher, err := db.NewKVStoreFlusher(kv, batch.NewCachedBatch())
+	require.NoError(err)
+	store := newStateDBWorkingSetStore(protocol.View{}, flusher, true)
+	require.NoError(store.Put(ns, []byte("existing"), []byte("v1")))
+	require.NoError(store.Put(ns, []byte("created"), []byte("v1")))
+	require.NoError(store.Put(ns, []byte("unchanged"), []byte("v1")))
+	require.NoError(store.Put(ns, []byte("existing"), []byte("v2")))
+	require.NoError(store.Put(ns, []byte("unchanged"), []byte("v0")))
+	require.NoError(store.Delete(ns, []byte("deleted")))
+	require.NoError(store.Put(ns, []byte("transient"), []byte("v1")))
+	require.NoError(store.Delete(ns, []byte("transient")))
+	require.NoError(store.Finalize(5))
+	sn := store.Snapshot()
+	require.NoError(store.Put(ns, []byte("reverted"), []byte("v1")))
+	require.NoError(store.RevertSnapshot(sn))
+
+	expected := []*StateChange{
+		{Namespace: ns, Key: []byte("existing"), OldValue: []byte("v0"), NewValue: []byte("v2")},
+		{Namespace: ns, Key: []byte("created"), NewValue: []byte("v1")},
+		{Namespace: ns, Key: []byte("deleted"), OldValue: []byte("v0")},
+	}
+	changes, err := store.(*stateDBWorkingSetStore).stateChanges()
+	require.NoError(err)
+	require.Equal(expected, changes)
+
+	// the subscriber receives the changes on commit
+	ws := newWorkingSet(5, store)
+	subscriber := &testStateChangeSubscriber{}
+	ws.stateChangeSubscriber = subscriber
+	require.NoError(ws.Commit(ctx))
+	require.Equal(uint64(5), subscriber.height)
+	require.Equal(expected, subscriber.changes)
+	value, err := kv.Get(ns, []byte("existing"))
+	require.NoError(err)
+	require.Equal([]byte("v2"), value)
+
+	// trie-based store does not support state changes
+	flusher, err = db.NewKVStoreFlusher(db.NewMemKVStore(), batch.NewCachedBatch())
+	require.NoError(err)
+	store, err = newFactoryWorkingSetStore(protocol.View{}, flusher)
+	require.NoError(err)
+	require.NoError(store.Start(ctx))
+	ws = newWorkingSet(1, store)
+	ws.stateChangeSubscriber = subscriber
+	_, err = ws.stateChanges()
+	require.ErrorIs(err, ErrNotSupported)
+}
@@ -47,6 +47,8 @@ type stateDB struct {
 	protocolView             protocol.View
 	skipBlockValidationOnPut bool
 	ps                       *patchStore
+	prefetcher               *statePrefetcher
+	stateChangeSubscriber    StateChangeSubscriber
 }
 
 // StateDBOption sets stateDB construction parameter
@@ -110,6 +112,9 @@ func NewStateDB(cfg Config, dao db.KVStore, opts ...StateDBOption) (Factory, err
 		log.L().Error("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	sdb.timerFactory = timerFactory
+	sdb.prefetcher = newStatePrefetcher(func() (db.KVStoreBasic, error) {
+		return sdb.dao, nil
+	}, &sdb.mutex, cfg.Chain.StatePrefetchWorkers)
 	return &sdb, nil
 }
 
@@ -157,6 +162,7 @@ func (sdb *stateDB) Start(ctx context.Context) error {
 }
 
 func (sdb *stateDB) Stop(ctx context.Context) error {
+	sdb.prefetcher.Cancel()
 	sdb.mutex.Lock()
 	defer sdb.mutex.Unlock()
 	sdb.workingsets.Clear()
@@ -196,13 +202,22 @@ func (sdb *stateDB) newWorkingSet(ctx context.Context, height uint64) (*workingS
 		return nil, err
 	}
 
-	return newWorkingSet(height, store), nil
+	ws := newWorkingSet(height, store)
+	ws.parallelExecutionWorkers = sdb.cfg.Chain.ParallelExecutionWorkers
+	ws.stateChangeSubscriber = sdb.stateChangeSubscriber
+	ws.rollbackRetention = sdb.cfg.Chain.RollbackRetention
+	return ws, nil
 }
 
 func (sdb *stateDB) Register(p protocol.Protocol) error {
 	return p.Register(sdb.registry)
 }
 
+// Prefetch starts loading the states the block is going to read in background
+func (sdb *stateDB) Prefetch(ctx context.Context, blk *block.Block) {
+	sdb.prefetcher.Prefetch(ctx, blk.Actions)
+}
+
 func (sdb *stateDB) Validate(ctx context.Context, blk *block.Block) error {
 	ctx = protocol.WithRegistry(ctx, sdb.registry)
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
@@ -282,6 +297,18 @@ func (sdb *stateDB) SimulateExecution(
 	return evm.SimulateExecution(ctx, ws, caller, ex)
 }
 
+// NewSimulator creates a simulator of executions on a working set of the tip state
+func (sdb *stateDB) NewSimulator(ctx context.Context) (*evm.Simulator, error) {
+	sdb.mutex.RLock()
+	currHeight := sdb.currentChainHeight
+	sdb.mutex.RUnlock()
+	ws, err := sdb.newWorkingSet(ctx, currHeight+1)
+	if err != nil {
+		return nil, err
+	}
+	return evm.NewSimulator(ws), nil
+}
+
 // ReadContractStorage reads contract's storage
 func (sdb *stateDB) ReadContractStorage(ctx context.Context, contract address.Address, key []byte) ([]byte, error) {
 	sdb.mutex.RLock()
@@ -296,6 +323,8 @@ func (sdb *stateDB) ReadContractStorage(ctx context.Context, contract address.Ad
 
 // PutBlock persists all changes in RunActions() into the DB
 func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
+	// must be cancelled before acquiring the lock, which the prefetcher waits for
+	sdb.prefetcher.Cancel()
 	sdb.mutex.Lock()
 	timer := sdb.timerFactory.NewTimer("Commit")
 	sdb.mutex.Unlock()
@@ -354,8 +383,48 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
-func (sdb *stateDB) DeleteTipBlock(_ context.Context, _ *block.Block) error {
-	return errors.Wrap(ErrNotSupported, "cannot delete tip block from state db")
+// DeleteTipBlock reverts the states to the height before the tip block, with the undo log kept
+// when the block was committed
+func (sdb *stateDB) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
+	sdb.prefetcher.Cancel()
+	if err := sdb.revertTipBlock(blk.Height()); err != nil {
+		return err
+	}
+	if sdb.stateChangeSubscriber != nil {
+		if err := sdb.stateChangeSubscriber.DeleteTipBlock(ctx, blk); err != nil {
+			return errors.Wrapf(err, "failed to delete state changes of block %d", blk.Height())
+		}
+	}
+	// reload the protocol views at the new tip
+	view, err := sdb.registry.StartAll(protocol.WithRegistry(ctx, sdb.registry), sdb)
+	if err != nil {
+		return errors.Wrapf(err, "failed to reload protocols at height %d", blk.Height()-1)
+	}
+	sdb.mutex.Lock()
+	sdb.protocolView = view
+	sdb.mutex.Unlock()
+	return nil
+}
+
+func (sdb *stateDB) revertTipBlock(height uint64) error {
+	sdb.mutex.Lock()
+	defer sdb.mutex.Unlock()
+	if height != sdb.currentChainHeight {
+		return errors.Errorf("cannot delete block %d, current state height is %d", height, sdb.currentChainHeight)
+	}
+	if height == 0 {
+		return errors.New("cannot delete genesis block")
+	}
+	b, err := db.UndoBatch(sdb.dao, height)
+	if err != nil {
+		return errors.Wrapf(err, "failed to revert states of block %d", height)
+	}
+	if err := sdb.dao.WriteBatch(b); err != nil {
+		return errors.Wrapf(err, "failed to revert states of block %d", height)
+	}
+	sdb.currentChainHeight = height - 1
+	sdb.workingsets.Clear()
+	return nil
 }
 
 // State returns a confirmed state in the state factory
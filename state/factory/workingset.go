@@ -53,6 +53,14 @@ type (
 		finalized bool
 		dock      protocol.Dock
 		receipts  []*action.Receipt
+		// viewVersion increases whenever the protocol views or the dock are modified
+		viewVersion uint64
+		// parallelExecutionWorkers is the number of workers to run actions in parallel, 0 means sequential
+		parallelExecutionWorkers int
+		// stateChangeSubscriber receives the state changes when the workingset is committed
+		stateChangeSubscriber StateChangeSubscriber
+		// rollbackRetention is the number of recent blocks which could be rolled back, 0 means disabled
+		rollbackRetention uint64
 	}
 )
 
@@ -102,18 +110,29 @@ func (ws *workingSet) runActions(
 	ctx context.Context,
 	elps []*action.SealedEnvelope,
 ) ([]*action.Receipt, error) {
+	var (
+		receipts []*action.Receipt
+		err      error
+	)
 	// Handle actions
-	receipts := make([]*action.Receipt, 0)
-	for _, elp := range elps {
-		ctxWithActionContext, err := withActionCtx(ctx, elp)
+	if ws.parallelExecutionWorkers > 0 && len(elps) > 1 {
+		receipts, err = ws.runActionsInParallel(ctx, elps)
 		if err != nil {
 			return nil, err
 		}
-		receipt, err := ws.runAction(ctxWithActionContext, elp)
-		if err != nil {
-			return nil, errors.Wrap(err, "error when run action")
+	} else {
+		receipts = make([]*action.Receipt, 0)
+		for _, elp := range elps {
+			ctxWithActionContext, err := withActionCtx(ctx, elp)
+			if err != nil {
+				return nil, err
+			}
+			receipt, err := ws.runAction(ctxWithActionContext, elp)
+			if err != nil {
+				return nil, errors.Wrap(err, "error when run action")
+			}
+			receipts = append(receipts, receipt)
 		}
-		receipts = append(receipts, receipt)
 	}
 	if protocol.MustGetFeatureCtx(ctx).CorrectTxLogIndex {
 		updateReceiptIndex(receipts)
@@ -281,6 +300,13 @@ func (ws *workingSet) Commit(ctx context.Context) error {
 	if err := protocolPreCommit(ctx, ws); err != nil {
 		return err
 	}
+	changes, err := ws.stateChanges()
+	if err != nil {
+		return err
+	}
+	if err := ws.putUndoLog(); err != nil {
+		return err
+	}
 	if err := ws.store.Commit(); err != nil {
 		return err
 	}
@@ -288,10 +314,41 @@ func (ws *workingSet) Commit(ctx context.Context) error {
 		// TODO (zhi): wrap the error and eventually panic it in caller side
 		return err
 	}
+	if ws.stateChangeSubscriber != nil {
+		// the states are committed already, so the error is not returned. The subscriber
+		// records the block as missing when it receives the changes of the next block
+		if err := ws.stateChangeSubscriber.ReceiveStateChanges(ws.height, changes); err != nil {
+			log.L().Error("Failed to handle state changes.", zap.Uint64("height", ws.height), zap.Error(err))
+		}
+	}
 	ws.Reset()
 	return nil
 }
 
+// stateChanges returns the state changes to be committed, if there is a subscriber
+func (ws *workingSet) stateChanges() ([]*StateChange, error) {
+	if ws.stateChangeSubscriber == nil {
+		return nil, nil
+	}
+	store, ok := ws.store.(*stateDBWorkingSetStore)
+	if !ok {
+		return nil, errors.Wrapf(ErrNotSupported, "cannot get state changes from %T", ws.store)
+	}
+	return store.stateChanges()
+}
+
+// putUndoLog puts the undo log of the workingset into the store, if rollback is enabled
+func (ws *workingSet) putUndoLog() error {
+	if ws.rollbackRetention == 0 {
+		return nil
+	}
+	store, ok := ws.store.(*stateDBWorkingSetStore)
+	if !ok {
+		return errors.Wrapf(ErrNotSupported, "cannot put undo log into %T", ws.store)
+	}
+	return store.putUndoLog(ws.height, ws.rollbackRetention)
+}
+
 // State pulls a state from DB
 func (ws *workingSet) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
 	_stateDBMtc.WithLabelValues("get").Inc()
@@ -352,6 +409,7 @@ func (ws *workingSet) ReadView(name string) (interface{}, error) {
 
 // WriteView writeback the view to factory
 func (ws *workingSet) WriteView(name string, v interface{}) error {
+	ws.viewVersion++
 	return ws.store.WriteView(name, v)
 }
 
@@ -360,6 +418,7 @@ func (ws *workingSet) ProtocolDirty(name string) bool {
 }
 
 func (ws *workingSet) Load(name, key string, v interface{}) error {
+	ws.viewVersion++
 	return ws.dock.Load(name, key, v)
 }
 
@@ -368,6 +427,7 @@ func (ws *workingSet) Unload(name, key string, v interface{}) error {
 }
 
 func (ws *workingSet) Reset() {
+	ws.viewVersion++
 	ws.dock.Reset()
 }
 
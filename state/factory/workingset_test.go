@@ -7,22 +7,29 @@ package factory
 
 import (
 	"context"
+	"encoding/hex"
+	"math"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -409,3 +416,167 @@ func makeBlock(t *testing.T, prevHash hash.Hash256, receiptRoot hash.Hash256, di
 	require.NoError(t, err)
 	return &blk
 }
+
+func TestWorkingSet_ParallelExecution(t *testing.T) {
+	require := require.New(t)
+	g := genesis.TestDefault()
+	g.Staking.BootstrapCandidates = []genesis.BootstrapCandidate{
+		{
+			OwnerAddress:      identityset.Address(1).String(),
+			OperatorAddress:   identityset.Address(1).String(),
+			RewardAddress:     identityset.Address(1).String(),
+			Name:              "test1",
+			SelfStakingTokens: unit.ConvertIotxToRau(1200000).String(),
+		},
+	}
+	registry := protocol.NewRegistry()
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	require.NoError(rewarding.NewProtocol(g.Rewarding).Register(registry))
+	require.NoError(execution.NewProtocol(
+		func(uint64) (hash.Hash256, error) { return hash.ZeroHash256, nil },
+		rewarding.DepositGasWithSGD,
+		nil,
+		func(uint64) (time.Time, error) { return time.Time{}, nil },
+	).Register(registry))
+	sp, err := staking.NewProtocol(staking.HelperCtx{
+		DepositGas:    rewarding.DepositGas,
+		BlockInterval: func(uint64) time.Duration { return 5 * time.Second },
+	}, &staking.BuilderConfig{
+		Staking:                  g.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+	}, nil, nil, nil, g.GreenlandBlockHeight)
+	require.NoError(err)
+	require.NoError(sp.Register(registry))
+
+	newFactories := func(workers int) []workingSetCreator {
+		cfg := Config{
+			Chain:   blockchain.DefaultConfig,
+			Genesis: g,
+		}
+		cfg.Chain.ParallelExecutionWorkers = workers
+		cfg.Genesis.InitBalanceMap = make(map[string]string)
+		for k, v := range g.InitBalanceMap {
+			cfg.Genesis.InitBalanceMap[k] = v
+		}
+		for i := 20; i < 29; i++ {
+			cfg.Genesis.InitBalanceMap[identityset.Address(i).String()] = "100000000000000000000"
+		}
+		ctx := protocol.WithBlockCtx(
+			genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), registry), cfg.Genesis),
+			protocol.BlockCtx{},
+		)
+		ctx = protocol.WithFeatureWithHeightCtx(protocol.WithFeatureCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{ChainID: 1})))
+		// staking reads the candidates with Filter(), which the in-memory KVStore does not support
+		newKVStore := func() db.KVStore {
+			path, err := testutil.PathOfTempFile(_stateDBPath)
+			require.NoError(err)
+			kv, err := db.CreateKVStore(db.DefaultConfig, path)
+			require.NoError(err)
+			t.Cleanup(func() { testutil.CleanupPath(path) })
+			return kv
+		}
+		f1, err := NewFactory(cfg, newKVStore(), RegistryOption(registry))
+		require.NoError(err)
+		f2, err := NewStateDB(cfg, newKVStore(), RegistryStateDBOption(registry))
+		require.NoError(err)
+		require.NoError(f1.Start(ctx))
+		require.NoError(f2.Start(ctx))
+		t.Cleanup(func() {
+			require.NoError(f1.Stop(ctx))
+			require.NoError(f2.Stop(ctx))
+		})
+		return []workingSetCreator{f1.(workingSetCreator), f2.(workingSetCreator)}
+	}
+
+	ctx := protocol.WithRegistry(context.Background(), registry)
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: uint64(1),
+		Producer:    identityset.Address(27),
+		GasLimit:    testutil.TestGasLimit * 100000,
+	})
+	ctx = genesis.WithGenesisContext(ctx, g)
+	ctx = protocol.WithFeatureWithHeightCtx(protocol.WithFeatureCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{
+		ChainID: 1,
+	})))
+	// a simple storage contract
+	data, err := hex.DecodeString("608060405234801561001057600080fd5b5060df8061001f6000396000f3006080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806360fe47b114604e5780636d4ce63c146078575b600080fd5b348015605957600080fd5b5060766004803603810190808035906020019092919050505060a0565b005b348015608357600080fd5b50608a60aa565b6040518082815260200191505060405180910390f35b8060008190555050565b600080549050905600a165627a7a7230582002faabbefbbda99b20217cf33cb8ab8100caf1542bf1f48117d72e2c59139aea0029")
+	require.NoError(err)
+	deploy, err := action.SignedExecution(action.EmptyAddress, identityset.PrivateKey(10), 1, big.NewInt(0), 1000000, big.NewInt(1), data, action.WithChainID(1))
+	require.NoError(err)
+	stake, err := action.SignedCreateStake(1, "test1", unit.ConvertIotxToRau(100).String(), 0, false, nil, 100000, big.NewInt(1), identityset.PrivateKey(11), action.WithChainID(1))
+	require.NoError(err)
+	actions := []*action.SealedEnvelope{
+		// independent transfers
+		makeTransferActionFrom(t, 20, 1, 1),
+		makeTransferActionFrom(t, 21, 1, 2),
+		// dependent transfers
+		makeTransferActionFrom(t, 22, 1, 23),
+		makeTransferActionFrom(t, 23, 1, 24),
+		makeTransferActionFrom(t, 22, 2, 25),
+		// transfer to a fresh account then out of it
+		makeTransferActionFrom(t, 24, 1, 26),
+		makeTransferActionFrom(t, 26, 1, 3),
+		// contract deployment and staking, both deposit gas before writing the rest of the states
+		deploy,
+		stake,
+	}
+	sequential, parallel := newFactories(0), newFactories(4)
+	for i := range sequential {
+		ws1, err := sequential[i].newWorkingSet(ctx, 1)
+		require.NoError(err)
+		require.NoError(ws1.Process(ctx, actions))
+		ws2, err := parallel[i].newWorkingSet(ctx, 1)
+		require.NoError(err)
+		require.Equal(4, ws2.parallelExecutionWorkers)
+		hit := promtestutil.ToFloat64(_parallelExecutionMtc.WithLabelValues("hit"))
+		conflict := promtestutil.ToFloat64(_parallelExecutionMtc.WithLabelValues("conflict"))
+		abort := promtestutil.ToFloat64(_parallelExecutionMtc.WithLabelValues("abort"))
+		require.NoError(ws2.Process(ctx, actions))
+		// the independent transfers and the deployment are not re-executed, although all of them deposit
+		// gas into the rewarding fund, the 2nd transfer of 22 fails on nonce during the speculation, and
+		// the staking action aborts on writing the staking view
+		require.Equal(float64(4), promtestutil.ToFloat64(_parallelExecutionMtc.WithLabelValues("hit"))-hit)
+		require.Equal(float64(3), promtestutil.ToFloat64(_parallelExecutionMtc.WithLabelValues("conflict"))-conflict)
+		require.Equal(float64(2), promtestutil.ToFloat64(_parallelExecutionMtc.WithLabelValues("abort"))-abort)
+
+		digest1, err := ws1.digest()
+		require.NoError(err)
+		digest2, err := ws2.digest()
+		require.NoError(err)
+		require.Equal(digest1, digest2)
+		receipts1, err := ws1.Receipts()
+		require.NoError(err)
+		receipts2, err := ws2.Receipts()
+		require.NoError(err)
+		require.Equal(len(actions), len(receipts2))
+		require.Equal(calculateReceiptRoot(receipts1), calculateReceiptRoot(receipts2))
+		for j := range receipts1 {
+			require.Equal(uint64(iotextypes.ReceiptStatus_Success), receipts1[j].Status)
+			require.Equal(receipts1[j].Hash(), receipts2[j].Hash())
+		}
+	}
+}
+
+func makeTransferActionFrom(t *testing.T, signer int, nonce uint64, recipient int) *action.SealedEnvelope {
+	tsf, err := action.NewTransfer(
+		nonce,
+		big.NewInt(1),
+		identityset.Address(recipient).String(),
+		nil,
+		testutil.TestGasLimit,
+		big.NewInt(1),
+	)
+	require.NoError(t, err)
+	eb := action.EnvelopeBuilder{}
+	evlp := eb.
+		SetAction(tsf).
+		SetGasLimit(tsf.GasLimit()).
+		SetGasPrice(tsf.GasPrice()).
+		SetNonce(nonce).
+		SetChainID(1).
+		SetVersion(1).
+		Build()
+	sevlp, err := action.Sign(evlp, identityset.PrivateKey(signer))
+	require.NoError(t, err)
+	return sevlp
+}
@@ -145,6 +145,21 @@ func (store *stateDBWorkingSetStore) ResetSnapshots() {
 	store.flusher.KVStoreWithBuffer().ResetSnapshots()
 }
 
+// putUndoLog puts the undo log of the writes in buffer into the buffer itself, and deletes the undo log
+// which is older than the retention
+func (store *stateDBWorkingSetStore) putUndoLog(height, retention uint64) error {
+	kvb := store.flusher.KVStoreWithBuffer()
+	undoLog, err := db.NewUndoLog(store.flusher.BaseKVStore(), kvb)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create undo log at height %d", height)
+	}
+	kvb.MustPut(db.UndoLogNamespace, db.UndoLogKey(height), undoLog)
+	if height > retention {
+		kvb.MustDelete(db.UndoLogNamespace, db.UndoLogKey(height-retention))
+	}
+	return nil
+}
+
 func (store *factoryWorkingSetStore) Start(ctx context.Context) error {
 	return store.tlt.Start(ctx)
 }
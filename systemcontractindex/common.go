@@ -1,6 +1,7 @@
 package systemcontractindex
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/pkg/errors"
@@ -22,17 +23,46 @@ type IndexerCommon struct {
 	startHeight     uint64
 	height          uint64
 	contractAddress string
+	// rollbackRetention is the number of recent blocks which could be reverted, 0 means disabled
+	rollbackRetention uint64
+	// history keeps the index data at earlier heights, nil means disabled
+	history *db.VersionedHistory
+}
+
+// IndexerCommonOption is the option to create IndexerCommon
+type IndexerCommonOption func(*IndexerCommon)
+
+// WithRollbackRetention sets the number of recent blocks which could be reverted
+func WithRollbackRetention(retention uint64) IndexerCommonOption {
+	return func(s *IndexerCommon) {
+		s.rollbackRetention = retention
+	}
+}
+
+// WithHistory keeps the history of the namespaces in the versioned store, the kvstore should be the
+// base of the versioned store
+func WithHistory(kv db.KvVersioned, namespaces ...string) IndexerCommonOption {
+	return func(s *IndexerCommon) {
+		s.history = db.NewVersionedHistory(kv, func(ns string, key []byte) bool {
+			// the height is the version of history
+			return ns != s.ns || !bytes.Equal(key, s.key)
+		}, namespaces...)
+	}
 }
 
 // NewIndexerCommon creates a new IndexerCommon
-func NewIndexerCommon(kvstore db.KVStore, ns string, key []byte, contractAddress string, startHeight uint64) *IndexerCommon {
-	return &IndexerCommon{
+func NewIndexerCommon(kvstore db.KVStore, ns string, key []byte, contractAddress string, startHeight uint64, opts ...IndexerCommonOption) *IndexerCommon {
+	s := &IndexerCommon{
 		kvstore:         kvstore,
 		ns:              ns,
 		key:             key,
 		startHeight:     startHeight,
 		contractAddress: contractAddress,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Start starts the indexer
@@ -45,6 +75,9 @@ func (s *IndexerCommon) Start(ctx context.Context) error {
 		return err
 	}
 	s.height = h
+	if s.history != nil {
+		return s.history.Sync(h)
+	}
 	return nil
 }
 
@@ -56,6 +89,17 @@ func (s *IndexerCommon) Stop(ctx context.Context) error {
 // KVStore returns the kvstore
 func (s *IndexerCommon) KVStore() db.KVStore { return s.kvstore }
 
+// HistoryEnabled returns true if the history of index data is kept
+func (s *IndexerCommon) HistoryEnabled() bool { return s.history != nil }
+
+// HistoryAt returns a KVStore to read the index data at the height from history
+func (s *IndexerCommon) HistoryAt(height uint64) (db.KVStore, error) {
+	if s.history == nil {
+		return nil, errors.Wrapf(db.ErrHistoryNotAvailable, "height %d", height)
+	}
+	return s.history.At(height)
+}
+
 // ContractAddress returns the contract address
 func (s *IndexerCommon) ContractAddress() string { return s.contractAddress }
 
@@ -85,13 +129,47 @@ func (s *IndexerCommon) StartHeight() uint64 { return s.startHeight }
 // Commit commits the height to the indexer
 func (s *IndexerCommon) Commit(height uint64, delta batch.KVStoreBatch) error {
 	delta.Put(s.ns, s.key, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
-	if err := s.kvstore.WriteBatch(delta); err != nil {
+	if err := db.PutUndoLog(s.kvstore, delta, height, s.rollbackRetention); err != nil {
+		return err
+	}
+	var err error
+	if s.history != nil {
+		err = s.history.Commit(height, delta)
+	} else {
+		err = s.kvstore.WriteBatch(delta)
+	}
+	if err != nil {
 		return err
 	}
 	s.height = height
 	return nil
 }
 
+// Revert reverts the changes committed at the tip height
+func (s *IndexerCommon) Revert(height uint64) error {
+	if height != s.height {
+		return errors.Errorf("invalid block height %d, expect tip %d", height, s.height)
+	}
+	b, err := db.UndoBatch(s.kvstore, height)
+	if err != nil {
+		return err
+	}
+	if s.history != nil {
+		err = s.history.Revert(height, b)
+	} else {
+		err = s.kvstore.WriteBatch(b)
+	}
+	if err != nil {
+		return err
+	}
+	h, err := s.loadHeight()
+	if err != nil {
+		return err
+	}
+	s.height = h
+	return nil
+}
+
 // ExpectedHeight returns the expected height
 func (s *IndexerCommon) ExpectedHeight() uint64 {
 	if s.height < s.startHeight {
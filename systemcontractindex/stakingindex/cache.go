@@ -40,7 +40,7 @@ func (s *cache) Load(kvstore db.KVStore) error {
 
 	// load buckets
 	ks, vs, err := kvstore.Filter(stakingBucketNS, func(k, v []byte) bool { return true }, nil, nil)
-	if err != nil && !errors.Is(err, db.ErrBucketNotExist) {
+	if err != nil && !errors.Is(err, db.ErrBucketNotExist) && !errors.Is(err, db.ErrNotExist) {
 		return err
 	}
 	for i := range vs {
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	lru "github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
@@ -20,6 +21,8 @@ import (
 const (
 	stakingNS       = "sns"
 	stakingBucketNS = "sbn"
+	// _historyCacheSize is the number of heights whose buckets loaded from history are cached
+	_historyCacheSize = 32
 )
 
 var (
@@ -46,16 +49,51 @@ type (
 	Indexer struct {
 		common        *systemcontractindex.IndexerCommon
 		cache         *cache // in-memory cache, used to query index data
+		// historyCache caches the buckets loaded from history, keyed by height
+		historyCache lru.LRUCache
 		mutex         sync.RWMutex
 		blockInterval time.Duration
 	}
+	// IndexerOption is the option to create the staking indexer
+	IndexerOption func(*indexerConfig)
+
+	indexerConfig struct {
+		rollbackRetention uint64
+		history           db.KvVersioned
+	}
 )
 
+// WithRollbackRetention sets the number of recent blocks which could be deleted from the indexer
+func WithRollbackRetention(retention uint64) IndexerOption {
+	return func(cfg *indexerConfig) {
+		cfg.rollbackRetention = retention
+	}
+}
+
+// WithHistory keeps the history of buckets in the versioned store, so that the buckets at an
+// earlier height could be read. The kvstore of the indexer should be the base of the versioned store
+func WithHistory(kv db.KvVersioned) IndexerOption {
+	return func(cfg *indexerConfig) {
+		cfg.history = kv
+	}
+}
+
 // NewIndexer creates a new staking indexer
-func NewIndexer(kvstore db.KVStore, contractAddr string, startHeight uint64, blockInterval time.Duration) *Indexer {
+func NewIndexer(kvstore db.KVStore, contractAddr string, startHeight uint64, blockInterval time.Duration, opts ...IndexerOption) *Indexer {
+	cfg := indexerConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	commonOpts := []systemcontractindex.IndexerCommonOption{
+		systemcontractindex.WithRollbackRetention(cfg.rollbackRetention),
+	}
+	if cfg.history != nil {
+		commonOpts = append(commonOpts, systemcontractindex.WithHistory(cfg.history, stakingNS, stakingBucketNS))
+	}
 	return &Indexer{
-		common:        systemcontractindex.NewIndexerCommon(kvstore, stakingNS, stakingHeightKey, contractAddr, startHeight),
+		common:        systemcontractindex.NewIndexerCommon(kvstore, stakingNS, stakingHeightKey, contractAddr, startHeight, commonOpts...),
 		cache:         newCache(),
+		historyCache:  lru.NewThreadSafeLruCache(_historyCacheSize),
 		blockInterval: blockInterval,
 	}
 }
@@ -108,8 +146,12 @@ func (s *Indexer) Buckets(height uint64) ([]*VoteBucket, error) {
 	} else if unstart {
 		return nil, nil
 	}
-	idxs := s.cache.BucketIdxs()
-	bkts := s.cache.Buckets(idxs)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	idxs := cache.BucketIdxs()
+	bkts := cache.Buckets(idxs)
 	vbs := batchAssembleVoteBucket(idxs, bkts, s.common.ContractAddress(), s.blockInterval)
 	return vbs, nil
 }
@@ -124,7 +166,11 @@ func (s *Indexer) Bucket(id uint64, height uint64) (*VoteBucket, bool, error) {
 	} else if unstart {
 		return nil, false, nil
 	}
-	bkt := s.cache.Bucket(id)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, false, err
+	}
+	bkt := cache.Bucket(id)
 	if bkt == nil {
 		return nil, false, nil
 	}
@@ -142,7 +188,11 @@ func (s *Indexer) BucketsByIndices(indices []uint64, height uint64) ([]*VoteBuck
 	} else if unstart {
 		return nil, nil
 	}
-	bkts := s.cache.Buckets(indices)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	bkts := cache.Buckets(indices)
 	vbs := batchAssembleVoteBucket(indices, bkts, s.common.ContractAddress(), s.blockInterval)
 	return vbs, nil
 }
@@ -157,8 +207,12 @@ func (s *Indexer) BucketsByCandidate(candidate address.Address, height uint64) (
 	} else if unstart {
 		return nil, nil
 	}
-	idxs := s.cache.BucketIdsByCandidate(candidate)
-	bkts := s.cache.Buckets(idxs)
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return nil, err
+	}
+	idxs := cache.BucketIdsByCandidate(candidate)
+	bkts := cache.Buckets(idxs)
 	vbs := batchAssembleVoteBucket(idxs, bkts, s.common.ContractAddress(), s.blockInterval)
 	return vbs, nil
 }
@@ -173,7 +227,11 @@ func (s *Indexer) TotalBucketCount(height uint64) (uint64, error) {
 	} else if unstart {
 		return 0, nil
 	}
-	return s.cache.TotalBucketCount(), nil
+	cache, err := s.cacheAt(height)
+	if err != nil {
+		return 0, err
+	}
+	return cache.TotalBucketCount(), nil
 }
 
 // PutBlock puts a block into indexer
@@ -208,8 +266,20 @@ func (s *Indexer) PutBlock(ctx context.Context, blk *block.Block) error {
 }
 
 // DeleteTipBlock deletes the tip block from indexer
-func (s *Indexer) DeleteTipBlock(context.Context, *block.Block) error {
-	return errors.New("not implemented")
+func (s *Indexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.common.Revert(blk.Height()); err != nil {
+		return errors.Wrapf(err, "failed to delete block %d from staking indexer", blk.Height())
+	}
+	// reload cache
+	cache := newCache()
+	if err := cache.Load(s.common.KVStore()); err != nil {
+		return err
+	}
+	s.cache = cache
+	s.historyCache.Clear()
+	return nil
 }
 
 func (s *Indexer) commit(handler *eventHandler, height uint64) error {
@@ -237,3 +307,24 @@ func (s *Indexer) checkHeight(height uint64) (unstart bool, err error) {
 	}
 	return false, nil
 }
+
+// cacheAt returns the cache of buckets at the height, which is loaded from the history if the
+// height is earlier than the tip. Without history, the tip cache is returned
+func (s *Indexer) cacheAt(height uint64) (*cache, error) {
+	if !s.common.HistoryEnabled() || height == 0 || height >= s.common.Height() {
+		return s.cache, nil
+	}
+	if c, ok := s.historyCache.Get(height); ok {
+		return c.(*cache), nil
+	}
+	kv, err := s.common.HistoryAt(height)
+	if err != nil {
+		return nil, err
+	}
+	c := newCache()
+	if err := c.Load(kv); err != nil {
+		return nil, errors.Wrapf(err, "failed to load buckets at height %d", height)
+	}
+	s.historyCache.Add(height, c)
+	return c, nil
+}
@@ -12,6 +12,7 @@ import (
 	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
+	actpool "github.com/iotexproject/iotex-core/actpool"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 )
 
@@ -68,6 +69,18 @@ func (mr *MockActPoolMockRecorder) AddActionEnvelopeValidators(arg0 ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActionEnvelopeValidators", reflect.TypeOf((*MockActPool)(nil).AddActionEnvelopeValidators), arg0...)
 }
 
+// AddSubscriber mocks base method.
+func (m *MockActPool) AddSubscriber(arg0 actpool.Subscriber) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSubscriber", arg0)
+}
+
+// AddSubscriber indicates an expected call of AddSubscriber.
+func (mr *MockActPoolMockRecorder) AddSubscriber(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockActPool)(nil).AddSubscriber), arg0)
+}
+
 // DeleteAction mocks base method.
 func (m *MockActPool) DeleteAction(arg0 address.Address) {
 	m.ctrl.T.Helper()
@@ -233,3 +246,38 @@ func (mr *MockActPoolMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockActPool)(nil).Validate), arg0, arg1)
 }
+
+// MockSubscriber is a mock of Subscriber interface.
+type MockSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberMockRecorder
+}
+
+// MockSubscriberMockRecorder is the mock recorder for MockSubscriber.
+type MockSubscriberMockRecorder struct {
+	mock *MockSubscriber
+}
+
+// NewMockSubscriber creates a new mock instance.
+func NewMockSubscriber(ctrl *gomock.Controller) *MockSubscriber {
+	mock := &MockSubscriber{ctrl: ctrl}
+	mock.recorder = &MockSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriber) EXPECT() *MockSubscriberMockRecorder {
+	return m.recorder
+}
+
+// OnAdded mocks base method.
+func (m *MockSubscriber) OnAdded(arg0 *action.SealedEnvelope) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnAdded", arg0)
+}
+
+// OnAdded indicates an expected call of OnAdded.
+func (mr *MockSubscriberMockRecorder) OnAdded(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnAdded", reflect.TypeOf((*MockSubscriber)(nil).OnAdded), arg0)
+}
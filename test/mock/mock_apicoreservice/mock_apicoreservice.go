@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	types "github.com/ethereum/go-ethereum/core/types"
 	tracers "github.com/ethereum/go-ethereum/eth/tracers"
 	gomock "github.com/golang/mock/gomock"
 	hash "github.com/iotexproject/go-pkgs/hash"
@@ -19,6 +20,8 @@ import (
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 	genesis "github.com/iotexproject/iotex-core/blockchain/genesis"
+	blockindex "github.com/iotexproject/iotex-core/blockindex"
+	factory "github.com/iotexproject/iotex-core/state/factory"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -243,6 +246,22 @@ func (mr *MockCoreServiceMockRecorder) ChainMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainMeta", reflect.TypeOf((*MockCoreService)(nil).ChainMeta))
 }
 
+// CreateAccessList mocks base method.
+func (m *MockCoreService) CreateAccessList(ctx context.Context, callerAddr address.Address, exec *action.Execution) (types.AccessList, *action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessList", ctx, callerAddr, exec)
+	ret0, _ := ret[0].(types.AccessList)
+	ret1, _ := ret[1].(*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAccessList indicates an expected call of CreateAccessList.
+func (mr *MockCoreServiceMockRecorder) CreateAccessList(ctx, callerAddr, exec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessList", reflect.TypeOf((*MockCoreService)(nil).CreateAccessList), ctx, callerAddr, exec)
+}
+
 // EVMNetworkID mocks base method.
 func (m *MockCoreService) EVMNetworkID() uint32 {
 	m.ctrl.T.Helper()
@@ -409,6 +428,21 @@ func (mr *MockCoreServiceMockRecorder) PendingActionByActionHash(h interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingActionByActionHash", reflect.TypeOf((*MockCoreService)(nil).PendingActionByActionHash), h)
 }
 
+// PendingActionHashes mocks base method.
+func (m *MockCoreService) PendingActionHashes(since uint64) ([]hash.Hash256, uint64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingActionHashes", since)
+	ret0, _ := ret[0].([]hash.Hash256)
+	ret1, _ := ret[1].(uint64)
+	return ret0, ret1
+}
+
+// PendingActionHashes indicates an expected call of PendingActionHashes.
+func (mr *MockCoreServiceMockRecorder) PendingActionHashes(since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingActionHashes", reflect.TypeOf((*MockCoreService)(nil).PendingActionHashes), since)
+}
+
 // PendingNonce mocks base method.
 func (m *MockCoreService) PendingNonce(arg0 address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -514,6 +548,18 @@ func (mr *MockCoreServiceMockRecorder) ReceiveBlock(blk interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveBlock", reflect.TypeOf((*MockCoreService)(nil).ReceiveBlock), blk)
 }
 
+// ReceivePendingAction mocks base method.
+func (m *MockCoreService) ReceivePendingAction(selp *action.SealedEnvelope) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReceivePendingAction", selp)
+}
+
+// ReceivePendingAction indicates an expected call of ReceivePendingAction.
+func (mr *MockCoreServiceMockRecorder) ReceivePendingAction(selp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePendingAction", reflect.TypeOf((*MockCoreService)(nil).ReceivePendingAction), selp)
+}
+
 // SendAction mocks base method.
 func (m *MockCoreService) SendAction(ctx context.Context, in *iotextypes.Action) (string, error) {
 	m.ctrl.T.Helper()
@@ -563,6 +609,37 @@ func (mr *MockCoreServiceMockRecorder) SimulateExecution(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecution", reflect.TypeOf((*MockCoreService)(nil).SimulateExecution), arg0, arg1, arg2)
 }
 
+// SimulateExecutions mocks base method.
+func (m *MockCoreService) SimulateExecutions(arg0 context.Context, arg1 []*apitypes.SimulationCall, arg2 *tracers.TraceConfig) ([]*apitypes.SimulationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateExecutions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*apitypes.SimulationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateExecutions indicates an expected call of SimulateExecutions.
+func (mr *MockCoreServiceMockRecorder) SimulateExecutions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecutions", reflect.TypeOf((*MockCoreService)(nil).SimulateExecutions), arg0, arg1, arg2)
+}
+
+// StakingHistory mocks base method.
+func (m *MockCoreService) StakingHistory(owner address.Address, offset, limit uint64) ([]*blockindex.StakingEvent, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StakingHistory", owner, offset, limit)
+	ret0, _ := ret[0].([]*blockindex.StakingEvent)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StakingHistory indicates an expected call of StakingHistory.
+func (mr *MockCoreServiceMockRecorder) StakingHistory(owner, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StakingHistory", reflect.TypeOf((*MockCoreService)(nil).StakingHistory), owner, offset, limit)
+}
+
 // Start mocks base method.
 func (m *MockCoreService) Start(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -577,6 +654,21 @@ func (mr *MockCoreServiceMockRecorder) Start(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockCoreService)(nil).Start), ctx)
 }
 
+// StateDiff mocks base method.
+func (m *MockCoreService) StateDiff(height uint64) ([]*factory.StateChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateDiff", height)
+	ret0, _ := ret[0].([]*factory.StateChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateDiff indicates an expected call of StateDiff.
+func (mr *MockCoreServiceMockRecorder) StateDiff(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDiff", reflect.TypeOf((*MockCoreService)(nil).StateDiff), height)
+}
+
 // Stop mocks base method.
 func (m *MockCoreService) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@ import (
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
 	protocol "github.com/iotexproject/iotex-core/action/protocol"
+	evm "github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	actpool "github.com/iotexproject/iotex-core/actpool"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 	state "github.com/iotexproject/iotex-core/state"
@@ -84,6 +85,21 @@ func (mr *MockFactoryMockRecorder) NewBlockBuilder(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBlockBuilder", reflect.TypeOf((*MockFactory)(nil).NewBlockBuilder), arg0, arg1, arg2)
 }
 
+// NewSimulator mocks base method.
+func (m *MockFactory) NewSimulator(arg0 context.Context) (*evm.Simulator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSimulator", arg0)
+	ret0, _ := ret[0].(*evm.Simulator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewSimulator indicates an expected call of NewSimulator.
+func (mr *MockFactoryMockRecorder) NewSimulator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSimulator", reflect.TypeOf((*MockFactory)(nil).NewSimulator), arg0)
+}
+
 // PutBlock mocks base method.
 func (m *MockFactory) PutBlock(arg0 context.Context, arg1 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+const (
+	// _compactTxMaxSize is the max size of a single transaction when copying data into the new file
+	_compactTxMaxSize = 64 * 1024 * 1024
+	_compactFileMode  = 0600
+)
+
+// Multi-language support
+var (
+	compactDbCmdShorts = map[string]string{
+		"english": "Sub-Command for compaction of IoTeX bolt db file.",
+		"chinese": "压缩IoTeX bolt db 文件的子命令",
+	}
+	compactDbCmdLongs = map[string]string{
+		"english": "Sub-Command for compaction of IoTeX bolt db file (e.g. trie.db, index.db) into a fresh file, reclaiming free pages.",
+		"chinese": "将IoTeX bolt db 文件（如 trie.db, index.db）压缩到一个新文件并回收空闲页的子命令",
+	}
+	compactDbCmdUse = map[string]string{
+		"english": "compact",
+		"chinese": "compact",
+	}
+	compactDbFlagSrcFileUse = map[string]string{
+		"english": "The bolt db file you want to compact.",
+		"chinese": "您要压缩的 bolt db 文件。",
+	}
+	compactDbFlagDstFileUse = map[string]string{
+		"english": "The path of the compacted file, must not exist yet.",
+		"chinese": "压缩后文件的路径，该文件不能已经存在。",
+	}
+)
+
+var (
+	// CompactDb Used to Sub command.
+	CompactDb = &cobra.Command{
+		Use:   common.TranslateInLang(compactDbCmdUse),
+		Short: common.TranslateInLang(compactDbCmdShorts),
+		Long:  common.TranslateInLang(compactDbCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return compactDbFile()
+		},
+	}
+)
+
+var (
+	srcFile = ""
+	dstFile = ""
+)
+
+func init() {
+	CompactDb.PersistentFlags().StringVarP(&srcFile, "src-file", "s", "", common.TranslateInLang(compactDbFlagSrcFileUse))
+	CompactDb.PersistentFlags().StringVarP(&dstFile, "dst-file", "d", "", common.TranslateInLang(compactDbFlagDstFileUse))
+}
+
+func compactDbFile() (err error) {
+	// Check flags
+	if srcFile == "" {
+		return fmt.Errorf("--src-file is empty")
+	}
+	if dstFile == "" {
+		return fmt.Errorf("--dst-file is empty")
+	}
+	if srcFile == dstFile {
+		return fmt.Errorf("the values of --src-file --dst-file flags cannot be the same")
+	}
+	srcInfo, err := os.Stat(srcFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat %s", srcFile)
+	}
+	if _, err := os.Stat(dstFile); err == nil {
+		return fmt.Errorf("the file %s already exists", dstFile)
+	}
+
+	src, err := bolt.Open(srcFile, _compactFileMode, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", srcFile)
+	}
+	defer func() {
+		if e := src.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+	dst, err := bolt.Open(dstFile, _compactFileMode, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", dstFile)
+	}
+	defer func() {
+		if e := dst.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	if err = bolt.Compact(dst, src, _compactTxMaxSize); err != nil {
+		return errors.Wrapf(err, "failed to compact %s into %s", srcFile, dstFile)
+	}
+	dstInfo, err := os.Stat(dstFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat %s", dstFile)
+	}
+	fmt.Printf("Compact db %s -> %s: %d -> %d bytes.\n", srcFile, dstFile, srcInfo.Size(), dstInfo.Size())
+	return nil
+}
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	verifyDbCmdShorts = map[string]string{
+		"english": "Sub-Command for integrity check of IoTeX blockchain db files.",
+		"chinese": "检查IoTeX区块链 db 文件完整性的子命令",
+	}
+	verifyDbCmdLongs = map[string]string{
+		"english": "Sub-Command for integrity check of IoTeX blockchain db files. It walks the account trie from the tip state root to check every referenced node exists, or decodes every account if the state db has no trie, and cross-checks the block index against the chain db.",
+		"chinese": "检查IoTeX区块链 db 文件完整性的子命令。从最新状态根遍历账户树以检查所有引用的节点是否存在（若状态数据库没有账户树则解码所有账户），并将区块索引与链数据库进行交叉核对。",
+	}
+	verifyDbCmdUse = map[string]string{
+		"english": "verify",
+		"chinese": "verify",
+	}
+	verifyDbFlagStateFileUse = map[string]string{
+		"english": "The state db file (trie.db) you want to verify.",
+		"chinese": "您要检查的状态 db 文件（trie.db）。",
+	}
+	verifyDbFlagIndexFileUse = map[string]string{
+		"english": "The block index db file (index.db) you want to verify, requires --chain-file.",
+		"chinese": "您要检查的区块索引 db 文件（index.db），需要同时指定 --chain-file。",
+	}
+	verifyDbFlagChainFileUse = map[string]string{
+		"english": "The chain db file (chain.db) the block index is checked against.",
+		"chinese": "用于核对区块索引的链 db 文件（chain.db）。",
+	}
+)
+
+var (
+	// VerifyDb Used to Sub command.
+	VerifyDb = &cobra.Command{
+		Use:   common.TranslateInLang(verifyDbCmdUse),
+		Short: common.TranslateInLang(verifyDbCmdShorts),
+		Long:  common.TranslateInLang(verifyDbCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verifyDbFiles()
+		},
+	}
+)
+
+var (
+	stateFile = ""
+	indexFile = ""
+	chainFile = ""
+)
+
+func init() {
+	VerifyDb.PersistentFlags().StringVarP(&stateFile, "state-file", "s", "", common.TranslateInLang(verifyDbFlagStateFileUse))
+	VerifyDb.PersistentFlags().StringVarP(&indexFile, "index-file", "i", "", common.TranslateInLang(verifyDbFlagIndexFileUse))
+	VerifyDb.PersistentFlags().StringVarP(&chainFile, "chain-file", "c", "", common.TranslateInLang(verifyDbFlagChainFileUse))
+}
+
+func verifyDbFiles() error {
+	// Check flags
+	if stateFile == "" && indexFile == "" {
+		return fmt.Errorf("either --state-file or --index-file should be set")
+	}
+	if indexFile != "" && chainFile == "" {
+		return fmt.Errorf("--chain-file is empty")
+	}
+	if stateFile != "" {
+		if err := verifyStateDb(stateFile); err != nil {
+			fmt.Printf("Verify state db %s err: %v\n", stateFile, err)
+			return err
+		}
+	}
+	if indexFile != "" {
+		if err := verifyIndexDb(indexFile, chainFile); err != nil {
+			fmt.Printf("Verify index db %s err: %v\n", indexFile, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func readOnlyKVStore(path string) *db.BoltDB {
+	cfg := db.DefaultConfig
+	cfg.DbPath = path
+	cfg.ReadOnly = true
+	return db.NewBoltDB(cfg)
+}
+
+func verifyStateDb(filePath string) (err error) {
+	kv := readOnlyKVStore(filePath)
+	if err := kv.Start(context.Background()); err != nil {
+		return errors.Wrapf(err, "failed to open %s", filePath)
+	}
+	defer func() {
+		if e := kv.Stop(context.Background()); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	h, err := kv.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+	if err != nil {
+		return errors.Wrap(err, "failed to read state db height")
+	}
+	trieKV, err := trie.NewKVStore(factory.ArchiveTrieNamespace, kv)
+	if err != nil {
+		return err
+	}
+	rootHash, err := trieKV.Get([]byte(factory.ArchiveTrieRootKey))
+	switch errors.Cause(err) {
+	case nil:
+	case trie.ErrNotExist:
+		// the state db without the archive trie keeps the accounts in the flat namespace
+		return verifyFlatStateDb(kv, filePath, byteutil.BytesToUint64(h))
+	default:
+		return errors.Wrap(err, "failed to read state root")
+	}
+
+	layerOneLeaves, layerTwoLeaves := 0, 0
+	if err := walkTrieLeaves(trieKV, rootHash, func(_, value []byte) error {
+		layerOneLeaves++
+		return walkTrieLeaves(trieKV, value, func(_, _ []byte) error {
+			layerTwoLeaves++
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	fmt.Printf(
+		"Verify state db %s at height %d: root %x, %d namespaces, %d states, ok.\n",
+		filePath,
+		byteutil.BytesToUint64(h),
+		rootHash,
+		layerOneLeaves,
+		layerTwoLeaves,
+	)
+	return nil
+}
+
+// verifyFlatStateDb checks every account in the flat account namespace can be decoded
+func verifyFlatStateDb(kv *db.BoltDB, filePath string, height uint64) error {
+	var (
+		accounts int
+		errAcct  error
+	)
+	// the accounts are checked in the filter without collecting them, to keep the memory bounded
+	_, _, err := kv.Filter(factory.AccountKVNamespace, func(k, v []byte) bool {
+		if errAcct != nil || string(k) == factory.CurrentHeightKey {
+			return false
+		}
+		if err := (&state.Account{}).Deserialize(v); err != nil {
+			errAcct = errors.Wrapf(err, "failed to decode account %x", k)
+			return false
+		}
+		accounts++
+		return false
+	}, nil, nil)
+	if errAcct != nil {
+		return errAcct
+	}
+	if err != nil && errors.Cause(err) != db.ErrNotExist {
+		return errors.Wrap(err, "failed to read accounts")
+	}
+	if accounts == 0 {
+		return errors.Errorf("no account trie or account found in %s", filePath)
+	}
+	fmt.Printf("Verify state db %s at height %d: %d accounts, ok.\n", filePath, height, accounts)
+	return nil
+}
+
+// walkTrieLeaves loads every node reachable from rootHash, and calls fn on each leaf
+func walkTrieLeaves(kv trie.KVStore, rootHash []byte, fn func([]byte, []byte) error) error {
+	tr, err := mptrie.New(mptrie.KVStoreOption(kv), mptrie.RootHashOption(rootHash))
+	if err != nil {
+		return err
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return errors.Wrapf(err, "failed to load trie root %x", rootHash)
+	}
+	iter, err := mptrie.NewLeafIterator(tr)
+	if err != nil {
+		return err
+	}
+	for {
+		key, value, err := iter.Next()
+		switch errors.Cause(err) {
+		case nil:
+		case trie.ErrEndOfIterator:
+			return nil
+		default:
+			return errors.Wrapf(err, "failed to walk trie root %x", rootHash)
+		}
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+}
+
+func verifyIndexDb(indexPath, chainPath string) (err error) {
+	cfg, err := config.New([]string{}, []string{})
+	if err != nil {
+		return fmt.Errorf("failed to new config: %v", err)
+	}
+
+	indexer, err := blockindex.NewIndexer(readOnlyKVStore(indexPath), cfg.Genesis.Hash())
+	if err != nil {
+		return err
+	}
+	cfg.DB.DbPath = chainPath
+	cfg.DB.ReadOnly = true
+	dao, err := filedao.NewFileDAO(cfg.DB, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create dao from %s", chainPath)
+	}
+
+	ctx := context.Background()
+	if err := indexer.Start(ctx); err != nil {
+		return errors.Wrapf(err, "failed to start indexer from %s", indexPath)
+	}
+	defer func() {
+		if e := indexer.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+	if err := dao.Start(ctx); err != nil {
+		return errors.Wrapf(err, "failed to start dao from %s", chainPath)
+	}
+	defer func() {
+		if e := dao.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	indexHeight, err := indexer.Height()
+	if err != nil {
+		return err
+	}
+	daoHeight, err := dao.Height()
+	if err != nil {
+		return err
+	}
+	if indexHeight > daoHeight {
+		return fmt.Errorf("index height %d is larger than chain height %d", indexHeight, daoHeight)
+	}
+	for i := uint64(1); i <= indexHeight; i++ {
+		indexHash, err := indexer.GetBlockHash(i)
+		if err != nil {
+			return fmt.Errorf("failed to get indexed block hash on height %d: %v", i, err)
+		}
+		daoHash, err := dao.GetBlockHash(i)
+		if err != nil {
+			return fmt.Errorf("failed to get block hash on height %d: %v", i, err)
+		}
+		if indexHash != daoHash {
+			return fmt.Errorf("block hash mismatch on height %d: index %x, chain %x", i, indexHash, daoHash)
+		}
+		blkIndex, err := indexer.GetBlockIndex(i)
+		if err != nil {
+			return fmt.Errorf("failed to get block index on height %d: %v", i, err)
+		}
+		blk, err := dao.GetBlockByHeight(i)
+		if err != nil {
+			return fmt.Errorf("failed to get block on height %d: %v", i, err)
+		}
+		if blkIndex.NumAction() != uint32(len(blk.Actions)) {
+			return fmt.Errorf("action count mismatch on height %d: index %d, chain %d", i, blkIndex.NumAction(), len(blk.Actions))
+		}
+	}
+	fmt.Printf("Verify index db %s against %s: index height %d, chain height %d, ok.\n", indexPath, chainPath, indexHeight, daoHeight)
+	return nil
+}
@@ -47,6 +47,8 @@ func Execute() {
 func init() {
 	RootCmd.AddCommand(cmd.CheckHeight)
 	RootCmd.AddCommand(cmd.MigrateDb)
+	RootCmd.AddCommand(cmd.CompactDb)
+	RootCmd.AddCommand(cmd.VerifyDb)
 
 	RootCmd.HelpFunc()
 }
@@ -3,8 +3,10 @@
 // or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
 // This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
 
-// This is a recovery tool that recovers a corrupted or missing state database.
-// To use, run "make recover"
+// This is a recovery tool that rolls back the chain to a target height, including the blocks, the state
+// database and the indexers. It requires the node to have run with the trieless state db and
+// chain.rollbackRetention > 0, and the target height could be at most rollbackRetention blocks lower than the tip.
+// To use, run "make recover RECOVERY_HEIGHT=[height]"
 package main
 
 import (
@@ -15,18 +17,20 @@ import (
 	"os"
 	"strings"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/server/itx"
-	"github.com/iotexproject/iotex-core/state/factory"
 )
 
 // recoveryHeight is the blockchain height being recovered to
-var recoveryHeight int
+var recoveryHeight uint64
 
 /**
  * overwritePath is the path to the config file which overwrite default values
@@ -55,7 +59,7 @@ func init() {
 	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
 	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
 	flag.Var(&_plugins, "plugin", "Plugin of the node")
-	flag.IntVar(&recoveryHeight, "recovery-height", 0, "Recovery height")
+	flag.Uint64Var(&recoveryHeight, "recovery-height", 0, "Recovery height, required")
 	flag.Usage = func() {
 		_, _ = fmt.Fprintf(os.Stderr,
 			"usage: recover -config-path=[string]\n -recovery-height=[int]\n")
@@ -66,6 +70,10 @@ func init() {
 }
 
 func main() {
+	if recoveryHeight == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "recovery-height is required, and must be greater than 0")
+		flag.Usage()
+	}
 	genesisCfg, err := genesis.New(genesisPath)
 	if err != nil {
 		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
@@ -77,6 +85,9 @@ func main() {
 	}
 
 	cfg.Genesis = genesisCfg
+	if !cfg.Chain.EnableTrielessStateDB {
+		glog.Fatalln("Recovery is only supported by trieless state DB, the trie-based state factory cannot revert blocks.")
+	}
 
 	log.S().Infof("Config in use: %+v", cfg)
 
@@ -87,43 +98,75 @@ func main() {
 	}
 
 	// recover chain and state
-	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
-	sf := svr.ChainService(cfg.Chain.ID).StateFactory()
-	dao := svr.ChainService(cfg.Chain.ID).BlockDAO()
-	if err := bc.Start(context.Background()); err == nil {
-		log.L().Info("State DB status is normal.")
+	cs := svr.ChainService(cfg.Chain.ID)
+	bc := cs.Blockchain()
+	ctx := protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(
+		protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{
+			ChainID:      bc.ChainID(),
+			EvmNetworkID: bc.EvmNetworkID(),
+		}),
+		cfg.Genesis,
+	))
+	if err := bc.Start(ctx); err != nil {
+		log.L().Fatal("Failed to start blockchain.", zap.Error(err))
 	}
 	defer func() {
-		if err := bc.Stop(context.Background()); err != nil {
+		if err := bc.Stop(ctx); err != nil {
 			log.L().Fatal("Failed to stop blockchain")
 		}
 	}()
-	if err := recoverChainAndState(dao, sf, cfg, uint64(recoveryHeight)); err != nil {
+	// the async indexers are not managed by blockDAO
+	if journal := cs.IndexJournal(); journal != nil {
+		if err := journal.Start(ctx); err != nil {
+			log.L().Fatal("Failed to start index journal.", zap.Error(err))
+		}
+		defer func() {
+			if err := journal.Stop(ctx); err != nil {
+				log.L().Fatal("Failed to stop index journal")
+			}
+		}()
+	}
+	builders := cs.IndexBuilders()
+	for _, ib := range builders {
+		indexer := ib.Indexer()
+		if err := indexer.Start(ctx); err != nil {
+			log.L().Fatal("Failed to start async indexer.", zap.String("indexer", ib.Name()), zap.Error(err))
+		}
+		defer func() {
+			if err := indexer.Stop(ctx); err != nil {
+				log.L().Fatal("Failed to stop async indexer")
+			}
+		}()
+	}
+	if err := recoverChainAndState(ctx, cs.BlockDAO(), builders, recoveryHeight); err != nil {
 		log.L().Fatal("Failed to recover chain and state.", zap.Error(err))
 	} else {
 		log.S().Infof("Success to recover chain and state to target height %d", recoveryHeight)
 	}
 }
 
-// recoverChainAndState recovers the chain to target height and refresh state db if necessary
-func recoverChainAndState(dao blockdao.BlockDAO, sf factory.Factory, cfg config.Config, targetHeight uint64) error {
-	// TODO: not all indexers could be reverted, e.g., state factory. Thus, dao.DeleteTipBlockToTarget will always
-	// return error. Therefore, it is not hard to tell that this tool is not working. The right way is to revert indexer
-	// one by one. Before we implement it in that way, comment out the following code.
-	/*
-		if err := dao.DeleteBlockToTarget(targetHeight); err != nil {
-			return errors.Wrapf(err, "failed to recover blockchain to target height %d", targetHeight)
-		}
-		stateHeight, err := sf.Height()
-		if err != nil {
-			return err
-		}
-		if targetHeight < stateHeight {
-			// delete existing state DB (build from scratch)
-			if fileutil.FileExists(cfg.Chain.TrieDBPath) && os.Remove(cfg.Chain.TrieDBPath) != nil {
-				return errors.New("failed to delete existing state DB")
-			}
+// recoverChainAndState rolls back the chain to the target height, the blocks above the target height are
+// deleted from the chain db, and the state db and all indexers are reverted to the target height
+func recoverChainAndState(ctx context.Context, dao blockdao.BlockDAO, builders []*blockindex.IndexBuilder, targetHeight uint64) error {
+	remover, ok := dao.(blockdao.BlockRemover)
+	if !ok {
+		return errors.Errorf("blockDAO %T does not support deleting blocks", dao)
+	}
+	tip, err := dao.Height()
+	if err != nil {
+		return err
+	}
+	if targetHeight == 0 || targetHeight >= tip {
+		return errors.Errorf("invalid recovery height %d, must be in [1, %d)", targetHeight, tip)
+	}
+	// revert the async indexers first, which read the blocks to delete from blockDAO
+	for _, ib := range builders {
+		if err := ib.RevertTo(ctx, targetHeight); err != nil {
+			return errors.Wrapf(err, "failed to revert indexer %s", ib.Name())
 		}
-	*/
-	panic("not implemented")
+	}
+	if err := remover.DeleteBlockToTarget(ctx, targetHeight); err != nil {
+		return errors.Wrapf(err, "failed to recover blockchain to target height %d", targetHeight)
+	}
+	return nil
 }